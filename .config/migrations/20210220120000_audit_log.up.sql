@@ -0,0 +1,11 @@
+CREATE TABLE `audit_log` (
+	`id` INT UNSIGNED NOT NULL AUTO_INCREMENT,
+	`member_id` INT UNSIGNED NOT NULL,
+	`action` VARCHAR(64) NOT NULL,
+	`source` VARCHAR(32) NOT NULL,
+	`details` TEXT NULL DEFAULT NULL,
+	`created_at` TIMESTAMP NOT NULL,
+	PRIMARY KEY (`id`) USING BTREE,
+	INDEX `audit_log_member_id_idx` (`member_id`) USING BTREE,
+	INDEX `audit_log_action_idx` (`action`) USING BTREE
+) COLLATE = 'utf8mb4_unicode_ci' ENGINE = InnoDB;
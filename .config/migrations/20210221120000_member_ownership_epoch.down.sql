@@ -0,0 +1,3 @@
+ALTER TABLE `members`
+	DROP COLUMN `owner_changed_at`,
+	DROP COLUMN `ownership_epoch`;
@@ -0,0 +1,3 @@
+ALTER TABLE `members`
+	ADD COLUMN `ownership_epoch` INT UNSIGNED NOT NULL DEFAULT '0' AFTER `owner_hash`,
+	ADD COLUMN `owner_changed_at` TIMESTAMP NULL DEFAULT NULL AFTER `ownership_epoch`;
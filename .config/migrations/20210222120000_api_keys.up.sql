@@ -0,0 +1,17 @@
+CREATE TABLE `api_keys` (
+	`id` INT UNSIGNED NOT NULL AUTO_INCREMENT,
+	`name` VARCHAR(64) NOT NULL,
+	`prefix` VARCHAR(16) NOT NULL,
+	`hash` CHAR(64) NOT NULL,
+	`allowed_queries` JSON NOT NULL,
+	`allowed_members` JSON NOT NULL,
+	`rate_limit` INT UNSIGNED NOT NULL,
+	`created_by` INT UNSIGNED NULL DEFAULT NULL,
+	`revoked` TINYINT UNSIGNED NOT NULL DEFAULT '0',
+	`revoked_at` TIMESTAMP NULL DEFAULT NULL,
+	`created_at` TIMESTAMP NOT NULL,
+	`updated_at` TIMESTAMP NOT NULL,
+	PRIMARY KEY (`id`) USING BTREE,
+	UNIQUE INDEX `api_keys_hash_unique_idx` (`hash`) USING BTREE,
+	INDEX `api_keys_created_by_idx` (`created_by`) USING BTREE
+) COLLATE = 'utf8mb4_unicode_ci' ENGINE = InnoDB;
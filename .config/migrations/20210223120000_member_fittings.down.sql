@@ -0,0 +1 @@
+DROP TABLE `member_fittings`;
\ No newline at end of file
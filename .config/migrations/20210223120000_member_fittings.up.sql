@@ -0,0 +1,13 @@
+CREATE TABLE `member_fittings` (
+    `member_id` INT UNSIGNED NOT NULL,
+    `fitting_id` INT UNSIGNED NOT NULL,
+    `ship_type_id` INT UNSIGNED NOT NULL,
+    `name` VARCHAR(255) NOT NULL,
+    `description` TEXT NOT NULL,
+    `item_hash` VARCHAR(64) NOT NULL,
+    `created_at` TIMESTAMP NOT NULL,
+    `updated_at` TIMESTAMP NOT NULL,
+    PRIMARY KEY (`member_id`, `fitting_id`) USING BTREE,
+    INDEX `member_fittings_ship_type_id_idx` (`ship_type_id`) USING BTREE,
+    CONSTRAINT `member_fittings_member_id_foreign` FOREIGN KEY (`member_id`) REFERENCES `athena`.`members` (`id`) ON UPDATE CASCADE ON DELETE CASCADE
+) COLLATE = 'utf8mb4_unicode_ci' ENGINE = InnoDB;
@@ -0,0 +1 @@
+DROP TABLE `member_fitting_items`;
\ No newline at end of file
@@ -0,0 +1,12 @@
+CREATE TABLE `member_fitting_items` (
+    `member_id` INT UNSIGNED NOT NULL,
+    `fitting_id` INT UNSIGNED NOT NULL,
+    `type_id` INT UNSIGNED NOT NULL,
+    `quantity` INT UNSIGNED NOT NULL,
+    `flag` VARCHAR(32) NOT NULL,
+    `created_at` TIMESTAMP NOT NULL,
+    `updated_at` TIMESTAMP NOT NULL,
+    PRIMARY KEY (`member_id`, `fitting_id`, `flag`, `type_id`) USING BTREE,
+    INDEX `member_fitting_items_type_id_idx` (`type_id`) USING BTREE,
+    CONSTRAINT `member_fitting_items_fitting_foreign` FOREIGN KEY (`member_id`, `fitting_id`) REFERENCES `athena`.`member_fittings` (`member_id`, `fitting_id`) ON UPDATE CASCADE ON DELETE CASCADE
+) COLLATE = 'utf8mb4_unicode_ci' ENGINE = InnoDB;
@@ -0,0 +1,2 @@
+ALTER TABLE `types`
+	DROP INDEX `types_published_name_idx`;
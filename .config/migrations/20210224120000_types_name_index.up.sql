@@ -0,0 +1,2 @@
+ALTER TABLE `types`
+	ADD INDEX `types_published_name_idx` (`published`, `name`) USING BTREE;
@@ -0,0 +1,2 @@
+ALTER TABLE `map_solar_systems`
+	DROP INDEX `solar_systems_name_idx`;
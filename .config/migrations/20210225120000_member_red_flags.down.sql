@@ -0,0 +1 @@
+DROP TABLE `member_red_flags`;
\ No newline at end of file
@@ -0,0 +1,14 @@
+CREATE TABLE `member_red_flags` (
+    `member_id` INT UNSIGNED NOT NULL,
+    `rule_id` VARCHAR(64) NOT NULL,
+    `entity` VARCHAR(32) NOT NULL,
+    `entity_id` VARCHAR(64) NOT NULL,
+    `severity` VARCHAR(16) NOT NULL,
+    `description` VARCHAR(255) NOT NULL,
+    `value` VARCHAR(255) NOT NULL,
+    `created_at` TIMESTAMP NOT NULL,
+    `updated_at` TIMESTAMP NOT NULL,
+    PRIMARY KEY (`member_id`, `rule_id`, `entity_id`) USING BTREE,
+    INDEX `member_red_flags_severity_idx` (`severity`) USING BTREE,
+    CONSTRAINT `member_red_flags_member_id_foreign` FOREIGN KEY (`member_id`) REFERENCES `athena`.`members` (`id`) ON UPDATE CASCADE ON DELETE CASCADE
+) COLLATE = 'utf8mb4_unicode_ci' ENGINE = InnoDB;
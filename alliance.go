@@ -30,3 +30,5 @@ type Alliance struct {
 }
 
 func (Alliance) IsContactInfo() {}
+func (Alliance) IsWalletParty() {}
+func (Alliance) IsMailParty()   {}
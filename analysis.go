@@ -0,0 +1,124 @@
+package athena
+
+import (
+	"time"
+
+	"github.com/volatiletech/null"
+)
+
+// CorporationHistoryAnalysis summarizes the corporation history of a character. Durations are
+// measured in whole days, and the stint in the current corporation is measured up until now
+type CorporationHistoryAnalysis struct {
+	CharacterID           uint                         `json:"character_id"`
+	Corporations          uint                         `json:"corporations"`
+	NPCCorporationDays    uint                         `json:"npc_corporation_days"`
+	PlayerCorporationDays uint                         `json:"player_corporation_days"`
+	HopsLast6Months       uint                         `json:"hops_last_6_months"`
+	HopsLast12Months      uint                         `json:"hops_last_12_months"`
+	AverageTenureDays     float64                      `json:"average_tenure_days"`
+	CurrentTenureDays     uint                         `json:"current_tenure_days"`
+	Returns               []*CorporationReturn         `json:"returns"`
+	HostileOverlaps       []*HostileCorporationOverlap `json:"hostile_overlaps"`
+}
+
+// CorporationReturn is a corporation that a character has been a member of more than once
+type CorporationReturn struct {
+	CorporationID uint `json:"corporation_id"`
+	Stints        uint `json:"stints"`
+	TotalDays     uint `json:"total_days"`
+}
+
+// HostileCorporationOverlap is a stint of a character in a corporation that is considered hostile
+type HostileCorporationOverlap struct {
+	CorporationID uint      `json:"corporation_id"`
+	StartDate     time.Time `json:"start_date"`
+	EndDate       null.Time `json:"end_date"`
+	TenureDays    uint      `json:"tenure_days"`
+}
+
+// WalletJournalAnalysis summarizes the wallet journal of a member over a range of dates. Entries
+// without an amount are counted but do not contribute to any of the totals
+type WalletJournalAnalysis struct {
+	MemberID          uint                         `json:"member_id"`
+	From              null.Time                    `json:"from"`
+	To                null.Time                    `json:"to"`
+	Entries           uint                         `json:"entries"`
+	Income            float64                      `json:"income"`
+	Expense           float64                      `json:"expense"`
+	Net               float64                      `json:"net"`
+	Months            []*WalletJournalMonth        `json:"months"`
+	TopCounterparties []*WalletJournalCounterparty `json:"top_counterparties"`
+	Anomalies         []*WalletJournalAnomaly      `json:"anomalies"`
+}
+
+// WalletJournalMonth is the income and expense of a calendar month, broken down by the category of
+// the ref type of each entry. Month is the first day of the month in UTC
+type WalletJournalMonth struct {
+	Month      time.Time                       `json:"month"`
+	Entries    uint                            `json:"entries"`
+	Income     float64                         `json:"income"`
+	Expense    float64                         `json:"expense"`
+	Net        float64                         `json:"net"`
+	Categories []*WalletJournalCategorySummary `json:"categories"`
+}
+
+type WalletJournalCategorySummary struct {
+	Category WalletJournalCategory `json:"category"`
+	Entries  uint                  `json:"entries"`
+	Income   float64               `json:"income"`
+	Expense  float64               `json:"expense"`
+	Net      float64               `json:"net"`
+}
+
+// WalletJournalCounterparty is a player character, player corporation or alliance that the member
+// has exchanged ISK with. Volume is the sum of the ISK received from and sent to the party
+type WalletJournalCounterparty struct {
+	PartyID   uint    `json:"party_id"`
+	PartyType string  `json:"party_type"`
+	Entries   uint    `json:"entries"`
+	Received  float64 `json:"received"`
+	Sent      float64 `json:"sent"`
+	Volume    float64 `json:"volume"`
+}
+
+// WalletJournalAnomaly is a journal entry that stands out from the rest of the journal of the member
+type WalletJournalAnomaly struct {
+	JournalID        uint64                   `json:"journal_id"`
+	Kind             WalletJournalAnomalyKind `json:"kind"`
+	RefType          RefType                  `json:"ref_type"`
+	Amount           float64                  `json:"amount"`
+	CounterpartyID   null.Uint                `json:"counterparty_id"`
+	CounterpartyType null.String              `json:"counterparty_type"`
+	Date             time.Time                `json:"date"`
+	Description      string                   `json:"description"`
+}
+
+type WalletJournalAnomalyKind string
+
+const (
+	// WalletJournalAnomalyKindLargeDonation is a donation that is many times larger than the typical
+	// entry in the journal of the member
+	WalletJournalAnomalyKindLargeDonation WalletJournalAnomalyKind = "large_donation"
+	// WalletJournalAnomalyKindPreApplicationTransfer is a large donation that was received or sent
+	// shortly before the member registered
+	WalletJournalAnomalyKindPreApplicationTransfer WalletJournalAnomalyKind = "pre_application_transfer"
+)
+
+var AllWalletJournalAnomalyKinds = []WalletJournalAnomalyKind{
+	WalletJournalAnomalyKindLargeDonation,
+	WalletJournalAnomalyKindPreApplicationTransfer,
+}
+
+func (i WalletJournalAnomalyKind) Valid() bool {
+	for _, v := range AllWalletJournalAnomalyKinds {
+		if i == v {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (i WalletJournalAnomalyKind) String() string {
+	return string(i)
+}
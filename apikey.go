@@ -0,0 +1,100 @@
+package athena
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/volatiletech/null"
+)
+
+type APIKeyRepository interface {
+	APIKey(ctx context.Context, id uint) (*APIKey, error)
+	APIKeys(ctx context.Context, operators ...*Operator) ([]*APIKey, error)
+	CreateAPIKey(ctx context.Context, key *APIKey) (*APIKey, error)
+	UpdateAPIKey(ctx context.Context, id uint, key *APIKey) (*APIKey, error)
+}
+
+// APIKey authenticates a service that consumes the GraphQL API on behalf of no particular member.
+// Only a hash of the key is stored, the key itself is returned once when it is created. An empty
+// AllowedQueries or AllowedMembers list places no restriction on the queries or members respectively.
+// Keys without a member restriction can only be created with the CLI.
+type APIKey struct {
+	ID             uint        `db:"id" json:"id"`
+	Name           string      `db:"name" json:"name"`
+	Prefix         string      `db:"prefix" json:"prefix"`
+	Hash           string      `db:"hash" json:"hash"`
+	AllowedQueries SliceString `db:"allowed_queries" json:"allowed_queries"`
+	AllowedMembers SliceUint   `db:"allowed_members" json:"allowed_members"`
+	RateLimit      uint        `db:"rate_limit" json:"rate_limit"`
+	CreatedBy      null.Uint   `db:"created_by,omitempty" json:"created_by"`
+	Revoked        bool        `db:"revoked" json:"revoked"`
+	RevokedAt      null.Time   `db:"revoked_at,omitempty" json:"revoked_at"`
+	CreatedAt      time.Time   `db:"created_at" json:"created_at"`
+	UpdatedAt      time.Time   `db:"updated_at" json:"updated_at"`
+}
+
+// AllowsQuery reports whether the key may execute the root field identified by name
+func (k *APIKey) AllowsQuery(name string) bool {
+	if len(k.AllowedQueries) == 0 {
+		return true
+	}
+
+	for _, q := range k.AllowedQueries {
+		if q == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AllowsMember reports whether the key may access the data of the member identified by memberID
+func (k *APIKey) AllowsMember(memberID uint) bool {
+	if len(k.AllowedMembers) == 0 {
+		return true
+	}
+
+	for _, m := range k.AllowedMembers {
+		if m == uint64(memberID) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type SliceString []string
+
+func (s *SliceString) Scan(value interface{}) error {
+
+	switch data := value.(type) {
+	case []byte:
+		err := json.Unmarshal(data, s)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+
+}
+
+func (s SliceString) Value() (driver.Value, error) {
+
+	var data []byte
+	var err error
+	if len(s) == 0 {
+		data, err = json.Marshal([]interface{}{})
+	} else {
+		data, err = json.Marshal([]string(s))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("[SliceString] Failed to marshal slice of strings for storage in data store: %w", err)
+	}
+
+	return data, nil
+
+}
@@ -8,6 +8,7 @@ import (
 type MemberAssetsRepository interface {
 	MemberAsset(ctx context.Context, memberID uint, itemID uint64) (*MemberAsset, error)
 	MemberAssets(ctx context.Context, memberID uint, operators ...*Operator) ([]*MemberAsset, error)
+	CountMemberAssets(ctx context.Context, memberID uint, operators ...*Operator) (uint, error)
 	CreateMemberAssets(ctx context.Context, memberID uint, assets []*MemberAsset) ([]*MemberAsset, error)
 	UpdateMemberAssets(ctx context.Context, memberID uint, itemID uint64, asset *MemberAsset) (*MemberAsset, error)
 	DeleteMemberAssets(ctx context.Context, memberID uint, assets []*MemberAsset) (bool, error)
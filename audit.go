@@ -0,0 +1,47 @@
+package athena
+
+import (
+	"context"
+	"time"
+
+	"github.com/volatiletech/null"
+)
+
+type AuditRepository interface {
+	AuditEntries(ctx context.Context, operators ...*Operator) ([]*AuditEntry, error)
+	CreateAuditEntry(ctx context.Context, entry *AuditEntry) (*AuditEntry, error)
+}
+
+// AuditEntry records an action that was taken against a member. Entries outlive
+// the member they reference, so MemberID is intentionally not a foreign key.
+type AuditEntry struct {
+	ID        uint        `db:"id" json:"id"`
+	MemberID  uint        `db:"member_id" json:"member_id"`
+	Action    AuditAction `db:"action" json:"action"`
+	Source    AuditSource `db:"source" json:"source"`
+	Details   null.String `db:"details" json:"details"`
+	CreatedAt time.Time   `db:"created_at" json:"created_at"`
+}
+
+type AuditAction string
+
+const (
+	MemberPurgedAuditAction       AuditAction = "member_purged"
+	MemberOwnerChangedAuditAction AuditAction = "member_owner_changed"
+)
+
+func (a AuditAction) String() string {
+	return string(a)
+}
+
+type AuditSource string
+
+const (
+	GraphQLAuditSource AuditSource = "graphql"
+	CLIAuditSource     AuditSource = "cli"
+	SSOAuditSource     AuditSource = "sso"
+)
+
+func (a AuditSource) String() string {
+	return string(a)
+}
@@ -2,6 +2,7 @@ package athena
 
 import (
 	"context"
+	"time"
 
 	"github.com/volatiletech/null"
 )
@@ -16,10 +17,18 @@ type AuthRepository interface {
 }
 
 type AuthAttempt struct {
-	Status AuthAttemptStatus
-	State  string
-	Token  null.String
-	User   *Member
+	Status       AuthAttemptStatus
+	State        string
+	CodeVerifier string
+	Expires      time.Time
+	Token        null.String
+	RefreshToken null.String
+	User         *Member
+}
+
+// IsExpired reports whether the attempt can no longer be used to complete a login
+func (a *AuthAttempt) IsExpired() bool {
+	return !a.Expires.IsZero() && time.Now().After(a.Expires)
 }
 
 type AuthAttemptStatus string
@@ -35,3 +44,23 @@ const (
 func (a AuthAttemptStatus) String() string {
 	return string(a)
 }
+
+// Session is an Athena issued login session for a member. Sessions are stored in Redis
+// and deleting one revokes every access token that was minted for it.
+type Session struct {
+	ID          string    `json:"id"`
+	MemberID    uint      `json:"member_id"`
+	RefreshHash string    `json:"refresh_hash"`
+	Expires     time.Time `json:"expires"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// SessionToken is the pair of tokens handed to a client for a Session. The AccessToken is
+// a signed JWT that is presented as the bearer on API requests, the RefreshToken is an opaque
+// value that can be exchanged for a new pair before the Session expires.
+type SessionToken struct {
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token"`
+	Expires      time.Time `json:"expires"`
+}
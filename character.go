@@ -2,6 +2,7 @@ package athena
 
 import (
 	"context"
+	"sort"
 	"time"
 
 	"github.com/volatiletech/null"
@@ -24,6 +25,19 @@ type characterHistoryRepository interface {
 	CreateCharacterCorporationHistory(ctx context.Context, id uint, records []*CharacterCorporationHistory) ([]*CharacterCorporationHistory, error)
 }
 
+const (
+	// NPC characters, such as mission agents, are issued IDs from a reserved range that player
+	// characters never use
+	npcCharacterIDMin = 3000000
+	npcCharacterIDMax = 4000000
+)
+
+// IsNPCCharacter reports whether the character is an NPC, such as the agent that pays out the
+// reward of a mission
+func IsNPCCharacter(id uint) bool {
+	return id >= npcCharacterIDMin && id < npcCharacterIDMax
+}
+
 type Character struct {
 	ID             uint         `db:"id" json:"id"`
 	Name           string       `db:"name" json:"name"`
@@ -42,6 +56,8 @@ type Character struct {
 }
 
 func (Character) IsContactInfo() {}
+func (Character) IsWalletParty() {}
+func (Character) IsMailParty()   {}
 
 type CharacterCorporationHistory struct {
 	CharacterID   uint      `db:"character_id" json:"character_id"`
@@ -49,6 +65,38 @@ type CharacterCorporationHistory struct {
 	CorporationID uint      `db:"corporation_id" json:"corporation_id"`
 	IsDeleted     bool      `db:"is_deleted" json:"is_deleted"`
 	StartDate     time.Time `db:"start_date" json:"start_date"`
+	EndDate       null.Time `db:"-" json:"-"`
 	CreatedAt     time.Time `db:"created_at" json:"created_at"`
 	UpdatedAt     time.Time `db:"updated_at" json:"updated_at"`
 }
+
+// Tenure returns how long the character was a member of the corporation. Records without an
+// end date are the current corporation of the character and are measured up until now
+func (h *CharacterCorporationHistory) Tenure() time.Duration {
+	if h.EndDate.Valid {
+		return h.EndDate.Time.Sub(h.StartDate)
+	}
+
+	return time.Since(h.StartDate)
+}
+
+// ResolveCharacterCorporationHistory orders the history of a single character newest first and sets
+// the end date of every record to the start date of the record that followed it
+func ResolveCharacterCorporationHistory(history []*CharacterCorporationHistory) []*CharacterCorporationHistory {
+	sort.SliceStable(history, func(i, j int) bool {
+		if !history[i].StartDate.Equal(history[j].StartDate) {
+			return history[i].StartDate.After(history[j].StartDate)
+		}
+
+		return history[i].RecordID > history[j].RecordID
+	})
+
+	for i, record := range history {
+		record.EndDate = null.Time{}
+		if i > 0 {
+			record.EndDate = null.TimeFrom(history[i-1].StartDate)
+		}
+	}
+
+	return history
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eveisesi/athena/internal/apikey"
+	"github.com/eveisesi/athena/internal/cache"
+	"github.com/urfave/cli"
+	"github.com/volatiletech/null"
+)
+
+func createAPIKeyCommand(c *cli.Context) error {
+
+	basics := basics("apikey-create")
+	var ctx = context.Background()
+
+	cache := cache.NewService(basics.redis)
+	apikey := apikey.NewService(basics.logger, cache, basics.repositories.apikey, basics.cfg.APIKey.Admins, basics.cfg.APIKey.Recruiters)
+
+	members := make([]uint, 0, len(c.Int64Slice("member")))
+	for _, m := range c.Int64Slice("member") {
+		members = append(members, uint(m))
+	}
+
+	key, plain, err := apikey.CreateAPIKey(ctx, c.String("name"), c.StringSlice("query"), members, c.Uint("rate-limit"), null.Uint{})
+	if err != nil {
+		basics.logger.WithError(err).Fatal("failed to create api key")
+	}
+
+	basics.logger.WithField("id", key.ID).Info("api key created successfully")
+
+	// The key can not be recovered once it has been hashed, so it is printed instead of logged
+	fmt.Println(plain)
+
+	return nil
+
+}
+
+func revokeAPIKeyCommand(c *cli.Context) error {
+
+	basics := basics("apikey-revoke")
+	var ctx = context.Background()
+
+	cache := cache.NewService(basics.redis)
+	apikey := apikey.NewService(basics.logger, cache, basics.repositories.apikey, basics.cfg.APIKey.Admins, basics.cfg.APIKey.Recruiters)
+
+	id := uint(c.Int64("id"))
+	err := apikey.RevokeAPIKey(ctx, id)
+	if err != nil {
+		basics.logger.WithError(err).WithField("id", id).Fatal("failed to revoke api key")
+	}
+
+	basics.logger.WithField("id", id).Info("api key revoked successfully")
+
+	return nil
+
+}
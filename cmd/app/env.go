@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/eveisesi/athena"
 	"github.com/joho/godotenv"
@@ -54,6 +55,13 @@ type config struct {
 
 	Server struct {
 		Port uint `required:"true"`
+
+		MaxComplexity int           `default:"5000"`
+		MaxDepth      int           `default:"10"`
+		RateLimit     uint          `default:"120"`
+		RateWindow    time.Duration `default:"1m"`
+		// TrustedProxies are the CIDRs of the reverse proxies whose X-Forwarded-For header is honoured
+		TrustedProxies []string `split_words:"true"`
 	}
 
 	Auth struct {
@@ -63,6 +71,36 @@ type config struct {
 		AuthorizationURL string `required:"true"`
 		TokenURL         string `required:"true"`
 		JWKSURL          string `required:"true"`
+
+		AttemptTTL time.Duration `default:"5m"`
+
+		SessionKey        string        `required:"true"`
+		SessionTTL        time.Duration `default:"1h"`
+		SessionRefreshTTL time.Duration `default:"720h"`
+	}
+
+	APIKey struct {
+		// Admins are the IDs of the members that may create API keys through the GraphQL API
+		Admins []uint
+		// Recruiters are the IDs of the members that may review the report, red flags and refreshes
+		// of any member. Admins are recruiters as well
+		Recruiters []uint
+	}
+
+	TokenRefresh struct {
+		Interval    time.Duration `default:"1m"`
+		Window      time.Duration `default:"5m"`
+		Concurrency int           `default:"10"`
+	}
+
+	RedFlag struct {
+		// Rules is the path to a YAML or JSON file of red flag rules. No red flags are raised without it
+		Rules string
+	}
+
+	Analysis struct {
+		// HostileCorporations are the IDs of the corporations whose members are treated as hostile
+		HostileCorporations []uint `split_words:"true"`
 	}
 
 	UserAgent string `required:"true"`
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/eveisesi/athena"
+	"github.com/eveisesi/athena/internal/apikey"
 	"github.com/eveisesi/athena/internal/mysqldb"
 	"github.com/go-redis/redis/v8"
 	"github.com/newrelic/go-agent/v3/newrelic"
@@ -33,6 +34,8 @@ type app struct {
 
 type repositories struct {
 	alliance    athena.AllianceRepository
+	apikey      athena.APIKeyRepository
+	audit       athena.AuditRepository
 	asset       athena.MemberAssetsRepository
 	character   athena.CharacterRepository
 	contact     athena.MemberContactRepository
@@ -40,10 +43,12 @@ type repositories struct {
 	corporation athena.CorporationRepository
 	clone       athena.CloneRepository
 	etag        athena.EtagRepository
+	fitting     athena.MemberFittingsRepository
 	location    athena.MemberLocationRepository
 	mail        athena.MailRepository
 	member      athena.MemberRepository
 	migration   athena.MigrationRepository
+	redflag     athena.RedFlagRepository
 	skill       athena.MemberSkillRepository
 	universe    athena.UniverseRepository
 	wallet      athena.MemberWalletRepository
@@ -95,10 +100,13 @@ func basics(command string) *app {
 	app.repositories = repositories{
 		member:      mysqldb.NewMemberRepository(app.db),
 		asset:       mysqldb.NewMemberAssetRepository(app.db),
+		audit:       mysqldb.NewAuditRepository(app.db),
 		character:   mysqldb.NewCharacterRepository(app.db),
 		corporation: mysqldb.NewCorporationRepository(app.db),
 		alliance:    mysqldb.NewAllianceRepository(app.db),
+		apikey:      mysqldb.NewAPIKeyRepository(app.db),
 		etag:        mysqldb.NewEtagRepository(app.db),
+		fitting:     mysqldb.NewFittingRepository(app.db),
 		universe:    mysqldb.NewUniverseRepository(app.db),
 		mail:        mysqldb.NewMailRepository(app.db),
 		migration:   mysqldb.NewMigrationRepository(app.db),
@@ -108,6 +116,7 @@ func basics(command string) *app {
 		contact:     mysqldb.NewMemberContactRepository(app.db),
 		wallet:      mysqldb.NewMemberWalletRepository(app.db),
 		contract:    mysqldb.NewMemberContractRepository(app.db),
+		redflag:     mysqldb.NewRedFlagRepository(app.db),
 	}
 
 	return &app
@@ -208,6 +217,11 @@ func main() {
 						},
 					},
 				},
+				{
+					Name:   "worker",
+					Usage:  "Periodically refreshes the tokens of members that are about to expire",
+					Action: tokenRefreshWorkerCommand,
+				},
 				{
 					Name:   "reset",
 					Action: resetMemberByCLI,
@@ -220,6 +234,83 @@ func main() {
 				},
 			},
 		},
+		{
+			Name:  "member",
+			Usage: "Commands for managing members",
+			Subcommands: []cli.Command{
+				{
+					Name:   "purge",
+					Usage:  "Permanently removes a member and all data that has been collected for the member",
+					Action: purgeMemberCommand,
+					Flags: []cli.Flag{
+						cli.Int64Flag{
+							Name:     "id",
+							Required: true,
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:   "report",
+			Usage:  "Generates a vetting report of a member and every alt that is linked to the member",
+			Action: reportCommand,
+			Flags: []cli.Flag{
+				cli.Int64Flag{
+					Name:     "member",
+					Required: true,
+				},
+				cli.StringFlag{
+					Name:  "format",
+					Usage: "format of the report, one of json, markdown or html",
+					Value: athena.ReportFormatMarkdown.String(),
+				},
+				cli.StringFlag{
+					Name:  "output",
+					Usage: "file to write the report to. Omit to write the report to stdout",
+				},
+			},
+		},
+		{
+			Name:  "apikey",
+			Usage: "Commands for managing API Keys",
+			Subcommands: []cli.Command{
+				{
+					Name:   "create",
+					Usage:  "Creates an API Key and prints it. The key can not be retrieved again",
+					Action: createAPIKeyCommand,
+					Flags: []cli.Flag{
+						cli.StringFlag{
+							Name:     "name",
+							Required: true,
+						},
+						cli.StringSliceFlag{
+							Name:  "query",
+							Usage: "root field the key may execute, may be repeated. Omit to allow every field",
+						},
+						cli.Int64SliceFlag{
+							Name:  "member",
+							Usage: "id of a member the key may access, may be repeated. Omit to allow every member",
+						},
+						cli.UintFlag{
+							Name:  "rate-limit",
+							Usage: "number of requests per minute the key may make",
+							Value: apikey.DefaultRateLimit,
+						},
+					},
+				},
+				{
+					Name:   "revoke",
+					Action: revokeAPIKeyCommand,
+					Flags: []cli.Flag{
+						cli.Int64Flag{
+							Name:     "id",
+							Required: true,
+						},
+					},
+				},
+			},
+		},
 		{
 			Name:   "test",
 			Action: testCommand,
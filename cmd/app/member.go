@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+
+	"github.com/eveisesi/athena"
+	"github.com/eveisesi/athena/internal/alliance"
+	"github.com/eveisesi/athena/internal/auth"
+	"github.com/eveisesi/athena/internal/cache"
+	"github.com/eveisesi/athena/internal/character"
+	"github.com/eveisesi/athena/internal/corporation"
+	"github.com/eveisesi/athena/internal/esi"
+	"github.com/eveisesi/athena/internal/etag"
+	"github.com/eveisesi/athena/internal/member"
+	"github.com/urfave/cli"
+)
+
+func purgeMemberCommand(c *cli.Context) error {
+
+	basics := basics("member-purge")
+	var ctx = context.Background()
+
+	cache := cache.NewService(basics.redis)
+	etag := etag.NewService(cache, basics.repositories.etag)
+	esi := esi.NewService(basics.client, cache, etag, basics.cfg.UserAgent)
+
+	alliance := alliance.NewService(basics.logger, cache, esi, basics.repositories.alliance)
+	corporation := corporation.NewService(basics.logger, cache, esi, alliance, basics.repositories.corporation)
+	character := character.NewService(basics.logger, cache, esi, corporation, basics.repositories.character)
+
+	auth := auth.NewService(
+		cache,
+		getAuthConfig(basics.cfg),
+		basics.client,
+		basics.cfg.Auth.JWKSURL,
+		basics.cfg.Auth.AttemptTTL,
+		[]byte(basics.cfg.Auth.SessionKey),
+		basics.cfg.Auth.SessionTTL,
+		basics.cfg.Auth.SessionRefreshTTL,
+	)
+
+	memberServ := member.NewService(basics.logger, auth, cache, alliance, character, corporation, basics.repositories.member)
+
+	memberID := uint(c.Int64("id"))
+	err := memberServ.PurgeMember(ctx, memberID, athena.CLIAuditSource)
+	if err != nil {
+		basics.logger.WithError(err).WithField("member_id", memberID).Fatal("failed to purge member")
+	}
+
+	basics.logger.WithField("member_id", memberID).Info("member purged successfully")
+
+	return nil
+
+}
@@ -12,10 +12,12 @@ import (
 	"github.com/eveisesi/athena/internal/corporation"
 	"github.com/eveisesi/athena/internal/esi"
 	"github.com/eveisesi/athena/internal/etag"
+	"github.com/eveisesi/athena/internal/fittings"
 	"github.com/eveisesi/athena/internal/location"
 	"github.com/eveisesi/athena/internal/mail"
 	"github.com/eveisesi/athena/internal/member"
 	"github.com/eveisesi/athena/internal/processor"
+	"github.com/eveisesi/athena/internal/redflag"
 	"github.com/eveisesi/athena/internal/skill"
 	"github.com/eveisesi/athena/internal/universe"
 	"github.com/eveisesi/athena/internal/wallet"
@@ -39,27 +41,34 @@ func processorCommand(c *cli.Context) error {
 		getAuthConfig(basics.cfg),
 		basics.client,
 		basics.cfg.Auth.JWKSURL,
+		basics.cfg.Auth.AttemptTTL,
+		[]byte(basics.cfg.Auth.SessionKey),
+		basics.cfg.Auth.SessionTTL,
+		basics.cfg.Auth.SessionRefreshTTL,
 	)
 
 	universe := universe.NewService(basics.logger, cache, esi, basics.repositories.universe)
 
 	asset := asset.NewService(basics.logger, cache, esi, universe, basics.repositories.asset)
-	member := member.NewService(auth, cache, alliance, character, corporation, basics.repositories.member)
+	member := member.NewService(basics.logger, auth, cache, alliance, character, corporation, basics.repositories.member)
 	clone := clone.NewService(basics.logger, cache, esi, universe, basics.repositories.clone)
 	contact := contact.NewService(basics.logger, cache, esi, universe, alliance, character, corporation, basics.repositories.contact)
 	contract := contract.NewService(basics.logger, cache, esi, universe, alliance, character, corporation, basics.repositories.contract)
+	fittings := fittings.NewService(basics.logger, cache, esi, universe, basics.repositories.fitting)
 	location := location.NewService(basics.logger, cache, esi, universe, basics.repositories.location)
 	mail := mail.NewService(basics.logger, cache, esi, character, alliance, corporation, basics.repositories.mail)
 	skill := skill.NewService(basics.logger, cache, esi, etag, universe, basics.repositories.skill)
 	wallet := wallet.NewService(basics.logger, cache, esi, universe, alliance, corporation, character, basics.repositories.wallet)
 
-	processor := processor.NewService(basics.logger, cache, member)
+	redflag := redflag.NewService(basics.logger, loadRedFlagRules(basics.cfg, basics.logger), character, contact, contract, mail, wallet, basics.repositories.redflag)
+
+	processor := processor.NewService(basics.logger, cache, esi, member, redflag)
 
 	processor.SetScopeMap(
 		buildScopeMap(
 			location, clone, contact,
 			mail, skill, wallet,
-			asset, contract,
+			asset, contract, fittings,
 		),
 	)
 
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"github.com/eveisesi/athena"
+	"github.com/eveisesi/athena/internal/redflag"
+	"github.com/sirupsen/logrus"
+)
+
+// loadRedFlagRules loads the red flag rules that have been configured. Invalid rules are fatal, so that
+// a typo in a rule does not silently stop red flags from being raised
+func loadRedFlagRules(cfg config, logger *logrus.Logger) []*athena.RedFlagRule {
+
+	if cfg.RedFlag.Rules == "" {
+		logger.Warn("red flag rules have not been configured, no red flags will be raised")
+		return nil
+	}
+
+	rules, err := redflag.LoadRules(cfg.RedFlag.Rules)
+	if err != nil {
+		logger.WithError(err).Fatal("failed to load red flag rules")
+	}
+
+	logger.WithField("rules", len(rules)).Info("red flag rules loaded")
+
+	return rules
+
+}
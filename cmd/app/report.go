@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/eveisesi/athena"
+	"github.com/eveisesi/athena/internal/alliance"
+	"github.com/eveisesi/athena/internal/auth"
+	"github.com/eveisesi/athena/internal/cache"
+	"github.com/eveisesi/athena/internal/character"
+	"github.com/eveisesi/athena/internal/clone"
+	"github.com/eveisesi/athena/internal/contact"
+	"github.com/eveisesi/athena/internal/contract"
+	"github.com/eveisesi/athena/internal/corporation"
+	"github.com/eveisesi/athena/internal/esi"
+	"github.com/eveisesi/athena/internal/etag"
+	"github.com/eveisesi/athena/internal/location"
+	"github.com/eveisesi/athena/internal/member"
+	"github.com/eveisesi/athena/internal/report"
+	"github.com/eveisesi/athena/internal/skill"
+	"github.com/eveisesi/athena/internal/universe"
+	"github.com/eveisesi/athena/internal/wallet"
+	"github.com/urfave/cli"
+)
+
+func reportCommand(c *cli.Context) error {
+
+	format := athena.ReportFormat(strings.ToLower(c.String("format")))
+	if !format.Valid() {
+		return fmt.Errorf("unsupported report format %q, expected one of %v", c.String("format"), athena.AllReportFormats)
+	}
+
+	basics := basics("report")
+	var ctx = context.Background()
+
+	cache := cache.NewService(basics.redis)
+	etag := etag.NewService(cache, basics.repositories.etag)
+	esi := esi.NewService(basics.client, cache, etag, basics.cfg.UserAgent)
+
+	universe := universe.NewService(basics.logger, cache, esi, basics.repositories.universe)
+	location := location.NewService(basics.logger, cache, esi, universe, basics.repositories.location)
+	clone := clone.NewService(basics.logger, cache, esi, universe, basics.repositories.clone)
+	alliance := alliance.NewService(basics.logger, cache, esi, basics.repositories.alliance)
+	corporation := corporation.NewService(basics.logger, cache, esi, alliance, basics.repositories.corporation)
+	character := character.NewService(basics.logger, cache, esi, corporation, basics.repositories.character)
+	contact := contact.NewService(basics.logger, cache, esi, universe, alliance, character, corporation, basics.repositories.contact)
+	contract := contract.NewService(basics.logger, cache, esi, universe, alliance, character, corporation, basics.repositories.contract)
+	skill := skill.NewService(basics.logger, cache, esi, etag, universe, basics.repositories.skill)
+	wallet := wallet.NewService(basics.logger, cache, esi, universe, alliance, corporation, character, basics.repositories.wallet)
+
+	auth := auth.NewService(
+		cache,
+		getAuthConfig(basics.cfg),
+		basics.client,
+		basics.cfg.Auth.JWKSURL,
+		basics.cfg.Auth.AttemptTTL,
+		[]byte(basics.cfg.Auth.SessionKey),
+		basics.cfg.Auth.SessionTTL,
+		basics.cfg.Auth.SessionRefreshTTL,
+	)
+
+	member := member.NewService(basics.logger, auth, cache, alliance, character, corporation, basics.repositories.member)
+	report := report.NewService(basics.logger, member, character, corporation, alliance, universe, location, clone, contact, contract, skill, wallet)
+
+	memberID := uint(c.Int64("member"))
+	content, err := report.RenderMemberReport(ctx, memberID, format, nil)
+	if err != nil {
+		basics.logger.WithError(err).WithField("member_id", memberID).Fatal("failed to generate member report")
+	}
+
+	output := c.String("output")
+	if output == "" {
+		_, err = os.Stdout.Write(content)
+		return err
+	}
+
+	err = ioutil.WriteFile(output, content, 0644)
+	if err != nil {
+		basics.logger.WithError(err).WithField("output", output).Fatal("failed to write member report")
+	}
+
+	basics.logger.WithField("member_id", memberID).WithField("output", output).Info("member report written successfully")
+
+	return nil
+
+}
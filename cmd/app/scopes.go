@@ -6,6 +6,7 @@ import (
 	"github.com/eveisesi/athena/internal/clone"
 	"github.com/eveisesi/athena/internal/contact"
 	"github.com/eveisesi/athena/internal/contract"
+	"github.com/eveisesi/athena/internal/fittings"
 	"github.com/eveisesi/athena/internal/location"
 	"github.com/eveisesi/athena/internal/mail"
 	"github.com/eveisesi/athena/internal/skill"
@@ -21,6 +22,7 @@ func buildScopeMap(
 	wallet wallet.Service,
 	asset asset.Service,
 	contract contract.Service,
+	fittings fittings.Service,
 ) athena.ScopeMap {
 
 	scopeMap := make(athena.ScopeMap, 10)
@@ -82,6 +84,10 @@ func buildScopeMap(
 			Name: "MemberSkills",
 			Func: skill.FetchMemberSkills,
 		},
+		{
+			Name: "MemberAttributes",
+			Func: skill.FetchMemberAttributes,
+		},
 	}
 
 	scopeMap[athena.ReadWalletV1] = []athena.ScopeResolver{
@@ -120,6 +126,13 @@ func buildScopeMap(
 		},
 	}
 
+	scopeMap[athena.ReadFittingsV1] = []athena.ScopeResolver{
+		{
+			Name: "MemberFittings",
+			Func: fittings.FetchMemberFittings,
+		},
+	}
+
 	return scopeMap
 
 }
@@ -8,6 +8,8 @@ import (
 	"time"
 
 	"github.com/eveisesi/athena/internal/alliance"
+	"github.com/eveisesi/athena/internal/analysis"
+	"github.com/eveisesi/athena/internal/apikey"
 	"github.com/eveisesi/athena/internal/asset"
 	"github.com/eveisesi/athena/internal/character"
 	"github.com/eveisesi/athena/internal/clone"
@@ -16,9 +18,15 @@ import (
 	"github.com/eveisesi/athena/internal/corporation"
 	"github.com/eveisesi/athena/internal/esi"
 	"github.com/eveisesi/athena/internal/etag"
+	"github.com/eveisesi/athena/internal/fittings"
 	"github.com/eveisesi/athena/internal/location"
+	"github.com/eveisesi/athena/internal/mail"
 	"github.com/eveisesi/athena/internal/member"
+	"github.com/eveisesi/athena/internal/redflag"
+	"github.com/eveisesi/athena/internal/report"
+	"github.com/eveisesi/athena/internal/skill"
 	"github.com/eveisesi/athena/internal/universe"
+	"github.com/eveisesi/athena/internal/wallet"
 
 	"github.com/eveisesi/athena/internal/auth"
 	"github.com/eveisesi/athena/internal/cache"
@@ -44,22 +52,48 @@ func serverCommand(c *cli.Context) error {
 	contact := contact.NewService(basics.logger, cache, esi, universe, alliance, character, corporation, basics.repositories.contact)
 	contract := contract.NewService(basics.logger, cache, esi, universe, alliance, character, corporation, basics.repositories.contract)
 	asset := asset.NewService(basics.logger, cache, esi, universe, basics.repositories.asset)
+	skill := skill.NewService(basics.logger, cache, esi, etag, universe, basics.repositories.skill)
+	wallet := wallet.NewService(basics.logger, cache, esi, universe, alliance, corporation, character, basics.repositories.wallet)
+	mail := mail.NewService(basics.logger, cache, esi, character, alliance, corporation, basics.repositories.mail)
+	fittings := fittings.NewService(basics.logger, cache, esi, universe, basics.repositories.fitting)
 
 	auth := auth.NewService(
 		cache,
 		getAuthConfig(basics.cfg),
 		basics.client,
 		basics.cfg.Auth.JWKSURL,
+		basics.cfg.Auth.AttemptTTL,
+		[]byte(basics.cfg.Auth.SessionKey),
+		basics.cfg.Auth.SessionTTL,
+		basics.cfg.Auth.SessionRefreshTTL,
 	)
 
-	member := member.NewService(auth, cache, alliance, character, corporation, basics.repositories.member)
+	member := member.NewService(basics.logger, auth, cache, alliance, character, corporation, basics.repositories.member)
+	apikey := apikey.NewService(basics.logger, cache, basics.repositories.apikey, basics.cfg.APIKey.Admins, basics.cfg.APIKey.Recruiters)
+	analysis := analysis.NewService(basics.logger, member, wallet, basics.cfg.Analysis.HostileCorporations)
+	redflag := redflag.NewService(basics.logger, loadRedFlagRules(basics.cfg, basics.logger), character, contact, contract, mail, wallet, basics.repositories.redflag)
+	report := report.NewService(basics.logger, member, character, corporation, alliance, universe, location, clone, contact, contract, skill, wallet)
+
+	trustedProxies, err := server.ParseTrustedProxies(basics.cfg.Server.TrustedProxies)
+	if err != nil {
+		basics.logger.WithError(err).Fatal("failed to parse trusted proxies")
+	}
 
 	server := server.NewServer(
 		basics.cfg.Server.Port,
 		basics.cfg.Env,
+		server.Limits{
+			MaxComplexity:  basics.cfg.Server.MaxComplexity,
+			MaxDepth:       basics.cfg.Server.MaxDepth,
+			RateLimit:      basics.cfg.Server.RateLimit,
+			RateWindow:     basics.cfg.Server.RateWindow,
+			TrustedProxies: trustedProxies,
+		},
 		basics.logger,
 		cache,
 		basics.newrelic,
+		esi,
+		apikey,
 		auth,
 		member,
 		character,
@@ -71,6 +105,13 @@ func serverCommand(c *cli.Context) error {
 		contact,
 		contract,
 		asset,
+		skill,
+		wallet,
+		mail,
+		fittings,
+		report,
+		redflag,
+		analysis,
 	)
 
 	serverErrors := make(chan error, 1)
@@ -3,6 +3,9 @@ package main
 import (
 	"context"
 	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/eveisesi/athena"
@@ -14,6 +17,7 @@ import (
 	"github.com/eveisesi/athena/internal/esi"
 	"github.com/eveisesi/athena/internal/etag"
 	"github.com/eveisesi/athena/internal/member"
+	"github.com/sirupsen/logrus"
 	"github.com/urfave/cli"
 	"github.com/volatiletech/null"
 	"golang.org/x/oauth2"
@@ -62,9 +66,13 @@ func refreshMemberToken(c *cli.Context) error {
 		getAuthConfig(basics.cfg),
 		basics.client,
 		basics.cfg.Auth.JWKSURL,
+		basics.cfg.Auth.AttemptTTL,
+		[]byte(basics.cfg.Auth.SessionKey),
+		basics.cfg.Auth.SessionTTL,
+		basics.cfg.Auth.SessionRefreshTTL,
 	)
 
-	memberServ := member.NewService(auth, cache, alliance, character, corporation, basics.repositories.member)
+	memberServ := member.NewService(basics.logger, auth, cache, alliance, character, corporation, basics.repositories.member)
 
 	memberID := c.Int64("id")
 	members, err := basics.repositories.member.Members(ctx, athena.NewEqualOperator("id", memberID))
@@ -146,9 +154,13 @@ func addMemberByCLI(c *cli.Context) error {
 		getAuthConfig(basics.cfg),
 		basics.client,
 		basics.cfg.Auth.JWKSURL,
+		basics.cfg.Auth.AttemptTTL,
+		[]byte(basics.cfg.Auth.SessionKey),
+		basics.cfg.Auth.SessionTTL,
+		basics.cfg.Auth.SessionRefreshTTL,
 	)
 
-	memberServ := member.NewService(auth, cache, alliance, character, corporation, basics.repositories.member)
+	memberServ := member.NewService(basics.logger, auth, cache, alliance, character, corporation, basics.repositories.member)
 
 	ctx := context.Background()
 
@@ -212,3 +224,69 @@ func addMemberByCLI(c *cli.Context) error {
 	return nil
 
 }
+
+func tokenRefreshWorkerCommand(c *cli.Context) error {
+
+	basics := basics("token-worker")
+
+	cache := cache.NewService(basics.redis)
+	etag := etag.NewService(cache, basics.repositories.etag)
+	esi := esi.NewService(basics.client, cache, etag, basics.cfg.UserAgent)
+
+	alliance := alliance.NewService(basics.logger, cache, esi, basics.repositories.alliance)
+	corporation := corporation.NewService(basics.logger, cache, esi, alliance, basics.repositories.corporation)
+	character := character.NewService(basics.logger, cache, esi, corporation, basics.repositories.character)
+
+	auth := auth.NewService(
+		cache,
+		getAuthConfig(basics.cfg),
+		basics.client,
+		basics.cfg.Auth.JWKSURL,
+		basics.cfg.Auth.AttemptTTL,
+		[]byte(basics.cfg.Auth.SessionKey),
+		basics.cfg.Auth.SessionTTL,
+		basics.cfg.Auth.SessionRefreshTTL,
+	)
+
+	memberServ := member.NewService(basics.logger, auth, cache, alliance, character, corporation, basics.repositories.member)
+
+	entry := basics.logger.WithFields(logrus.Fields{
+		"interval":    basics.cfg.TokenRefresh.Interval,
+		"window":      basics.cfg.TokenRefresh.Window,
+		"concurrency": basics.cfg.TokenRefresh.Concurrency,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	osSignals := make(chan os.Signal, 1)
+	signal.Notify(osSignals, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		sig := <-osSignals
+		entry.WithField("sig", sig).Info("interrupt signal received, stopping token refresh worker")
+		cancel()
+	}()
+
+	entry.Info("token refresh worker is running")
+
+	ticker := time.NewTicker(basics.cfg.TokenRefresh.Interval)
+	defer ticker.Stop()
+
+	for {
+		count, err := memberServ.RefreshExpiredTokens(ctx, basics.cfg.TokenRefresh.Window, basics.cfg.TokenRefresh.Concurrency)
+		if err != nil {
+			entry.WithError(err).Error("failed to refresh expired tokens")
+		} else {
+			entry.WithField("count", count).Info("refreshed expired tokens")
+		}
+
+		select {
+		case <-ctx.Done():
+			entry.Info("token refresh worker stopped")
+			return nil
+		case <-ticker.C:
+		}
+	}
+
+}
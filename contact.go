@@ -13,6 +13,7 @@ type MemberContactRepository interface {
 type memberContactRepository interface {
 	MemberContact(ctx context.Context, memberID, contactID uint) (*MemberContact, error)
 	MemberContacts(ctx context.Context, memberID uint, operators ...*Operator) ([]*MemberContact, error)
+	CountMemberContacts(ctx context.Context, memberID uint, operators ...*Operator) (uint, error)
 	CreateMemberContacts(ctx context.Context, memberID uint, contacts []*MemberContact) ([]*MemberContact, error)
 	UpdateMemberContact(ctx context.Context, memberID uint, contact *MemberContact) (*MemberContact, error)
 	DeleteMemberContacts(ctx context.Context, memberID uint, contacts []*MemberContact) (bool, error)
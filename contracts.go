@@ -16,20 +16,30 @@ type MemberContractRepository interface {
 type memberContractRepository interface {
 	MemberContract(ctx context.Context, memberID, contractID uint) (*MemberContract, error)
 	MemberContracts(ctx context.Context, memberID uint, operators ...*Operator) ([]*MemberContract, error)
+	CountMemberContracts(ctx context.Context, memberID uint, operators ...*Operator) (uint, error)
 	CreateContracts(ctx context.Context, memberID uint, contracts []*MemberContract) ([]*MemberContract, error)
 	UpdateContract(ctx context.Context, memberID uint, contract *MemberContract) (*MemberContract, error)
 }
 
 type memberContractItemRepository interface {
 	MemberContractItems(ctx context.Context, memberID, contractID uint, operators ...*Operator) ([]*MemberContractItem, error)
+	MemberContractItemsByContractIDs(ctx context.Context, memberID uint, contractIDs []uint) ([]*MemberContractItem, error)
 	CreateMemberContractItems(ctx context.Context, memberID, contractID uint, items []*MemberContractItem) ([]*MemberContractItem, error)
 }
 
 type memberContractBidRepository interface {
 	MemberContractBids(ctx context.Context, memberID, contractID uint, operators ...*Operator) ([]*MemberContractBid, error)
+	MemberContractBidsByContractIDs(ctx context.Context, memberID uint, contractIDs []uint) ([]*MemberContractBid, error)
 	CreateMemberContractBids(ctx context.Context, memberID, contractID uint, bids []*MemberContractBid) ([]*MemberContractBid, error)
 }
 
+// MemberContractKey identifies a contract of a member. The same contract is stored once for every
+// member that can see it, so the contract ID alone does not identify a row
+type MemberContractKey struct {
+	MemberID   uint
+	ContractID uint
+}
+
 type MemberContract struct {
 	MemberID            uint                 `db:"member_id" json:"member_id" deep:"-"`
 	ContractID          uint                 `db:"contract_id" json:"contract_id"`
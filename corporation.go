@@ -2,6 +2,7 @@ package athena
 
 import (
 	"context"
+	"sort"
 	"time"
 
 	"github.com/volatiletech/null"
@@ -16,6 +17,18 @@ type CorporationRepository interface {
 	CreateCorporationAllianceHistory(ctx context.Context, id uint, history []*CorporationAllianceHistory) ([]*CorporationAllianceHistory, error)
 }
 
+const (
+	// NPC corporations are issued IDs from a reserved range that player corporations never use
+	npcCorporationIDMin = 1000000
+	npcCorporationIDMax = 2000000
+)
+
+// IsNPCCorporation reports whether the corporation is run by an NPC, such as the starter
+// corporations that characters are placed in when they leave a player corporation
+func IsNPCCorporation(id uint) bool {
+	return id >= npcCorporationIDMin && id < npcCorporationIDMax
+}
+
 type Corporation struct {
 	ID            uint        `db:"id,omitempty" json:"id"`
 	AllianceID    null.Uint   `db:"alliance_id,omitempty" json:"alliance_id,omitempty"`
@@ -36,6 +49,8 @@ type Corporation struct {
 }
 
 func (Corporation) IsContactInfo() {}
+func (Corporation) IsWalletParty() {}
+func (Corporation) IsMailParty()   {}
 
 type CorporationAllianceHistory struct {
 	CorporationID uint      `db:"corporation_id" json:"id"`
@@ -43,6 +58,38 @@ type CorporationAllianceHistory struct {
 	AllianceID    null.Uint `db:"alliance_id" json:"alliance_id"`
 	IsDeleteed    null.Bool `db:"is_deleted" json:"is_deleted"`
 	StartDate     time.Time `db:"start_date" json:"start_date"`
+	EndDate       null.Time `db:"-" json:"-" deep:"-"`
 	CreatedAt     time.Time `db:"created_at" json:"created_at" deep:"-"`
 	UpdatedAt     time.Time `db:"updated_at" json:"updated_at" deep:"-"`
 }
+
+// Tenure returns how long the corporation was a member of the alliance, or without one when the
+// record has no alliance. Records without an end date are measured up until now
+func (h *CorporationAllianceHistory) Tenure() time.Duration {
+	if h.EndDate.Valid {
+		return h.EndDate.Time.Sub(h.StartDate)
+	}
+
+	return time.Since(h.StartDate)
+}
+
+// ResolveCorporationAllianceHistory orders the history of a single corporation newest first and sets
+// the end date of every record to the start date of the record that followed it
+func ResolveCorporationAllianceHistory(history []*CorporationAllianceHistory) []*CorporationAllianceHistory {
+	sort.SliceStable(history, func(i, j int) bool {
+		if !history[i].StartDate.Equal(history[j].StartDate) {
+			return history[i].StartDate.After(history[j].StartDate)
+		}
+
+		return history[i].RecordID > history[j].RecordID
+	})
+
+	for i, record := range history {
+		record.EndDate = null.Time{}
+		if i > 0 {
+			record.EndDate = null.TimeFrom(history[i-1].StartDate)
+		}
+	}
+
+	return history
+}
@@ -0,0 +1,39 @@
+package athena
+
+import "time"
+
+type MemberEventType string
+
+const (
+	MemberLocationChanged   MemberEventType = "location"
+	MemberShipChanged       MemberEventType = "ship"
+	MemberOnlineChanged     MemberEventType = "online"
+	MemberClonesChanged     MemberEventType = "clones"
+	MemberImplantsChanged   MemberEventType = "implants"
+	MemberSkillsChanged     MemberEventType = "skills"
+	MemberSkillQueueChanged MemberEventType = "skill_queue"
+	MemberAttributesChanged MemberEventType = "attributes"
+)
+
+func (t MemberEventType) String() string {
+	return string(t)
+}
+
+// MemberEvent is published when the processor observes a change to the data of a member. Events
+// only describe what changed, subscribers read the new state from the service that owns it.
+// Services that compare a value with what is stored publish when it differs. Services that only
+// see an etag publish whenever ESI hands out a new one, because ESI only does that when the data
+// of the member has changed
+type MemberEvent struct {
+	MemberID  uint            `json:"member_id"`
+	Type      MemberEventType `json:"type"`
+	Timestamp time.Time       `json:"timestamp"`
+}
+
+func NewMemberEvent(memberID uint, eventType MemberEventType) *MemberEvent {
+	return &MemberEvent{
+		MemberID:  memberID,
+		Type:      eventType,
+		Timestamp: time.Now(),
+	}
+}
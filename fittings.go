@@ -2,6 +2,7 @@ package athena
 
 import (
 	"context"
+	"strings"
 	"time"
 )
 
@@ -11,6 +12,7 @@ type MemberFittingsRepository interface {
 }
 
 type memberFittingRepository interface {
+	MemberFitting(ctx context.Context, memberID, fittingID uint) (*MemberFitting, error)
 	MemberFittings(ctx context.Context, memberID uint, operators ...*Operator) ([]*MemberFitting, error)
 	CreateMemberFittings(ctx context.Context, memberID uint, fittings []*MemberFitting) ([]*MemberFitting, error)
 	UpdateMemberFitting(ctx context.Context, memberID, fittingID uint, fitting *MemberFitting) (*MemberFitting, error)
@@ -24,24 +26,25 @@ type memberFittingItemRepository interface {
 }
 
 type MemberFitting struct {
-	MemberID    uint                 `db:"member_id" json:"-"`
+	MemberID    uint                 `db:"member_id" json:"member_id"`
 	FittingID   uint                 `db:"fitting_id" json:"fitting_id"`
 	ShipTypeID  uint                 `db:"ship_type_id" json:"ship_type_id"`
 	Name        string               `db:"name" json:"name"`
 	Description string               `db:"description" json:"description"`
 	Items       []*MemberFittingItem `db:"-" json:"items"`
 	ItemsHash   string               `db:"item_hash" json:"item_hash"`
-	CreatedAt   time.Time            `db:"created_at" json:"-"`
-	UpdatedAt   time.Time            `db:"updated_at" json:"-"`
+	CreatedAt   time.Time            `db:"created_at" json:"created_at"`
+	UpdatedAt   time.Time            `db:"updated_at" json:"updated_at"`
 }
 
 type MemberFittingItem struct {
+	MemberID  uint            `db:"member_id" json:"member_id"`
 	FittingID uint            `db:"fitting_id" json:"fitting_id"`
 	TypeID    uint            `db:"type_id" json:"type_id"`
 	Quantity  uint            `db:"quantity" json:"quantity"`
 	Flag      FittingItemFlag `db:"flag" json:"flag"`
-	CreatedAt time.Time       `db:"created_at" json:"-"`
-	UpdatedAt time.Time       `db:"updated_at" json:"-"`
+	CreatedAt time.Time       `db:"created_at" json:"created_at"`
+	UpdatedAt time.Time       `db:"updated_at" json:"updated_at"`
 }
 
 type FittingItemFlag string
@@ -151,3 +154,41 @@ func (i FittingItemFlag) Valid() bool {
 func (i FittingItemFlag) String() string {
 	return string(i)
 }
+
+// FittingSlot groups the individual FittingItemFlags of a fitting into the rack or bay they belong to
+type FittingSlot string
+
+const (
+	FittingSlotHigh       FittingSlot = "HiSlot"
+	FittingSlotMedium     FittingSlot = "MedSlot"
+	FittingSlotLow        FittingSlot = "LoSlot"
+	FittingSlotRig        FittingSlot = "RigSlot"
+	FittingSlotSubSystem  FittingSlot = "SubSystemSlot"
+	FittingSlotService    FittingSlot = "ServiceSlot"
+	FittingSlotDroneBay   FittingSlot = "DroneBay"
+	FittingSlotFighterBay FittingSlot = "FighterBay"
+	FittingSlotCargo      FittingSlot = "Cargo"
+	FittingSlotInvalid    FittingSlot = "Invalid"
+)
+
+var AllFittingSlots = []FittingSlot{
+	FittingSlotHigh, FittingSlotMedium, FittingSlotLow,
+	FittingSlotRig, FittingSlotSubSystem, FittingSlotService,
+	FittingSlotDroneBay, FittingSlotFighterBay, FittingSlotCargo,
+	FittingSlotInvalid,
+}
+
+func (i FittingSlot) String() string {
+	return string(i)
+}
+
+// Slot returns the rack or bay that the flag belongs to
+func (i FittingItemFlag) Slot() FittingSlot {
+	for _, slot := range AllFittingSlots {
+		if strings.HasPrefix(i.String(), slot.String()) {
+			return slot
+		}
+	}
+
+	return FittingSlotInvalid
+}
@@ -0,0 +1,109 @@
+package analysis
+
+import (
+	"sort"
+	"time"
+
+	"github.com/eveisesi/athena"
+)
+
+type corporationHistoryAnalysis interface {
+	CorporationHistoryAnalysis(characterID uint, history []*athena.CharacterCorporationHistory) *athena.CorporationHistoryAnalysis
+}
+
+// CorporationHistoryAnalysis analyses the corporation history of the character. Callers load the
+// history themselves, resolved by athena.ResolveCharacterCorporationHistory, so that the history of
+// many characters can be loaded in a single batch
+func (s *service) CorporationHistoryAnalysis(characterID uint, history []*athena.CharacterCorporationHistory) *athena.CorporationHistoryAnalysis {
+	return analyzeCorporationHistory(characterID, history, s.hostileCorporations, time.Now())
+}
+
+// analyzeCorporationHistory computes the analysis of a history that has been resolved by
+// athena.ResolveCharacterCorporationHistory, which orders it newest first
+func analyzeCorporationHistory(characterID uint, history []*athena.CharacterCorporationHistory, hostile map[uint]bool, now time.Time) *athena.CorporationHistoryAnalysis {
+
+	analysis := &athena.CorporationHistoryAnalysis{
+		CharacterID:     characterID,
+		Returns:         make([]*athena.CorporationReturn, 0),
+		HostileOverlaps: make([]*athena.HostileCorporationOverlap, 0),
+	}
+
+	if len(history) == 0 {
+		return analysis
+	}
+
+	sixMonthsAgo := now.AddDate(0, -6, 0)
+	twelveMonthsAgo := now.AddDate(0, -12, 0)
+
+	returns := make(map[uint]*athena.CorporationReturn)
+	var totalDays uint
+	for i, record := range history {
+
+		days := tenureDays(record, now)
+		totalDays += days
+
+		if athena.IsNPCCorporation(record.CorporationID) {
+			analysis.NPCCorporationDays += days
+		} else {
+			analysis.PlayerCorporationDays += days
+		}
+
+		// The oldest record is the corporation the character was created in, every record after
+		// it is the character hopping to another corporation
+		if i < len(history)-1 {
+			if !record.StartDate.Before(sixMonthsAgo) {
+				analysis.HopsLast6Months++
+			}
+			if !record.StartDate.Before(twelveMonthsAgo) {
+				analysis.HopsLast12Months++
+			}
+		}
+
+		if _, ok := returns[record.CorporationID]; !ok {
+			returns[record.CorporationID] = &athena.CorporationReturn{CorporationID: record.CorporationID}
+		}
+		returns[record.CorporationID].Stints++
+		returns[record.CorporationID].TotalDays += days
+
+		if hostile[record.CorporationID] {
+			analysis.HostileOverlaps = append(analysis.HostileOverlaps, &athena.HostileCorporationOverlap{
+				CorporationID: record.CorporationID,
+				StartDate:     record.StartDate,
+				EndDate:       record.EndDate,
+				TenureDays:    days,
+			})
+		}
+	}
+
+	analysis.Corporations = uint(len(returns))
+	analysis.AverageTenureDays = float64(totalDays) / float64(len(history))
+	analysis.CurrentTenureDays = tenureDays(history[0], now)
+
+	for _, r := range returns {
+		if r.Stints > 1 {
+			analysis.Returns = append(analysis.Returns, r)
+		}
+	}
+
+	sort.Slice(analysis.Returns, func(i, j int) bool {
+		if analysis.Returns[i].Stints != analysis.Returns[j].Stints {
+			return analysis.Returns[i].Stints > analysis.Returns[j].Stints
+		}
+
+		return analysis.Returns[i].CorporationID < analysis.Returns[j].CorporationID
+	})
+
+	return analysis
+
+}
+
+// tenureDays measures the tenure of the current corporation up until now, so that every tenure of
+// an analysis is measured at the same moment
+func tenureDays(record *athena.CharacterCorporationHistory, now time.Time) uint {
+	end := now
+	if record.EndDate.Valid {
+		end = record.EndDate.Time
+	}
+
+	return uint(end.Sub(record.StartDate).Hours() / 24)
+}
@@ -0,0 +1,76 @@
+package analysis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eveisesi/athena"
+)
+
+func TestAnalyzeCorporationHistory(t *testing.T) {
+
+	now := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	history := athena.ResolveCharacterCorporationHistory([]*athena.CharacterCorporationHistory{
+		{RecordID: 1, CorporationID: 1000166, StartDate: date(2018, time.January, 1)},
+		{RecordID: 2, CorporationID: 98000001, StartDate: date(2018, time.January, 31)},
+		{RecordID: 3, CorporationID: 98000002, StartDate: date(2020, time.January, 31)},
+		{RecordID: 4, CorporationID: 98000001, StartDate: date(2020, time.November, 1)},
+		{RecordID: 5, CorporationID: 98000003, StartDate: date(2021, time.May, 2)},
+	})
+
+	analysis := analyzeCorporationHistory(90000001, history, map[uint]bool{98000002: true}, now)
+
+	if analysis.CharacterID != 90000001 {
+		t.Errorf("CharacterID = %d, want 90000001", analysis.CharacterID)
+	}
+	if analysis.Corporations != 4 {
+		t.Errorf("Corporations = %d, want 4", analysis.Corporations)
+	}
+	if analysis.NPCCorporationDays != 30 {
+		t.Errorf("NPCCorporationDays = %d, want 30", analysis.NPCCorporationDays)
+	}
+	if analysis.PlayerCorporationDays != 730+275+182+30 {
+		t.Errorf("PlayerCorporationDays = %d, want %d", analysis.PlayerCorporationDays, 730+275+182+30)
+	}
+	if analysis.HopsLast6Months != 1 {
+		t.Errorf("HopsLast6Months = %d, want 1", analysis.HopsLast6Months)
+	}
+	if analysis.HopsLast12Months != 2 {
+		t.Errorf("HopsLast12Months = %d, want 2", analysis.HopsLast12Months)
+	}
+	if want := float64(30+730+275+182+30) / 5; analysis.AverageTenureDays != want {
+		t.Errorf("AverageTenureDays = %f, want %f", analysis.AverageTenureDays, want)
+	}
+	if analysis.CurrentTenureDays != 30 {
+		t.Errorf("CurrentTenureDays = %d, want 30", analysis.CurrentTenureDays)
+	}
+
+	if len(analysis.Returns) != 1 {
+		t.Fatalf("got %d returns, want 1", len(analysis.Returns))
+	}
+	if r := analysis.Returns[0]; r.CorporationID != 98000001 || r.Stints != 2 || r.TotalDays != 730+182 {
+		t.Errorf("return = %+v, want corporation 98000001 with 2 stints over %d days", r, 730+182)
+	}
+
+	if len(analysis.HostileOverlaps) != 1 {
+		t.Fatalf("got %d hostile overlaps, want 1", len(analysis.HostileOverlaps))
+	}
+	overlap := analysis.HostileOverlaps[0]
+	if overlap.CorporationID != 98000002 || overlap.TenureDays != 275 || !overlap.EndDate.Valid || !overlap.EndDate.Time.Equal(date(2020, time.November, 1)) {
+		t.Errorf("hostile overlap = %+v, want corporation 98000002 for 275 days until 2020-11-01", overlap)
+	}
+
+}
+
+func TestAnalyzeCorporationHistoryWithoutHistory(t *testing.T) {
+
+	analysis := analyzeCorporationHistory(90000001, nil, nil, time.Now())
+	if analysis.Corporations != 0 || analysis.Returns == nil || analysis.HostileOverlaps == nil {
+		t.Errorf("unexpected analysis of an empty history: %+v", analysis)
+	}
+
+}
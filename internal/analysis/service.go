@@ -0,0 +1,45 @@
+package analysis
+
+import (
+	"github.com/eveisesi/athena/internal/member"
+	"github.com/eveisesi/athena/internal/wallet"
+	"github.com/sirupsen/logrus"
+)
+
+type Service interface {
+	corporationHistoryAnalysis
+	walletJournalAnalysis
+}
+
+type service struct {
+	logger *logrus.Logger
+
+	member member.Service
+	wallet wallet.Service
+
+	hostileCorporations map[uint]bool
+}
+
+const (
+	serviceIdentifier = "Analysis Service"
+)
+
+// NewService returns a service that analyses the data that has been collected for characters and
+// members. Stints in any of the hostile corporations are reported by the corporation history analysis
+func NewService(logger *logrus.Logger, member member.Service, wallet wallet.Service, hostileCorporations []uint) Service {
+
+	hostile := make(map[uint]bool, len(hostileCorporations))
+	for _, id := range hostileCorporations {
+		hostile[id] = true
+	}
+
+	return &service{
+		logger: logger,
+
+		member: member,
+		wallet: wallet,
+
+		hostileCorporations: hostile,
+	}
+
+}
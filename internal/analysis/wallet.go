@@ -0,0 +1,289 @@
+package analysis
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/eveisesi/athena"
+	"github.com/sirupsen/logrus"
+	"github.com/volatiletech/null"
+)
+
+type walletJournalAnalysis interface {
+	WalletJournalAnalysis(ctx context.Context, memberID uint, from, to null.Time) (*athena.WalletJournalAnalysis, error)
+}
+
+const (
+	// topCounterpartyLimit is the number of counterparties that the analysis reports
+	topCounterpartyLimit = 10
+	// largeDonationMinimum is the smallest amount of ISK that a donation must move to be
+	// reported as an anomaly
+	largeDonationMinimum = 100000000
+	// largeDonationFactor is how many times larger than the median entry of the journal a
+	// donation must be to be reported as a large donation
+	largeDonationFactor = 10
+	// preApplicationWindow is how long before the registration of the member a large donation is
+	// reported as a pre application transfer
+	preApplicationWindow = time.Hour * 24 * 14
+)
+
+// WalletJournalAnalysis analyses the wallet journal entries of the member that were made between
+// from and to. Either end of the range may be left open
+func (s *service) WalletJournalAnalysis(ctx context.Context, memberID uint, from, to null.Time) (*athena.WalletJournalAnalysis, error) {
+
+	entry := s.logger.WithContext(ctx).WithFields(logrus.Fields{
+		"member_id": memberID,
+		"service":   serviceIdentifier,
+		"method":    "WalletJournalAnalysis",
+	})
+
+	if from.Valid && to.Valid && from.Time.After(to.Time) {
+		return nil, fmt.Errorf("from must not be after to")
+	}
+
+	member, err := s.member.Member(ctx, memberID)
+	if err != nil {
+		entry.WithError(err).Error("failed to fetch member")
+		return nil, fmt.Errorf("failed to fetch member")
+	}
+
+	if member == nil {
+		return nil, fmt.Errorf("member %d does not exist", memberID)
+	}
+
+	operators := make([]*athena.Operator, 0, 2)
+	if from.Valid {
+		operators = append(operators, athena.NewGreaterThanEqualToOperator("date", from.Time))
+	}
+	if to.Valid {
+		operators = append(operators, athena.NewLessThanEqualToOperator("date", to.Time))
+	}
+
+	// A nil page selects every entry in the range
+	entries, err := s.wallet.MemberWalletJournals(ctx, memberID, nil, operators...)
+	if err != nil {
+		entry.WithError(err).Error("failed to fetch member wallet journal")
+		return nil, fmt.Errorf("failed to fetch member wallet journal")
+	}
+
+	analysis := analyzeWalletJournal(member, entries)
+	analysis.From = from
+	analysis.To = to
+
+	return analysis, nil
+
+}
+
+func analyzeWalletJournal(member *athena.Member, entries []*athena.MemberWalletJournal) *athena.WalletJournalAnalysis {
+
+	analysis := &athena.WalletJournalAnalysis{
+		MemberID:          member.ID,
+		Entries:           uint(len(entries)),
+		Months:            make([]*athena.WalletJournalMonth, 0),
+		TopCounterparties: make([]*athena.WalletJournalCounterparty, 0),
+		Anomalies:         make([]*athena.WalletJournalAnomaly, 0),
+	}
+
+	months := make(map[time.Time]*athena.WalletJournalMonth)
+	categories := make(map[time.Time]map[athena.WalletJournalCategory]*athena.WalletJournalCategorySummary)
+	counterparties := make(map[string]*athena.WalletJournalCounterparty)
+	amounts := make([]float64, 0, len(entries))
+	for _, entry := range entries {
+
+		date := entry.Date.UTC()
+		key := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+		if _, ok := months[key]; !ok {
+			months[key] = &athena.WalletJournalMonth{Month: key}
+			categories[key] = make(map[athena.WalletJournalCategory]*athena.WalletJournalCategorySummary)
+			analysis.Months = append(analysis.Months, months[key])
+		}
+
+		category := entry.RefType.Category()
+		if _, ok := categories[key][category]; !ok {
+			categories[key][category] = &athena.WalletJournalCategorySummary{Category: category}
+		}
+
+		month, summary := months[key], categories[key][category]
+		month.Entries++
+		summary.Entries++
+		if !entry.Amount.Valid {
+			continue
+		}
+
+		amount := entry.Amount.Float64
+		if amount >= 0 {
+			analysis.Income += amount
+			month.Income += amount
+			summary.Income += amount
+		} else {
+			analysis.Expense -= amount
+			month.Expense -= amount
+			summary.Expense -= amount
+		}
+
+		analysis.Net += amount
+		month.Net += amount
+		summary.Net += amount
+
+		if amount != 0 {
+			amounts = append(amounts, math.Abs(amount))
+		}
+
+		partyID, partyType := counterparty(member.ID, entry)
+		if !isPlayerParty(partyID, partyType) {
+			continue
+		}
+
+		partyKey := fmt.Sprintf("%s::%d", partyType.String, partyID.Uint)
+		if _, ok := counterparties[partyKey]; !ok {
+			counterparties[partyKey] = &athena.WalletJournalCounterparty{PartyID: partyID.Uint, PartyType: partyType.String}
+			analysis.TopCounterparties = append(analysis.TopCounterparties, counterparties[partyKey])
+		}
+
+		party := counterparties[partyKey]
+		party.Entries++
+		if amount >= 0 {
+			party.Received += amount
+		} else {
+			party.Sent -= amount
+		}
+		party.Volume += math.Abs(amount)
+	}
+
+	for _, month := range analysis.Months {
+		month.Categories = make([]*athena.WalletJournalCategorySummary, 0, len(categories[month.Month]))
+		for _, category := range athena.AllWalletJournalCategories {
+			if summary, ok := categories[month.Month][category]; ok {
+				month.Categories = append(month.Categories, summary)
+			}
+		}
+	}
+
+	sort.Slice(analysis.Months, func(i, j int) bool {
+		return analysis.Months[i].Month.Before(analysis.Months[j].Month)
+	})
+
+	sort.Slice(analysis.TopCounterparties, func(i, j int) bool {
+		a, b := analysis.TopCounterparties[i], analysis.TopCounterparties[j]
+		if a.Volume != b.Volume {
+			return a.Volume > b.Volume
+		}
+
+		if a.PartyType != b.PartyType {
+			return a.PartyType < b.PartyType
+		}
+
+		return a.PartyID < b.PartyID
+	})
+
+	if len(analysis.TopCounterparties) > topCounterpartyLimit {
+		analysis.TopCounterparties = analysis.TopCounterparties[:topCounterpartyLimit]
+	}
+
+	analysis.Anomalies = walletJournalAnomalies(member, entries, median(amounts))
+
+	return analysis
+
+}
+
+// walletJournalAnomalies reports the donations that were made shortly before the member registered,
+// along with every other donation that dwarfs the median amount of the journal. The most recent
+// anomaly is reported first
+func walletJournalAnomalies(member *athena.Member, entries []*athena.MemberWalletJournal, median float64) []*athena.WalletJournalAnomaly {
+
+	threshold := math.Max(largeDonationMinimum, median*largeDonationFactor)
+	applied := member.CreatedAt.Add(-preApplicationWindow)
+
+	anomalies := make([]*athena.WalletJournalAnomaly, 0)
+	for _, entry := range entries {
+		if entry.RefType.Category() != athena.WalletJournalCategoryDonations || !entry.Amount.Valid {
+			continue
+		}
+
+		amount := math.Abs(entry.Amount.Float64)
+
+		var kind athena.WalletJournalAnomalyKind
+		switch {
+		case amount >= largeDonationMinimum && !entry.Date.Before(applied) && !entry.Date.After(member.CreatedAt):
+			kind = athena.WalletJournalAnomalyKindPreApplicationTransfer
+		case amount >= threshold:
+			kind = athena.WalletJournalAnomalyKindLargeDonation
+		default:
+			continue
+		}
+
+		partyID, partyType := counterparty(member.ID, entry)
+		anomalies = append(anomalies, &athena.WalletJournalAnomaly{
+			JournalID:        entry.JournalID,
+			Kind:             kind,
+			RefType:          entry.RefType,
+			Amount:           entry.Amount.Float64,
+			CounterpartyID:   partyID,
+			CounterpartyType: partyType,
+			Date:             entry.Date,
+			Description:      entry.Description,
+		})
+	}
+
+	sort.Slice(anomalies, func(i, j int) bool {
+		if !anomalies[i].Date.Equal(anomalies[j].Date) {
+			return anomalies[i].Date.After(anomalies[j].Date)
+		}
+
+		return anomalies[i].JournalID > anomalies[j].JournalID
+	})
+
+	return anomalies
+
+}
+
+// counterparty returns whichever party of the entry is not the member
+func counterparty(memberID uint, entry *athena.MemberWalletJournal) (null.Uint, null.String) {
+	if entry.FirstPartyID.Valid && entry.FirstPartyID.Uint == memberID {
+		return entry.SecondPartyID, entry.SecondPartyType
+	}
+
+	return entry.FirstPartyID, entry.FirstPartyType
+}
+
+// isPlayerParty reports whether the party is a player character, player corporation or alliance.
+// NPCs such as CONCORD and mission agents are party to most entries and would otherwise crowd out
+// the players that the member trades with
+func isPlayerParty(id null.Uint, partyType null.String) bool {
+	if !id.Valid || !partyType.Valid {
+		return false
+	}
+
+	switch partyType.String {
+	case "character":
+		return !athena.IsNPCCharacter(id.Uint)
+	case "corporation":
+		return !athena.IsNPCCorporation(id.Uint)
+	case "alliance":
+		return true
+	}
+
+	return false
+}
+
+func median(values []float64) float64 {
+
+	if len(values) == 0 {
+		return 0
+	}
+
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[middle-1] + sorted[middle]) / 2
+	}
+
+	return sorted[middle]
+
+}
@@ -0,0 +1,142 @@
+package analysis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eveisesi/athena"
+	"github.com/volatiletech/null"
+)
+
+func TestAnalyzeWalletJournal(t *testing.T) {
+
+	const memberID uint = 90000001
+
+	member := &athena.Member{ID: memberID, CreatedAt: time.Date(2021, 3, 15, 0, 0, 0, 0, time.UTC)}
+	journal := func(id uint64, refType athena.RefType, amount null.Float64, from, to uint, date time.Time) *athena.MemberWalletJournal {
+		partyType := func(id uint) null.String {
+			if athena.IsNPCCorporation(id) {
+				return null.StringFrom("corporation")
+			}
+			return null.StringFrom("character")
+		}
+
+		return &athena.MemberWalletJournal{
+			MemberID:        memberID,
+			JournalID:       id,
+			RefType:         refType,
+			Amount:          amount,
+			FirstPartyID:    null.UintFrom(from),
+			FirstPartyType:  partyType(from),
+			SecondPartyID:   null.UintFrom(to),
+			SecondPartyType: partyType(to),
+			Date:            date,
+		}
+	}
+
+	entries := []*athena.MemberWalletJournal{
+		journal(1, athena.RefTypeBountyPrizes, null.Float64From(5000000), 1000125, memberID, time.Date(2021, 1, 10, 0, 0, 0, 0, time.UTC)),
+		journal(2, athena.RefTypeMarketTransaction, null.Float64From(-20000000), memberID, 90000010, time.Date(2021, 1, 20, 0, 0, 0, 0, time.UTC)),
+		journal(3, athena.RefTypePlayerDonation, null.Float64From(-2000000000), memberID, 90000010, time.Date(2021, 2, 5, 0, 0, 0, 0, time.UTC)),
+		journal(4, athena.RefTypeMarketTransaction, null.Float64From(10000000), 90000010, memberID, time.Date(2021, 2, 7, 0, 0, 0, 0, time.UTC)),
+		journal(5, athena.RefTypeBountyPrizes, null.Float64{}, 1000125, memberID, time.Date(2021, 2, 8, 0, 0, 0, 0, time.UTC)),
+		journal(6, athena.RefTypePlayerDonation, null.Float64From(500000000), 90000020, memberID, time.Date(2021, 3, 10, 0, 0, 0, 0, time.UTC)),
+	}
+
+	analysis := analyzeWalletJournal(member, entries)
+
+	if analysis.Entries != 6 {
+		t.Errorf("Entries = %d, want 6", analysis.Entries)
+	}
+	if analysis.Income != 515000000 {
+		t.Errorf("Income = %f, want 515000000", analysis.Income)
+	}
+	if analysis.Expense != 2020000000 {
+		t.Errorf("Expense = %f, want 2020000000", analysis.Expense)
+	}
+	if analysis.Net != -1505000000 {
+		t.Errorf("Net = %f, want -1505000000", analysis.Net)
+	}
+
+	months := []struct {
+		month      time.Month
+		entries    uint
+		net        float64
+		categories []athena.WalletJournalCategory
+	}{
+		{time.January, 2, -15000000, []athena.WalletJournalCategory{athena.WalletJournalCategoryBounties, athena.WalletJournalCategoryMarket}},
+		{time.February, 3, -1990000000, []athena.WalletJournalCategory{athena.WalletJournalCategoryBounties, athena.WalletJournalCategoryMarket, athena.WalletJournalCategoryDonations}},
+		{time.March, 1, 500000000, []athena.WalletJournalCategory{athena.WalletJournalCategoryDonations}},
+	}
+
+	if len(analysis.Months) != len(months) {
+		t.Fatalf("got %d months, want %d", len(analysis.Months), len(months))
+	}
+	for i, want := range months {
+		got := analysis.Months[i]
+		if got.Month.Month() != want.month || got.Entries != want.entries || got.Net != want.net {
+			t.Errorf("month %d = %s with %d entries and net %f, want %s with %d entries and net %f", i, got.Month.Month(), got.Entries, got.Net, want.month, want.entries, want.net)
+		}
+
+		if len(got.Categories) != len(want.categories) {
+			t.Errorf("month %d has %d categories, want %d", i, len(got.Categories), len(want.categories))
+			continue
+		}
+		for j, category := range want.categories {
+			if got.Categories[j].Category != category {
+				t.Errorf("month %d category %d = %s, want %s", i, j, got.Categories[j].Category, category)
+			}
+		}
+	}
+
+	// CONCORD pays out bounties and is not a player party
+	if len(analysis.TopCounterparties) != 2 {
+		t.Fatalf("got %d counterparties, want 2", len(analysis.TopCounterparties))
+	}
+	if party := analysis.TopCounterparties[0]; party.PartyID != 90000010 || party.Entries != 3 || party.Sent != 2020000000 || party.Received != 10000000 || party.Volume != 2030000000 {
+		t.Errorf("top counterparty = %+v", party)
+	}
+	if party := analysis.TopCounterparties[1]; party.PartyID != 90000020 || party.Received != 500000000 {
+		t.Errorf("second counterparty = %+v", party)
+	}
+
+	anomalies := []struct {
+		journalID      uint64
+		kind           athena.WalletJournalAnomalyKind
+		counterpartyID uint
+	}{
+		{6, athena.WalletJournalAnomalyKindPreApplicationTransfer, 90000020},
+		{3, athena.WalletJournalAnomalyKindLargeDonation, 90000010},
+	}
+
+	if len(analysis.Anomalies) != len(anomalies) {
+		t.Fatalf("got %d anomalies, want %d", len(analysis.Anomalies), len(anomalies))
+	}
+	for i, want := range anomalies {
+		got := analysis.Anomalies[i]
+		if got.JournalID != want.journalID || got.Kind != want.kind || got.CounterpartyID.Uint != want.counterpartyID {
+			t.Errorf("anomaly %d = %+v, want journal %d of kind %s", i, got, want.journalID, want.kind)
+		}
+	}
+
+}
+
+func TestMedian(t *testing.T) {
+
+	tests := []struct {
+		values []float64
+		want   float64
+	}{
+		{nil, 0},
+		{[]float64{3}, 3},
+		{[]float64{5, 1, 3}, 3},
+		{[]float64{4, 1, 3, 2}, 2.5},
+	}
+
+	for _, test := range tests {
+		if got := median(test.values); got != test.want {
+			t.Errorf("median(%v) = %f, want %f", test.values, got, test.want)
+		}
+	}
+
+}
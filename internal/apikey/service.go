@@ -0,0 +1,357 @@
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/eveisesi/athena"
+	"github.com/eveisesi/athena/internal/cache"
+	"github.com/sirupsen/logrus"
+	"github.com/volatiletech/null"
+)
+
+type Service interface {
+	APIKey(ctx context.Context, id uint) (*athena.APIKey, error)
+	APIKeys(ctx context.Context, operators ...*athena.Operator) ([]*athena.APIKey, error)
+	CreateAPIKey(ctx context.Context, name string, queries []string, members []uint, rateLimit uint, createdBy null.Uint) (*athena.APIKey, string, error)
+	RevokeAPIKey(ctx context.Context, id uint) error
+	Authenticate(ctx context.Context, key string) (*athena.APIKey, error)
+	Middleware(next http.Handler) http.Handler
+	ContextWithAPIKey(ctx context.Context, plain string) (context.Context, error)
+	APIKeyFromContext(ctx context.Context) *athena.APIKey
+	IsAdmin(memberID uint) bool
+	IsRecruiter(memberID uint) bool
+	AuthorizeMember(ctx context.Context, member *athena.Member, memberID uint) error
+	AuthorizeApplicant(ctx context.Context, member *athena.Member, memberID uint) error
+}
+
+type service struct {
+	logger *logrus.Logger
+
+	cache cache.Service
+
+	keys athena.APIKeyRepository
+
+	admins     map[uint]bool
+	recruiters map[uint]bool
+}
+
+type ctxKey struct {
+	name string
+}
+
+const (
+	serviceIdentifier = "APIKey Service"
+
+	// HeaderName is the request header API keys are presented in
+	HeaderName = "X-Api-Key"
+
+	// DefaultRateLimit is the number of requests per minute a key may make when no limit is provided
+	DefaultRateLimit uint = 60
+
+	keyPrefix     = "athena_"
+	displayLength = 12
+	rateWindow    = time.Minute
+)
+
+var apiKeyCtxKey = ctxKey{name: "apikey"}
+
+var (
+	ErrInvalidAPIKey = errors.New("api key is invalid or has been revoked")
+	ErrRateLimited   = errors.New("api key has exceeded its rate limit")
+	// ErrUnauthenticated is returned by AuthorizeMember when a request carries neither an API Key nor a session
+	ErrUnauthenticated = errors.New("request is not authenticated")
+)
+
+// NewService returns a service that manages API keys. Admins are the members that may create keys
+// through the GraphQL API, every other key has to be created with the CLI. Recruiters, and admins,
+// are the members that may review the applications of other members, see AuthorizeApplicant
+func NewService(logger *logrus.Logger, cache cache.Service, keys athena.APIKeyRepository, admins, recruiters []uint) Service {
+
+	a := make(map[uint]bool, len(admins))
+	for _, id := range admins {
+		a[id] = true
+	}
+
+	r := make(map[uint]bool, len(recruiters))
+	for _, id := range recruiters {
+		r[id] = true
+	}
+
+	return &service{
+		logger: logger,
+
+		cache: cache,
+
+		keys: keys,
+
+		admins:     a,
+		recruiters: r,
+	}
+
+}
+
+func (s *service) APIKey(ctx context.Context, id uint) (*athena.APIKey, error) {
+	return s.keys.APIKey(ctx, id)
+}
+
+func (s *service) APIKeys(ctx context.Context, operators ...*athena.Operator) ([]*athena.APIKey, error) {
+	return s.keys.APIKeys(ctx, operators...)
+}
+
+// CreateAPIKey generates a new key and stores its hash. The plain text key is only ever
+// returned from this function, it cannot be recovered afterwards.
+func (s *service) CreateAPIKey(ctx context.Context, name string, queries []string, members []uint, rateLimit uint, createdBy null.Uint) (*athena.APIKey, string, error) {
+
+	entry := s.logger.WithContext(ctx).WithFields(logrus.Fields{
+		"service": serviceIdentifier,
+		"method":  "CreateAPIKey",
+	})
+
+	if name == "" {
+		return nil, "", fmt.Errorf("name is required")
+	}
+
+	if rateLimit == 0 {
+		rateLimit = DefaultRateLimit
+	}
+
+	b := make([]byte, 32)
+	_, err := rand.Read(b)
+	if err != nil {
+		entry.WithError(err).Error("failed to generate api key")
+		return nil, "", fmt.Errorf("failed to generate api key")
+	}
+
+	plain := keyPrefix + base64.RawURLEncoding.EncodeToString(b)
+
+	allowedMembers := make(athena.SliceUint, 0, len(members))
+	for _, m := range members {
+		allowedMembers = append(allowedMembers, uint64(m))
+	}
+
+	key, err := s.keys.CreateAPIKey(ctx, &athena.APIKey{
+		Name:           name,
+		Prefix:         plain[:displayLength],
+		Hash:           hashKey(plain),
+		AllowedQueries: athena.SliceString(queries),
+		AllowedMembers: allowedMembers,
+		RateLimit:      rateLimit,
+		CreatedBy:      createdBy,
+	})
+	if err != nil {
+		entry.WithError(err).Error("failed to create api key")
+		return nil, "", fmt.Errorf("failed to create api key")
+	}
+
+	return key, plain, nil
+
+}
+
+func (s *service) RevokeAPIKey(ctx context.Context, id uint) error {
+
+	entry := s.logger.WithContext(ctx).WithFields(logrus.Fields{
+		"api_key_id": id,
+		"service":    serviceIdentifier,
+		"method":     "RevokeAPIKey",
+	})
+
+	key, err := s.keys.APIKey(ctx, id)
+	if err != nil {
+		entry.WithError(err).Error("failed to fetch api key")
+		return fmt.Errorf("failed to fetch api key")
+	}
+
+	if key == nil {
+		return fmt.Errorf("api key %d does not exist", id)
+	}
+
+	if key.Revoked {
+		return nil
+	}
+
+	key.Revoked = true
+	key.RevokedAt.SetValid(time.Now())
+
+	_, err = s.keys.UpdateAPIKey(ctx, key.ID, key)
+	if err != nil {
+		entry.WithError(err).Error("failed to revoke api key")
+		return fmt.Errorf("failed to revoke api key")
+	}
+
+	err = s.cache.DeleteAPIKey(ctx, key.Hash)
+	if err != nil {
+		entry.WithError(err).Error("failed to remove api key from cache")
+	}
+
+	return nil
+
+}
+
+// Authenticate resolves the provided plain text key to a valid, unrevoked APIKey and
+// counts the request against the rate limit of the key
+func (s *service) Authenticate(ctx context.Context, plain string) (*athena.APIKey, error) {
+
+	if !strings.HasPrefix(plain, keyPrefix) {
+		return nil, ErrInvalidAPIKey
+	}
+
+	hash := hashKey(plain)
+
+	key, err := s.cache.APIKey(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	if key == nil {
+		keys, err := s.keys.APIKeys(ctx, athena.NewEqualOperator("hash", hash))
+		if err != nil {
+			return nil, err
+		}
+
+		if len(keys) != 1 {
+			return nil, ErrInvalidAPIKey
+		}
+
+		key = keys[0]
+
+		_ = s.cache.SetAPIKey(ctx, key)
+	}
+
+	if key.Revoked {
+		return nil, ErrInvalidAPIKey
+	}
+
+	count, err := s.cache.IncrementAPIKeyUsage(ctx, key.ID, rateWindow)
+	if err != nil {
+		return nil, err
+	}
+
+	if count > int64(key.RateLimit) {
+		return nil, ErrRateLimited
+	}
+
+	return key, nil
+
+}
+
+// Middleware authenticates requests that present an API Key. Unlike sessions, a key that
+// is presented but cannot be used rejects the request outright.
+func (s *service) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		var ctx = r.Context()
+
+		plain := r.Header.Get(HeaderName)
+		if plain == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ctx, err := s.ContextWithAPIKey(ctx, plain)
+		if err != nil {
+			status := http.StatusUnauthorized
+			switch {
+			case errors.Is(err, ErrRateLimited):
+				status = http.StatusTooManyRequests
+				w.Header().Set("Retry-After", fmt.Sprintf("%d", int(rateWindow.Seconds())))
+			case !errors.Is(err, ErrInvalidAPIKey):
+				s.logger.WithError(err).Error("failed to authenticate api key")
+				status = http.StatusInternalServerError
+				err = fmt.Errorf("failed to authenticate api key")
+			}
+
+			w.WriteHeader(status)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"message": err.Error(),
+			})
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+
+	})
+}
+
+// ContextWithAPIKey authenticates a plain text key and returns a copy of ctx that carries it
+func (s *service) ContextWithAPIKey(ctx context.Context, plain string) (context.Context, error) {
+
+	key, err := s.Authenticate(ctx, plain)
+	if err != nil {
+		return ctx, err
+	}
+
+	return context.WithValue(ctx, apiKeyCtxKey, key), nil
+
+}
+
+func (s *service) APIKeyFromContext(ctx context.Context) *athena.APIKey {
+
+	key, ok := ctx.Value(apiKeyCtxKey).(*athena.APIKey)
+	if !ok {
+		return nil
+	}
+
+	return key
+
+}
+
+func (s *service) IsAdmin(memberID uint) bool {
+	return s.admins[memberID]
+}
+
+func (s *service) IsRecruiter(memberID uint) bool {
+	return s.admins[memberID] || s.recruiters[memberID]
+}
+
+// AuthorizeMember verifies that a request may access the data of the member identified by memberID.
+// Requests authenticated with an API Key may access the members that the key has been scoped to,
+// anybody else may only access the member that they are logged in as, which is passed as member
+func (s *service) AuthorizeMember(ctx context.Context, member *athena.Member, memberID uint) error {
+
+	if key := s.APIKeyFromContext(ctx); key != nil {
+		if !key.AllowsMember(memberID) {
+			return fmt.Errorf("api key is not permitted to access member %d", memberID)
+		}
+
+		return nil
+	}
+
+	if member == nil {
+		return ErrUnauthenticated
+	}
+
+	if member.ID != memberID {
+		return fmt.Errorf("not permitted to access member %d", memberID)
+	}
+
+	return nil
+
+}
+
+// AuthorizeApplicant verifies that a request may review the application of the member identified by
+// memberID, such as their vetting report or red flags. On top of what AuthorizeMember allows, members
+// that are logged in as a recruiter may review every member. API Keys are not widened, a key that
+// is used to review applicants should be scoped to them or left unscoped
+func (s *service) AuthorizeApplicant(ctx context.Context, member *athena.Member, memberID uint) error {
+
+	if s.APIKeyFromContext(ctx) == nil && member != nil && s.IsRecruiter(member.ID) {
+		return nil
+	}
+
+	return s.AuthorizeMember(ctx, member, memberID)
+
+}
+
+func hashKey(plain string) string {
+	sum := sha256.Sum256([]byte(plain))
+	return fmt.Sprintf("%x", sum)
+}
@@ -0,0 +1,93 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/eveisesi/athena"
+)
+
+func TestAuthorizeMember(t *testing.T) {
+
+	scoped := &athena.APIKey{AllowedMembers: athena.SliceUint{1}}
+	unscoped := &athena.APIKey{}
+
+	var tests = []struct {
+		name     string
+		key      *athena.APIKey
+		member   *athena.Member
+		memberID uint
+		wantErr  error
+		allowed  bool
+	}{
+		{name: "scoped key, granted member", key: scoped, memberID: 1, allowed: true},
+		{name: "scoped key, other member", key: scoped, memberID: 2},
+		{name: "unscoped key", key: unscoped, memberID: 2, allowed: true},
+		{name: "key is checked before the session", key: scoped, member: &athena.Member{ID: 2}, memberID: 2},
+		{name: "self", member: &athena.Member{ID: 1}, memberID: 1, allowed: true},
+		{name: "other member", member: &athena.Member{ID: 1}, memberID: 2},
+		{name: "unauthenticated", memberID: 1, wantErr: ErrUnauthenticated},
+	}
+
+	s := &service{}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			if test.key != nil {
+				ctx = context.WithValue(ctx, apiKeyCtxKey, test.key)
+			}
+
+			err := s.AuthorizeMember(ctx, test.member, test.memberID)
+			if (err == nil) != test.allowed {
+				t.Fatalf("AuthorizeMember() error = %v, allowed %t", err, test.allowed)
+			}
+			if test.wantErr != nil && !errors.Is(err, test.wantErr) {
+				t.Errorf("AuthorizeMember() error = %v, want %v", err, test.wantErr)
+			}
+		})
+	}
+
+}
+
+func TestAuthorizeApplicant(t *testing.T) {
+
+	const adminID, recruiterID = 10, 11
+
+	scoped := &athena.APIKey{AllowedMembers: athena.SliceUint{1}}
+
+	var tests = []struct {
+		name     string
+		key      *athena.APIKey
+		member   *athena.Member
+		memberID uint
+		allowed  bool
+	}{
+		{name: "recruiter", member: &athena.Member{ID: recruiterID}, memberID: 2, allowed: true},
+		{name: "admin", member: &athena.Member{ID: adminID}, memberID: 2, allowed: true},
+		{name: "self", member: &athena.Member{ID: 2}, memberID: 2, allowed: true},
+		{name: "other member", member: &athena.Member{ID: 1}, memberID: 2},
+		{name: "scoped key, granted member", key: scoped, memberID: 1, allowed: true},
+		{name: "scoped key, other member", key: scoped, memberID: 2},
+		{name: "scoped key is not widened by a recruiter session", key: scoped, member: &athena.Member{ID: recruiterID}, memberID: 2},
+		{name: "unauthenticated", memberID: 2},
+	}
+
+	s := NewService(nil, nil, nil, []uint{adminID}, []uint{recruiterID})
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			if test.key != nil {
+				ctx = context.WithValue(ctx, apiKeyCtxKey, test.key)
+			}
+
+			err := s.AuthorizeApplicant(ctx, test.member, test.memberID)
+			if (err == nil) != test.allowed {
+				t.Errorf("AuthorizeApplicant() error = %v, allowed %t", err, test.allowed)
+			}
+		})
+	}
+
+}
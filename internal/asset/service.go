@@ -18,7 +18,8 @@ import (
 
 type Service interface {
 	EmptyMemberAssets(ctx context.Context, member *athena.Member) (*athena.Etag, error)
-	MemberAssets(ctx context.Context, memberID, page uint) ([]*athena.MemberAsset, error)
+	MemberAssets(ctx context.Context, memberID uint, page *athena.Page, operators ...*athena.Operator) ([]*athena.MemberAsset, error)
+	MemberAssetCount(ctx context.Context, memberID uint, operators ...*athena.Operator) (uint, error)
 }
 
 type service struct {
@@ -69,7 +70,7 @@ func (s *service) EmptyMemberAssets(ctx context.Context, member *athena.Member)
 	_, res, err := s.esi.HeadCharacterAssets(ctx, member.ID, 1, member.AccessToken.String)
 	if err != nil {
 		entry.WithError(err).Error("failed to exec head request for member assets from ESI")
-		return nil, fmt.Errorf("failed to exec head request for member assets from ESI")
+		return nil, fmt.Errorf("failed to exec head request for member assets from ESI: %w", err)
 	}
 
 	pages := esi.RetrieveXPagesFromHeader(res.Header)
@@ -80,7 +81,7 @@ func (s *service) EmptyMemberAssets(ctx context.Context, member *athena.Member)
 		newAssets, _, _, err := s.esi.GetCharacterAssets(ctx, member.ID, page, member.AccessToken.String)
 		if err != nil {
 			entry.WithError(err).Error("failed to fetch member assets from ESI")
-			return nil, fmt.Errorf("failed to fetch member assets from ESI")
+			return nil, fmt.Errorf("failed to fetch member assets from ESI: %w", err)
 		}
 
 		if len(newAssets) == 0 {
@@ -240,39 +241,36 @@ func (s *service) resolveMemberAssetsAttributes(ctx context.Context, member *ath
 
 }
 
-func (s *service) MemberAssets(ctx context.Context, memberID, page uint) ([]*athena.MemberAsset, error) {
+func (s *service) MemberAssets(ctx context.Context, memberID uint, page *athena.Page, operators ...*athena.Operator) ([]*athena.MemberAsset, error) {
 
 	entry := s.logger.WithContext(ctx).WithFields(logrus.Fields{
-		"service":     serviceIdentifier,
-		"method":      "Characters",
-		"member_id":   memberID,
-		"source_page": page,
+		"service":   serviceIdentifier,
+		"method":    "MemberAssets",
+		"member_id": memberID,
 	})
 
-	// assets, err := s.cache.MemberAssets(ctx, memberID, page)
-	// if err != nil {
-	// 	entry.WithError(err).Error("failed to fetch member contacts from cache")
-	// 	return nil, fmt.Errorf("failed to fetch member contacts from cache")
-	// }
+	assets, err := s.assets.MemberAssets(ctx, memberID, append(operators, page.Operators("item_id", athena.SortAsc)...)...)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		entry.WithError(err).Error("failed to fetch member assets from DB")
+		return nil, fmt.Errorf("failed to fetch member assets from DB")
+	}
 
-	// if len(assets) > 0 {
-	// 	return assets, nil
-	// }
+	return assets, nil
 
-	assets, err := s.assets.MemberAssets(ctx, memberID, athena.NewEqualOperator("source_page", page))
-	if err != nil {
-		entry.WithError(err).Error("failed to fetch member contacts from DB")
-		return nil, fmt.Errorf("failed to fetch member contacts from DB")
-	}
+}
 
-	// if len(assets) > 0 {
-	// 	err = s.cache.SetMemberAssets(ctx, memberID, page, assets)
-	// 	if err != nil {
-	// 		entry.WithError(err).Error("failed to cache member contacts")
+func (s *service) MemberAssetCount(ctx context.Context, memberID uint, operators ...*athena.Operator) (uint, error) {
 
-	// 	}
-	// }
+	count, err := s.assets.CountMemberAssets(ctx, memberID, operators...)
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithFields(logrus.Fields{
+			"service":   serviceIdentifier,
+			"method":    "MemberAssetCount",
+			"member_id": memberID,
+		}).Error("failed to count member assets in DB")
+		return 0, fmt.Errorf("failed to count member assets in DB")
+	}
 
-	return assets, nil
+	return count, nil
 
 }
@@ -2,16 +2,18 @@ package auth
 
 import (
 	"context"
-	"crypto/hmac"
 	"crypto/sha256"
+	"encoding/base64"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/eveisesi/athena"
 	"github.com/eveisesi/athena/internal/cache"
+	"github.com/korovkin/limiter"
 	"github.com/lestrrat-go/jwx/jwk"
 	"github.com/lestrrat-go/jwx/jwt"
 	"golang.org/x/oauth2"
@@ -21,12 +23,19 @@ type Service interface {
 	InitializeAttempt(ctx context.Context) (*athena.AuthAttempt, error)
 	AuthAttempt(ctx context.Context, hash string) (*athena.AuthAttempt, error)
 	UpdateAuthAttempt(ctx context.Context, hash string, attempt *athena.AuthAttempt) (*athena.AuthAttempt, error)
-	// RefreshExpiredTokens(ctx context.Context []*athena.Member) ([]*athena.Member, error)
+	ClaimAuthAttempt(ctx context.Context, attempt *athena.AuthAttempt) (bool, error)
+	RefreshExpiredTokens(ctx context.Context, members []*athena.Member, concurrency int) ([]*athena.Member, []*athena.TokenRefreshFailure)
 
 	ValidateToken(ctx context.Context, member *athena.Member) (*athena.Member, error)
-	AuthorizationURI(ctx context.Context, state string, scopes []string) string
-	BearerForCode(ctx context.Context, code string) (*oauth2.Token, error)
+	AuthorizationURI(ctx context.Context, attempt *athena.AuthAttempt, scopes []string) string
+	BearerForCode(ctx context.Context, code string, attempt *athena.AuthAttempt) (*oauth2.Token, error)
 	ParseAndVerifyToken(ctx context.Context, t string) (jwt.Token, error)
+
+	CreateSession(ctx context.Context, memberID uint) (*athena.SessionToken, error)
+	RefreshSession(ctx context.Context, refreshToken string) (*athena.SessionToken, error)
+	ParseSessionToken(ctx context.Context, t string) (*athena.Session, error)
+	RevokeSession(ctx context.Context, session *athena.Session) error
+	RevokeMemberSessions(ctx context.Context, memberID uint) error
 }
 
 type service struct {
@@ -34,29 +43,51 @@ type service struct {
 	oauth *oauth2.Config
 	cache cache.Service
 
-	client  *http.Client
-	jwksURI string
+	client    *http.Client
+	jwksURI   string
+	validator *TokenValidator
+
+	attemptTTL time.Duration
+
+	sessionKey        []byte
+	sessionTTL        time.Duration
+	sessionRefreshTTL time.Duration
 }
 
 // authRepo athena.AuthRepository
-func NewService(cache cache.Service, oauth *oauth2.Config, client *http.Client, jwksURI string) *service {
+func NewService(cache cache.Service, oauth *oauth2.Config, client *http.Client, jwksURI string, attemptTTL time.Duration, sessionKey []byte, sessionTTL, sessionRefreshTTL time.Duration) *service {
 	return &service{
-		cache:   cache,
-		oauth:   oauth,
-		client:  client,
-		jwksURI: jwksURI,
+		cache:     cache,
+		oauth:     oauth,
+		client:    client,
+		jwksURI:   jwksURI,
+		validator: NewTokenValidator(oauth.ClientID),
+
+		attemptTTL: attemptTTL,
+
+		sessionKey:        sessionKey,
+		sessionTTL:        sessionTTL,
+		sessionRefreshTTL: sessionRefreshTTL,
 	}
 }
 
 func (s *service) InitializeAttempt(ctx context.Context) (*athena.AuthAttempt, error) {
 
-	h := hmac.New(sha256.New, nil)
-	_, _ = h.Write([]byte(time.Now().Format(time.RFC3339Nano)))
-	b := h.Sum(nil)
+	state, err := randomString(32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate state: %w", err)
+	}
+
+	verifier, err := randomString(32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate code verifier: %w", err)
+	}
 
 	attempt := &athena.AuthAttempt{
-		Status: athena.PendingAuthStatus,
-		State:  fmt.Sprintf("%x", string(b)),
+		Status:       athena.PendingAuthStatus,
+		State:        state,
+		CodeVerifier: verifier,
+		Expires:      time.Now().Add(s.attemptTTL),
 	}
 
 	return s.cache.CreateAuthAttempt(ctx, attempt)
@@ -75,6 +106,10 @@ func (s *service) AuthAttempt(ctx context.Context, hash string) (*athena.AuthAtt
 		attempt.Status = athena.InvalidAuthStatus
 	}
 
+	if attempt.Status == athena.PendingAuthStatus && attempt.IsExpired() {
+		attempt.Status = athena.ExpiredAuthStatus
+	}
+
 	return attempt, nil
 
 }
@@ -92,13 +127,31 @@ func (s *service) UpdateAuthAttempt(ctx context.Context, hash string, attempt *a
 
 }
 
-func (s *service) AuthorizationURI(ctx context.Context, state string, scopes []string) string {
+// ClaimAuthAttempt deletes a completed attempt before its session tokens are handed to the client,
+// so that nobody else holding the state can read them again. False is returned when the attempt was
+// already claimed, in which case the tokens must not be handed out
+func (s *service) ClaimAuthAttempt(ctx context.Context, attempt *athena.AuthAttempt) (bool, error) {
+
+	if attempt.Status != athena.CompletedAuthStatus {
+		return false, fmt.Errorf("attempt has not been completed")
+	}
+
+	return s.cache.DeleteAuthAttempt(ctx, attempt.State)
+
+}
+
+func (s *service) AuthorizationURI(ctx context.Context, attempt *athena.AuthAttempt, scopes []string) string {
 	strScopes := ""
 	if len(scopes) > 0 {
 		strScopes = strings.Join(scopes, " ")
 	}
 
-	return s.oauth.AuthCodeURL(state, oauth2.SetAuthURLParam("scope", strScopes))
+	return s.oauth.AuthCodeURL(
+		attempt.State,
+		oauth2.SetAuthURLParam("scope", strScopes),
+		oauth2.SetAuthURLParam("code_challenge", codeChallenge(attempt.CodeVerifier)),
+		oauth2.SetAuthURLParam("code_challenge_method", "S256"),
+	)
 }
 
 func (s *service) ValidateToken(ctx context.Context, member *athena.Member) (*athena.Member, error) {
@@ -126,8 +179,58 @@ func (s *service) ValidateToken(ctx context.Context, member *athena.Member) (*at
 
 }
 
-func (s *service) BearerForCode(ctx context.Context, code string) (*oauth2.Token, error) {
-	return s.oauth.Exchange(ctx, code)
+// RefreshExpiredTokens exchanges the refresh token of each member for a new access token, regardless of whether the
+// current access token has expired yet. At most concurrency refreshes are in flight at once. The members that were
+// refreshed successfully are returned alongside a failure for every member that could not be refreshed.
+func (s *service) RefreshExpiredTokens(ctx context.Context, members []*athena.Member, concurrency int) ([]*athena.Member, []*athena.TokenRefreshFailure) {
+
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, s.client)
+
+	var mx sync.Mutex
+	refreshed := make([]*athena.Member, 0, len(members))
+	failures := make([]*athena.TokenRefreshFailure, 0)
+
+	limit := limiter.NewConcurrencyLimiter(concurrency)
+	for _, member := range members {
+		member := member
+		limit.Execute(func() {
+			// Omitting the access token forces the token source to use the refresh token
+			token := &oauth2.Token{RefreshToken: member.RefreshToken.String}
+
+			newToken, err := s.oauth.TokenSource(ctx, token).Token()
+
+			mx.Lock()
+			defer mx.Unlock()
+
+			if err != nil {
+				failures = append(failures, &athena.TokenRefreshFailure{
+					MemberID:  member.ID,
+					Error:     err.Error(),
+					Timestamp: time.Now(),
+				})
+				return
+			}
+
+			member.AccessToken.SetValid(newToken.AccessToken)
+			member.Expires.SetValid(newToken.Expiry)
+			member.RefreshToken.SetValid(newToken.RefreshToken)
+			refreshed = append(refreshed, member)
+		})
+	}
+	limit.Wait()
+
+	return refreshed, failures
+
+}
+
+func (s *service) BearerForCode(ctx context.Context, code string, attempt *athena.AuthAttempt) (*oauth2.Token, error) {
+	return s.oauth.Exchange(ctx, code, oauth2.SetAuthURLParam("code_verifier", attempt.CodeVerifier))
+}
+
+// codeChallenge derives the S256 PKCE challenge for the provided verifier
+func codeChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
 }
 
 func (s *service) ParseAndVerifyToken(ctx context.Context, t string) (jwt.Token, error) {
@@ -137,17 +240,7 @@ func (s *service) ParseAndVerifyToken(ctx context.Context, t string) (jwt.Token,
 		return nil, fmt.Errorf("failed to fetch jwks: %w", err)
 	}
 
-	token, err := jwt.ParseString(t, jwt.WithKeySet(set))
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse token: %w", err)
-	}
-
-	// err = jwt.Validate(token, jwt.WithIssuer("login.eveonline.com"), jwt.WithClaimValue("azp", "27a0d315019c4d15bf909abefe67282b"))
-	// if err != nil {
-	// 	return nil, fmt.Errorf("failed to validate token: %w", err)
-	// }
-
-	return token, nil
+	return s.validator.ParseAndValidate(t, set)
 
 }
 
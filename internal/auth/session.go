@@ -0,0 +1,165 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/eveisesi/athena"
+	"github.com/lestrrat-go/jwx/jwa"
+	"github.com/lestrrat-go/jwx/jwt"
+)
+
+const (
+	sessionIssuer  = "athena"
+	sessionSubject = "CHARACTER:EVE:%d"
+)
+
+// CreateSession starts a new session for the member and returns the first pair of tokens for it
+func (s *service) CreateSession(ctx context.Context, memberID uint) (*athena.SessionToken, error) {
+
+	id, err := randomString(16)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate session id: %w", err)
+	}
+
+	session := &athena.Session{
+		ID:        id,
+		MemberID:  memberID,
+		CreatedAt: time.Now(),
+	}
+
+	return s.issueSession(ctx, session)
+
+}
+
+// RefreshSession exchanges a refresh token for a new pair of tokens. The refresh token
+// is rotated, so the token that was presented can not be used a second time.
+func (s *service) RefreshSession(ctx context.Context, refreshToken string) (*athena.SessionToken, error) {
+
+	parts := strings.SplitN(refreshToken, ".", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid refresh token format")
+	}
+
+	session, err := s.cache.Session(ctx, parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch session: %w", err)
+	}
+
+	if session == nil {
+		return nil, fmt.Errorf("session is expired or has been revoked")
+	}
+
+	if subtle.ConstantTimeCompare([]byte(hashSecret(parts[1])), []byte(session.RefreshHash)) != 1 {
+		return nil, fmt.Errorf("invalid refresh token")
+	}
+
+	return s.issueSession(ctx, session)
+
+}
+
+// ParseSessionToken verifies the signature and claims of a session access token
+// and returns the session it was issued for, provided the session has not been revoked
+func (s *service) ParseSessionToken(ctx context.Context, t string) (*athena.Session, error) {
+
+	token, err := jwt.ParseString(t, jwt.WithVerify(jwa.HS256, s.sessionKey))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse token: %w", err)
+	}
+
+	if token.Issuer() != sessionIssuer || token.JwtID() == "" || token.Expiration().IsZero() {
+		return nil, fmt.Errorf("token is not an athena session token")
+	}
+
+	err = jwt.Validate(token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate token: %w", err)
+	}
+
+	session, err := s.cache.Session(ctx, token.JwtID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch session: %w", err)
+	}
+
+	if session == nil {
+		return nil, fmt.Errorf("session is expired or has been revoked")
+	}
+
+	if token.Subject() != fmt.Sprintf(sessionSubject, session.MemberID) {
+		return nil, fmt.Errorf("token subject does not match session")
+	}
+
+	return session, nil
+
+}
+
+func (s *service) RevokeSession(ctx context.Context, session *athena.Session) error {
+	return s.cache.DeleteSession(ctx, session)
+}
+
+func (s *service) RevokeMemberSessions(ctx context.Context, memberID uint) error {
+	return s.cache.DeleteMemberSessions(ctx, memberID)
+}
+
+func (s *service) issueSession(ctx context.Context, session *athena.Session) (*athena.SessionToken, error) {
+
+	secret, err := randomString(32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+
+	now := time.Now()
+
+	session.RefreshHash = hashSecret(secret)
+	session.Expires = now.Add(s.sessionRefreshTTL)
+	session.UpdatedAt = now
+
+	err = s.cache.SetSession(ctx, session, s.sessionRefreshTTL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save session: %w", err)
+	}
+
+	expires := now.Add(s.sessionTTL)
+
+	token := jwt.New()
+	_ = token.Set(jwt.IssuerKey, sessionIssuer)
+	_ = token.Set(jwt.SubjectKey, fmt.Sprintf(sessionSubject, session.MemberID))
+	_ = token.Set(jwt.JwtIDKey, session.ID)
+	_ = token.Set(jwt.IssuedAtKey, now)
+	_ = token.Set(jwt.ExpirationKey, expires)
+
+	signed, err := jwt.Sign(token, jwa.HS256, s.sessionKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign session token: %w", err)
+	}
+
+	return &athena.SessionToken{
+		AccessToken:  string(signed),
+		RefreshToken: fmt.Sprintf("%s.%s", session.ID, secret),
+		Expires:      expires,
+	}, nil
+
+}
+
+func randomString(size int) (string, error) {
+
+	b := make([]byte, size)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+
+}
+
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return fmt.Sprintf("%x", sum)
+}
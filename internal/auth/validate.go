@@ -0,0 +1,164 @@
+package auth
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lestrrat-go/jwx/jwk"
+	"github.com/lestrrat-go/jwx/jwt"
+)
+
+const (
+	ssoIssuer         = "login.eveonline.com"
+	ssoAudience       = "EVE Online"
+	ssoAuthorizedKey  = "azp"
+	defaultClockSkew  = time.Second * 30
+	ssoIssuerHTTPSFmt = "https://%s"
+)
+
+type MissingClaimError struct {
+	Claim string
+}
+
+func (e MissingClaimError) Error() string {
+	return fmt.Sprintf("token is missing required claim %s", e.Claim)
+}
+
+type InvalidIssuerError struct {
+	Issuer string
+}
+
+func (e InvalidIssuerError) Error() string {
+	return fmt.Sprintf("token issuer %q is not trusted", e.Issuer)
+}
+
+type InvalidAudienceError struct {
+	Audience []string
+}
+
+func (e InvalidAudienceError) Error() string {
+	return fmt.Sprintf("token audience [%s] does not include %q", strings.Join(e.Audience, ", "), ssoAudience)
+}
+
+type InvalidAuthorizedPartyError struct {
+	AuthorizedParty string
+}
+
+func (e InvalidAuthorizedPartyError) Error() string {
+	return fmt.Sprintf("token was issued to %q, not this application", e.AuthorizedParty)
+}
+
+type TokenExpiredError struct {
+	Expiration time.Time
+}
+
+func (e TokenExpiredError) Error() string {
+	return fmt.Sprintf("token expired at %s", e.Expiration.Format(time.RFC3339))
+}
+
+type TokenNotYetValidError struct {
+	NotBefore time.Time
+}
+
+func (e TokenNotYetValidError) Error() string {
+	return fmt.Sprintf("token is not valid before %s", e.NotBefore.Format(time.RFC3339))
+}
+
+// TokenValidator holds the expectations an access token issued by the EVE SSO must satisfy.
+// The Clock can be swapped out so that expiry can be checked against a fixed point in time.
+type TokenValidator struct {
+	Issuers  []string
+	Audience string
+	ClientID string
+	Skew     time.Duration
+	Clock    jwt.Clock
+}
+
+// NewTokenValidator returns a TokenValidator that only accepts tokens issued by
+// the EVE SSO to the application identified by clientID
+func NewTokenValidator(clientID string) *TokenValidator {
+	return &TokenValidator{
+		Issuers:  []string{ssoIssuer, fmt.Sprintf(ssoIssuerHTTPSFmt, ssoIssuer)},
+		Audience: ssoAudience,
+		ClientID: clientID,
+		Skew:     defaultClockSkew,
+		Clock:    jwt.ClockFunc(time.Now),
+	}
+}
+
+// ParseAndValidate verifies the signature of t against the keys in set and then validates its claims
+func (v *TokenValidator) ParseAndValidate(t string, set *jwk.Set) (jwt.Token, error) {
+
+	token, err := jwt.ParseString(t, jwt.WithKeySet(set))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse token: %w", err)
+	}
+
+	err = v.Validate(token)
+	if err != nil {
+		return nil, err
+	}
+
+	return token, nil
+
+}
+
+// Validate checks the issuer, audience, authorized party, expiry and not before claims of token
+func (v *TokenValidator) Validate(token jwt.Token) error {
+
+	issuer := token.Issuer()
+	if issuer == "" {
+		return MissingClaimError{Claim: jwt.IssuerKey}
+	}
+
+	trusted := false
+	for _, i := range v.Issuers {
+		if issuer == i {
+			trusted = true
+			break
+		}
+	}
+	if !trusted {
+		return InvalidIssuerError{Issuer: issuer}
+	}
+
+	audience := token.Audience()
+	found := false
+	for _, a := range audience {
+		if a == v.Audience {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return InvalidAudienceError{Audience: audience}
+	}
+
+	azp, ok := token.Get(ssoAuthorizedKey)
+	if !ok {
+		return MissingClaimError{Claim: ssoAuthorizedKey}
+	}
+
+	if party, _ := azp.(string); party != v.ClientID {
+		return InvalidAuthorizedPartyError{AuthorizedParty: fmt.Sprintf("%v", azp)}
+	}
+
+	now := v.Clock.Now()
+
+	exp := token.Expiration()
+	if exp.IsZero() {
+		return MissingClaimError{Claim: jwt.ExpirationKey}
+	}
+
+	if !now.Before(exp.Add(v.Skew)) {
+		return TokenExpiredError{Expiration: exp}
+	}
+
+	if nbf := token.NotBefore(); !nbf.IsZero() && now.Before(nbf.Add(-v.Skew)) {
+		return TokenNotYetValidError{NotBefore: nbf}
+	}
+
+	return nil
+
+}
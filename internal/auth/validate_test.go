@@ -0,0 +1,205 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/jwa"
+	"github.com/lestrrat-go/jwx/jwk"
+	"github.com/lestrrat-go/jwx/jwt"
+)
+
+const testClientID = "athena-test-client"
+
+// newTestKeys generates an RSA key pair and returns the private key as a signing JWK along with a
+// JWKS that only holds the matching public key
+func newTestKeys(t *testing.T, kid string) (jwk.Key, *jwk.Set) {
+	t.Helper()
+
+	raw, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate rsa key: %s", err)
+	}
+
+	private, err := jwk.New(raw)
+	if err != nil {
+		t.Fatalf("failed to create private jwk: %s", err)
+	}
+
+	public, err := jwk.New(&raw.PublicKey)
+	if err != nil {
+		t.Fatalf("failed to create public jwk: %s", err)
+	}
+
+	for _, key := range []jwk.Key{private, public} {
+		err = key.Set(jwk.KeyIDKey, kid)
+		if err != nil {
+			t.Fatalf("failed to set key id: %s", err)
+		}
+	}
+
+	return private, &jwk.Set{Keys: []jwk.Key{public}}
+}
+
+func signTestToken(t *testing.T, key jwk.Key, claims map[string]interface{}) string {
+	t.Helper()
+
+	token := jwt.New()
+	for k, v := range claims {
+		err := token.Set(k, v)
+		if err != nil {
+			t.Fatalf("failed to set claim %s: %s", k, err)
+		}
+	}
+
+	signed, err := jwt.Sign(token, jwa.RS256, key)
+	if err != nil {
+		t.Fatalf("failed to sign token: %s", err)
+	}
+
+	return string(signed)
+}
+
+func validClaims(now time.Time) map[string]interface{} {
+	return map[string]interface{}{
+		jwt.IssuerKey:     ssoIssuer,
+		jwt.AudienceKey:   []string{testClientID, ssoAudience},
+		jwt.SubjectKey:    "CHARACTER:EVE:90000001",
+		jwt.ExpirationKey: now.Add(time.Minute * 20),
+		jwt.NotBeforeKey:  now.Add(-time.Minute),
+		ssoAuthorizedKey:  testClientID,
+	}
+}
+
+func TestTokenValidatorParseAndValidate(t *testing.T) {
+
+	now := time.Date(2021, 2, 1, 12, 0, 0, 0, time.UTC)
+
+	key, set := newTestKeys(t, "JWT-Signature-Key")
+	otherKey, _ := newTestKeys(t, "JWT-Signature-Key")
+
+	tests := []struct {
+		name   string
+		key    jwk.Key
+		modify func(claims map[string]interface{})
+		check  func(err error) bool
+	}{
+		{
+			name:  "valid token",
+			key:   key,
+			check: func(err error) bool { return err == nil },
+		},
+		{
+			name:   "https issuer",
+			key:    key,
+			modify: func(c map[string]interface{}) { c[jwt.IssuerKey] = "https://" + ssoIssuer },
+			check:  func(err error) bool { return err == nil },
+		},
+		{
+			name:   "untrusted issuer",
+			key:    key,
+			modify: func(c map[string]interface{}) { c[jwt.IssuerKey] = "login.example.com" },
+			check: func(err error) bool {
+				var target InvalidIssuerError
+				return errors.As(err, &target) && target.Issuer == "login.example.com"
+			},
+		},
+		{
+			name:   "missing issuer",
+			key:    key,
+			modify: func(c map[string]interface{}) { delete(c, jwt.IssuerKey) },
+			check: func(err error) bool {
+				var target MissingClaimError
+				return errors.As(err, &target) && target.Claim == jwt.IssuerKey
+			},
+		},
+		{
+			name:   "wrong audience",
+			key:    key,
+			modify: func(c map[string]interface{}) { c[jwt.AudienceKey] = []string{testClientID} },
+			check: func(err error) bool {
+				var target InvalidAudienceError
+				return errors.As(err, &target)
+			},
+		},
+		{
+			name:   "issued to another application",
+			key:    key,
+			modify: func(c map[string]interface{}) { c[ssoAuthorizedKey] = "another-client" },
+			check: func(err error) bool {
+				var target InvalidAuthorizedPartyError
+				return errors.As(err, &target) && target.AuthorizedParty == "another-client"
+			},
+		},
+		{
+			name:   "missing authorized party",
+			key:    key,
+			modify: func(c map[string]interface{}) { delete(c, ssoAuthorizedKey) },
+			check: func(err error) bool {
+				var target MissingClaimError
+				return errors.As(err, &target) && target.Claim == ssoAuthorizedKey
+			},
+		},
+		{
+			name:   "expired",
+			key:    key,
+			modify: func(c map[string]interface{}) { c[jwt.ExpirationKey] = now.Add(-time.Minute) },
+			check: func(err error) bool {
+				var target TokenExpiredError
+				return errors.As(err, &target)
+			},
+		},
+		{
+			name:   "expired within clock skew",
+			key:    key,
+			modify: func(c map[string]interface{}) { c[jwt.ExpirationKey] = now.Add(-time.Second * 10) },
+			check:  func(err error) bool { return err == nil },
+		},
+		{
+			name:   "missing expiration",
+			key:    key,
+			modify: func(c map[string]interface{}) { delete(c, jwt.ExpirationKey) },
+			check: func(err error) bool {
+				var target MissingClaimError
+				return errors.As(err, &target) && target.Claim == jwt.ExpirationKey
+			},
+		},
+		{
+			name:   "not yet valid",
+			key:    key,
+			modify: func(c map[string]interface{}) { c[jwt.NotBeforeKey] = now.Add(time.Minute) },
+			check: func(err error) bool {
+				var target TokenNotYetValidError
+				return errors.As(err, &target)
+			},
+		},
+		{
+			name: "bad signature",
+			key:  otherKey,
+			check: func(err error) bool {
+				return err != nil
+			},
+		},
+	}
+
+	validator := NewTokenValidator(testClientID)
+	validator.Clock = jwt.ClockFunc(func() time.Time { return now })
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			claims := validClaims(now)
+			if test.modify != nil {
+				test.modify(claims)
+			}
+
+			_, err := validator.ParseAndValidate(signTestToken(t, test.key, claims), set)
+			if !test.check(err) {
+				t.Errorf("unexpected result: %v", err)
+			}
+		})
+	}
+
+}
@@ -0,0 +1,90 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/eveisesi/athena"
+	"github.com/go-redis/redis/v8"
+)
+
+type apiKeyService interface {
+	APIKey(ctx context.Context, hash string) (*athena.APIKey, error)
+	SetAPIKey(ctx context.Context, key *athena.APIKey) error
+	DeleteAPIKey(ctx context.Context, hash string) error
+	IncrementAPIKeyUsage(ctx context.Context, id uint, window time.Duration) (int64, error)
+}
+
+const (
+	keyAPIKey      = "athena::apikey::%s"
+	keyAPIKeyUsage = "athena::apikey::%d::usage::%d"
+)
+
+func (s *service) APIKey(ctx context.Context, hash string) (*athena.APIKey, error) {
+
+	result, err := s.client.Get(ctx, fmt.Sprintf(keyAPIKey, hash)).Bytes()
+	if err != nil && err != redis.Nil {
+		return nil, err
+	}
+
+	if len(result) == 0 {
+		return nil, nil
+	}
+
+	var key = new(athena.APIKey)
+	err = json.Unmarshal(result, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal api key onto struct: %w", err)
+	}
+
+	return key, nil
+
+}
+
+func (s *service) SetAPIKey(ctx context.Context, key *athena.APIKey) error {
+
+	data, err := json.Marshal(key)
+	if err != nil {
+		return fmt.Errorf("failed to marshal struct: %w", err)
+	}
+
+	_, err = s.client.Set(ctx, fmt.Sprintf(keyAPIKey, key.Hash), data, time.Hour).Result()
+	if err != nil {
+		return fmt.Errorf("failed to write to cache: %w", err)
+	}
+
+	return nil
+
+}
+
+func (s *service) DeleteAPIKey(ctx context.Context, hash string) error {
+
+	key := fmt.Sprintf(keyAPIKey, hash)
+	_, err := s.client.Del(ctx, key).Result()
+	if err != nil {
+		return fmt.Errorf("[Cache Service] Failed to delete key %s from cache: %w", key, err)
+	}
+
+	return nil
+
+}
+
+// IncrementAPIKeyUsage increments the number of requests that have been made with the key in
+// the current fixed window and returns the updated count
+func (s *service) IncrementAPIKeyUsage(ctx context.Context, id uint, window time.Duration) (int64, error) {
+
+	key := fmt.Sprintf(keyAPIKeyUsage, id, time.Now().UnixNano()/int64(window))
+
+	pipe := s.client.TxPipeline()
+	incr := pipe.Incr(ctx, key)
+	pipe.Expire(ctx, key, window)
+	_, err := pipe.Exec(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("[Cache Service] Failed to increment usage of api key %d: %w", id, err)
+	}
+
+	return incr.Val(), nil
+
+}
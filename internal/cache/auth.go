@@ -15,10 +15,17 @@ type authService interface {
 	SaveJSONWebKeySet(ctx context.Context, jwks []byte) error
 	AuthAttempt(ctx context.Context, hash string) (*athena.AuthAttempt, error)
 	CreateAuthAttempt(ctx context.Context, attempt *athena.AuthAttempt) (*athena.AuthAttempt, error)
+	DeleteAuthAttempt(ctx context.Context, hash string) (bool, error)
+	Session(ctx context.Context, sessionID string) (*athena.Session, error)
+	SetSession(ctx context.Context, session *athena.Session, expires time.Duration) error
+	DeleteSession(ctx context.Context, session *athena.Session) error
+	DeleteMemberSessions(ctx context.Context, memberID uint) error
 }
 
 const keyAuthAttempt = "athena::auth::attempt::%s"
 const keyAuthJWKS = "athena::auth::jwks"
+const keyAuthSession = "athena::auth::session::%s"
+const keyAuthMemberSessions = "athena::auth::member::%d::sessions"
 
 func (s *service) JSONWebKeySet(ctx context.Context) ([]byte, error) {
 
@@ -76,10 +83,125 @@ func (s *service) CreateAuthAttempt(ctx context.Context, attempt *athena.AuthAtt
 		return nil, fmt.Errorf("failed to cache auth attempt: %w", err)
 	}
 
-	_, err = s.client.Set(ctx, fmt.Sprintf(keyAuthAttempt, attempt.State), b, time.Minute*5).Result()
+	// Attempts are kept around for a short while after they expire so that
+	// callers polling for the status of an attempt see it as expired or completed
+	// instead of it simply disappearing
+	expires := time.Minute * 5
+	if !attempt.Expires.IsZero() {
+		expires += time.Until(attempt.Expires)
+	}
+
+	_, err = s.client.Set(ctx, fmt.Sprintf(keyAuthAttempt, attempt.State), b, expires).Result()
 	if err != nil {
 		return nil, fmt.Errorf("failed to create auth attempt: %w", err)
 	}
 
 	return attempt, nil
 }
+
+// DeleteAuthAttempt deletes the attempt and reports whether it still existed. Only one of several
+// concurrent callers ever sees true
+func (s *service) DeleteAuthAttempt(ctx context.Context, hash string) (bool, error) {
+
+	deleted, err := s.client.Del(ctx, fmt.Sprintf(keyAuthAttempt, hash)).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to delete auth attempt: %w", err)
+	}
+
+	return deleted > 0, nil
+
+}
+
+func (s *service) Session(ctx context.Context, sessionID string) (*athena.Session, error) {
+
+	result, err := s.client.Get(ctx, fmt.Sprintf(keyAuthSession, sessionID)).Bytes()
+	if err != nil && err != redis.Nil {
+		return nil, err
+	}
+
+	if len(result) == 0 {
+		return nil, nil
+	}
+
+	var session = new(athena.Session)
+	err = json.Unmarshal(result, session)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal data onto result struct: %w", err)
+	}
+
+	return session, nil
+
+}
+
+// SetSession caches the session and indexes it under the member that owns it
+// so that every session for a member can be revoked at once
+func (s *service) SetSession(ctx context.Context, session *athena.Session, expires time.Duration) error {
+
+	if session.ID == "" {
+		return fmt.Errorf("empty session id provided")
+	}
+
+	data, err := json.Marshal(session)
+	if err != nil {
+		return fmt.Errorf("failed to marshal session: %w", err)
+	}
+
+	_, err = s.client.Set(ctx, fmt.Sprintf(keyAuthSession, session.ID), data, expires).Result()
+	if err != nil {
+		return fmt.Errorf("failed to write session to cache: %w", err)
+	}
+
+	key := fmt.Sprintf(keyAuthMemberSessions, session.MemberID)
+	_, err = s.client.SAdd(ctx, key, session.ID).Result()
+	if err != nil {
+		return fmt.Errorf("failed to index session for member: %w", err)
+	}
+
+	_, err = s.client.Expire(ctx, key, expires).Result()
+	if err != nil {
+		return fmt.Errorf("failed to set expiry for key %s: %w", key, err)
+	}
+
+	return nil
+
+}
+
+func (s *service) DeleteSession(ctx context.Context, session *athena.Session) error {
+
+	_, err := s.client.Del(ctx, fmt.Sprintf(keyAuthSession, session.ID)).Result()
+	if err != nil {
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
+
+	_, err = s.client.SRem(ctx, fmt.Sprintf(keyAuthMemberSessions, session.MemberID), session.ID).Result()
+	if err != nil {
+		return fmt.Errorf("failed to remove session from member index: %w", err)
+	}
+
+	return nil
+
+}
+
+func (s *service) DeleteMemberSessions(ctx context.Context, memberID uint) error {
+
+	key := fmt.Sprintf(keyAuthMemberSessions, memberID)
+
+	sessionIDs, err := s.client.SMembers(ctx, key).Result()
+	if err != nil && err != redis.Nil {
+		return fmt.Errorf("failed to fetch sessions for member: %w", err)
+	}
+
+	keys := make([]string, 0, len(sessionIDs)+1)
+	for _, sessionID := range sessionIDs {
+		keys = append(keys, fmt.Sprintf(keyAuthSession, sessionID))
+	}
+	keys = append(keys, key)
+
+	_, err = s.client.Del(ctx, keys...).Result()
+	if err != nil {
+		return fmt.Errorf("failed to delete sessions for member: %w", err)
+	}
+
+	return nil
+
+}
@@ -6,6 +6,7 @@ import (
 
 type Service interface {
 	allianceService
+	apiKeyService
 	authService
 	characterService
 	cloneService
@@ -14,10 +15,13 @@ type Service interface {
 	corporationService
 	esiService
 	etagService
+	eventService
+	fittingService
 	locationService
 	mailService
 	memberService
 	processorService
+	rateLimitService
 	skillService
 	universeService
 	walletService
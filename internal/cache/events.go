@@ -0,0 +1,167 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/eveisesi/athena"
+)
+
+type eventService interface {
+	PublishTokenRefreshFailure(ctx context.Context, failure *athena.TokenRefreshFailure) error
+	PublishMemberEvent(ctx context.Context, event *athena.MemberEvent) error
+	SubscribeMemberEvents(ctx context.Context, memberID uint) (<-chan *athena.MemberEvent, error)
+	PublishRefreshJob(ctx context.Context, job *athena.RefreshJob) error
+	SubscribeRefreshJob(ctx context.Context, jobID string) (<-chan *athena.RefreshJob, error)
+}
+
+const (
+	keyEventTokenRefreshFailed = "athena::events::token::refresh::failed"
+	keyEventMember             = "athena::events::member::%d"
+	keyEventRefreshJob         = "athena::events::job::%s"
+)
+
+func (s *service) PublishTokenRefreshFailure(ctx context.Context, failure *athena.TokenRefreshFailure) error {
+
+	data, err := json.Marshal(failure)
+	if err != nil {
+		return fmt.Errorf("failed to marshal struct: %w", err)
+	}
+
+	_, err = s.client.Publish(ctx, keyEventTokenRefreshFailed, data).Result()
+	if err != nil {
+		return fmt.Errorf("[Cache Service] Failed to publish token refresh failure for member %d: %w", failure.MemberID, err)
+	}
+
+	return nil
+
+}
+
+func (s *service) PublishMemberEvent(ctx context.Context, event *athena.MemberEvent) error {
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal struct: %w", err)
+	}
+
+	_, err = s.client.Publish(ctx, fmt.Sprintf(keyEventMember, event.MemberID), data).Result()
+	if err != nil {
+		return fmt.Errorf("[Cache Service] Failed to publish %s event for member %d: %w", event.Type, event.MemberID, err)
+	}
+
+	return nil
+
+}
+
+// SubscribeMemberEvents streams the events published for a member until ctx is cancelled, at
+// which point the subscription is closed along with the returned channel
+func (s *service) SubscribeMemberEvents(ctx context.Context, memberID uint) (<-chan *athena.MemberEvent, error) {
+
+	key := fmt.Sprintf(keyEventMember, memberID)
+	pubsub := s.client.Subscribe(ctx, key)
+
+	// Wait for redis to confirm the subscription so that no events are missed between
+	// returning to the caller and the subscription becoming active
+	_, err := pubsub.Receive(ctx)
+	if err != nil {
+		_ = pubsub.Close()
+		return nil, fmt.Errorf("[Cache Service] Failed to subscribe to %s: %w", key, err)
+	}
+
+	events := make(chan *athena.MemberEvent)
+	go func() {
+		defer close(events)
+		defer pubsub.Close()
+
+		messages := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case message, ok := <-messages:
+				if !ok {
+					return
+				}
+
+				var event = new(athena.MemberEvent)
+				err := json.Unmarshal([]byte(message.Payload), event)
+				if err != nil {
+					continue
+				}
+
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return events, nil
+
+}
+
+func (s *service) PublishRefreshJob(ctx context.Context, job *athena.RefreshJob) error {
+
+	data, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("failed to marshal struct: %w", err)
+	}
+
+	_, err = s.client.Publish(ctx, fmt.Sprintf(keyEventRefreshJob, job.ID), data).Result()
+	if err != nil {
+		return fmt.Errorf("[Cache Service] Failed to publish progress of job %s: %w", job.ID, err)
+	}
+
+	return nil
+
+}
+
+// SubscribeRefreshJob streams the progress published for a job until ctx is cancelled, at which
+// point the subscription is closed along with the returned channel
+func (s *service) SubscribeRefreshJob(ctx context.Context, jobID string) (<-chan *athena.RefreshJob, error) {
+
+	key := fmt.Sprintf(keyEventRefreshJob, jobID)
+	pubsub := s.client.Subscribe(ctx, key)
+
+	_, err := pubsub.Receive(ctx)
+	if err != nil {
+		_ = pubsub.Close()
+		return nil, fmt.Errorf("[Cache Service] Failed to subscribe to %s: %w", key, err)
+	}
+
+	jobs := make(chan *athena.RefreshJob)
+	go func() {
+		defer close(jobs)
+		defer pubsub.Close()
+
+		messages := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case message, ok := <-messages:
+				if !ok {
+					return
+				}
+
+				var job = new(athena.RefreshJob)
+				err := json.Unmarshal([]byte(message.Payload), job)
+				if err != nil {
+					continue
+				}
+
+				select {
+				case jobs <- job:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return jobs, nil
+
+}
@@ -0,0 +1,59 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/eveisesi/athena"
+	"github.com/go-redis/redis/v8"
+)
+
+type fittingService interface {
+	MemberFittings(ctx context.Context, memberID uint) ([]*athena.MemberFitting, error)
+	SetMemberFittings(ctx context.Context, memberID uint, fittings []*athena.MemberFitting) error
+}
+
+const (
+	keyMemberFittings = "athena::member::%d::fittings"
+)
+
+func (s *service) MemberFittings(ctx context.Context, memberID uint) ([]*athena.MemberFitting, error) {
+
+	key := fmt.Sprintf(keyMemberFittings, memberID)
+	data, err := s.client.Get(ctx, key).Bytes()
+	if err != nil && err != redis.Nil {
+		return nil, fmt.Errorf("[Cache Layer] Failed to fetch data from cache for key %s: %w", key, err)
+	}
+
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var fittings = make([]*athena.MemberFitting, 0)
+	err = json.Unmarshal(data, &fittings)
+	if err != nil {
+		return nil, fmt.Errorf("[Cache Layer] Failed to unmarshal data for key %s on struct: %w", key, err)
+	}
+
+	return fittings, nil
+
+}
+
+func (s *service) SetMemberFittings(ctx context.Context, memberID uint, fittings []*athena.MemberFitting) error {
+
+	data, err := json.Marshal(fittings)
+	if err != nil {
+		return fmt.Errorf("failed to marshal struct: %w", err)
+	}
+
+	key := fmt.Sprintf(keyMemberFittings, memberID)
+	_, err = s.client.Set(ctx, key, data, time.Hour).Result()
+	if err != nil {
+		return fmt.Errorf("failed to write to cache: %w", err)
+	}
+
+	return nil
+
+}
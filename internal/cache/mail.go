@@ -18,9 +18,6 @@ type mailService interface {
 	MailHeaderRecipients(ctx context.Context, mailID uint) ([]*athena.MailRecipient, error)
 	SetMailHeaderRecipients(ctx context.Context, mailID uint, recipients []*athena.MailRecipient) error
 
-	MemberMailHeaders(ctx context.Context, memberID, page uint) ([]*athena.MemberMailHeader, error)
-	SetMemberMailHeaders(ctx context.Context, memberID, page uint, headers []*athena.MemberMailHeader) error
-
 	MemberMailLabels(ctx context.Context, memberID uint) (*athena.MemberMailLabels, error)
 	SetMemberMailLabels(ctx context.Context, memberID uint, labels *athena.MemberMailLabels) error
 
@@ -32,12 +29,11 @@ type mailService interface {
 }
 
 const (
-	keyMailHeader         = "athena::mail::header::%d"              // *athena.MailHeader
-	keyMailRecipients     = "athena::mail::recipients::%d"          // []*athena.MailRecipient
-	keyMemberMailHeaders  = "athena::member::%d::mail::headers::%d" // []*athena.MemberMailHeaders (ties a header to a member)
-	keyMemberMailLabels   = "athena::member::%d::mail::labels"      // *athena.MemberMailLabels
-	keyMemberMailingLists = "athena::member::%d::mail::lists"       // []*athena.MemberMailingLists
-	keyMailingList        = "athena::mail::list::%d"                // *athena.MailingLists
+	keyMailHeader         = "athena::mail::header::%d"         // *athena.MailHeader
+	keyMailRecipients     = "athena::mail::recipients::%d"     // []*athena.MailRecipient
+	keyMemberMailLabels   = "athena::member::%d::mail::labels" // *athena.MemberMailLabels
+	keyMemberMailingLists = "athena::member::%d::mail::lists"  // []*athena.MemberMailingLists
+	keyMailingList        = "athena::mail::list::%d"           // *athena.MailingLists
 )
 
 func (s *service) MailHeader(ctx context.Context, mailID uint) (*athena.MailHeader, error) {
@@ -118,63 +114,6 @@ func (s *service) SetMailHeaderRecipients(ctx context.Context, mailID uint, reci
 	return nil
 }
 
-func (s *service) MemberMailHeaders(ctx context.Context, memberID, page uint) ([]*athena.MemberMailHeader, error) {
-
-	key := fmt.Sprintf(keyMemberMailHeaders, memberID, page)
-	members, err := s.client.SMembers(ctx, key).Result()
-	if err != nil && !errors.Is(err, redis.Nil) {
-		return nil, fmt.Errorf("[Cache Layer] Failed to fetch member mail headers from cache: %w", err)
-	}
-
-	if len(members) == 0 {
-		return nil, nil
-	}
-
-	var headers = make([]*athena.MemberMailHeader, 0, len(members))
-	for _, member := range members {
-
-		var header = new(athena.MemberMailHeader)
-		err = json.Unmarshal([]byte(member), header)
-		if err != nil {
-			return nil, fmt.Errorf("[Cache Layer] Failed to unmarshal set member onto struct: %w", err)
-		}
-
-		headers = append(headers, header)
-
-	}
-
-	return headers, nil
-
-}
-
-func (s *service) SetMemberMailHeaders(ctx context.Context, memberID, page uint, headers []*athena.MemberMailHeader) error {
-
-	members := make([]string, 0, len(headers))
-	for _, header := range headers {
-		data, err := json.Marshal(header)
-		if err != nil {
-			return fmt.Errorf("[Cache Layer] Failed to marshal member mail headers: %w", err)
-		}
-
-		members = append(members, string(data))
-
-	}
-
-	key := fmt.Sprintf(keyMemberMailHeaders, memberID, page)
-	_, err := s.client.SAdd(ctx, key, members).Result()
-	if err != nil {
-		return fmt.Errorf("[Cache Layer] Failed to write to cache for key %s: %w", key, err)
-	}
-
-	_, err = s.client.Expire(ctx, key, time.Hour).Result()
-	if err != nil {
-		return fmt.Errorf("[Cache Layer] Failed to set expiry on key %s: %w", key, err)
-	}
-
-	return nil
-
-}
-
 func (s *service) MemberMailLabels(ctx context.Context, memberID uint) (*athena.MemberMailLabels, error) {
 
 	key := fmt.Sprintf(keyMemberMailLabels, memberID)
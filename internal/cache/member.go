@@ -17,6 +17,7 @@ type memberService interface {
 	SetMember(ctx context.Context, memberID uint, member *athena.Member) error
 	Members(ctx context.Context, operators ...*athena.Operator) ([]*athena.Member, error)
 	SetMembers(ctx context.Context, members []*athena.Member, operators ...*athena.Operator) error
+	PurgeMember(ctx context.Context, memberID uint) error
 }
 
 const (
@@ -126,3 +127,41 @@ func (s *service) SetMembers(ctx context.Context, members []*athena.Member, oper
 
 	return nil
 }
+
+// PurgeMember removes every key that holds data for the member, the etags of the
+// member's authenticated endpoints and the member's entry in the processor queue
+func (s *service) PurgeMember(ctx context.Context, memberID uint) error {
+
+	patterns := []string{
+		fmt.Sprintf(keyMember, memberID) + "::*",
+		fmt.Sprintf(keyEtag, fmt.Sprintf("GetCharacter*::%d", memberID)),
+		fmt.Sprintf(keyEtag, fmt.Sprintf("GetCharacter*::%d::*", memberID)),
+	}
+
+	keys := []string{fmt.Sprintf(keyMember, memberID)}
+	for _, pattern := range patterns {
+		iter := s.client.Scan(ctx, 0, pattern, 100).Iterator()
+		for iter.Next(ctx) {
+			keys = append(keys, iter.Val())
+		}
+		if err := iter.Err(); err != nil {
+			return fmt.Errorf("[Cache Service] Failed to scan keys matching %s: %w", pattern, err)
+		}
+	}
+
+	_, err := s.client.Del(ctx, keys...).Result()
+	if err != nil {
+		return fmt.Errorf("[Cache Service] Failed to delete keys for member %d: %w", memberID, err)
+	}
+
+	mx.Lock()
+	defer mx.Unlock()
+
+	_, err = s.client.ZRem(ctx, keyProcessorMemberIDQueue, memberID).Result()
+	if err != nil {
+		return fmt.Errorf("[Cache Service] Failed to remove member %d from processor queue: %w", memberID, err)
+	}
+
+	return nil
+
+}
@@ -2,10 +2,12 @@ package cache
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"time"
 
+	"github.com/eveisesi/athena"
 	"github.com/go-redis/redis/v8"
 )
 
@@ -13,10 +15,20 @@ type processorService interface {
 	PushIDToProcessorQueue(ctx context.Context, memberID uint)
 	PopFromProcessorQueue(ctx context.Context, count int) ([]uint, error)
 	ProcessorQueueCount(ctx context.Context) (int64, error)
+	PushRefreshJob(ctx context.Context, job *athena.RefreshJob) error
+	PopRefreshJobs(ctx context.Context, count int) ([]*athena.RefreshJob, error)
+	RefreshJob(ctx context.Context, jobID string) (*athena.RefreshJob, error)
+	SetRefreshJob(ctx context.Context, job *athena.RefreshJob) error
 }
 
 const (
 	keyProcessorMemberIDQueue = "athena::processor::members"
+	keyProcessorJobQueue      = "athena::processor::jobs"
+	keyProcessorJob           = "athena::processor::job::%s"
+
+	// refreshJobTTL is how long a job is kept around after it was last updated, so that its
+	// progress can still be read after it has completed
+	refreshJobTTL = time.Hour
 )
 
 func (s *service) PushIDToProcessorQueue(ctx context.Context, memberID uint) {
@@ -71,3 +83,89 @@ func (s *service) ProcessorQueueCount(ctx context.Context) (int64, error) {
 	return results, nil
 
 }
+
+// PushRefreshJob stores the job and pushes it onto the queue of refresh jobs, which the processor
+// drains before the regular member queue
+func (s *service) PushRefreshJob(ctx context.Context, job *athena.RefreshJob) error {
+
+	err := s.SetRefreshJob(ctx, job)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.RPush(ctx, keyProcessorJobQueue, job.ID).Result()
+	if err != nil {
+		return fmt.Errorf("[Cache Layer] Failed to push job %s onto processor job queue: %w", job.ID, err)
+	}
+
+	return nil
+
+}
+
+func (s *service) PopRefreshJobs(ctx context.Context, count int) ([]*athena.RefreshJob, error) {
+
+	jobs := make([]*athena.RefreshJob, 0, count)
+	for i := 0; i < count; i++ {
+		jobID, err := s.client.LPop(ctx, keyProcessorJobQueue).Result()
+		if err == redis.Nil {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("[PopRefreshJobs] Failed to retrieve records from processor job queue: %w", err)
+		}
+
+		job, err := s.RefreshJob(ctx, jobID)
+		if err != nil {
+			return nil, err
+		}
+
+		// The job has expired before it could be processed, there is nobody left to report to
+		if job == nil {
+			continue
+		}
+
+		jobs = append(jobs, job)
+	}
+
+	return jobs, nil
+
+}
+
+func (s *service) RefreshJob(ctx context.Context, jobID string) (*athena.RefreshJob, error) {
+
+	key := fmt.Sprintf(keyProcessorJob, jobID)
+	result, err := s.client.Get(ctx, key).Result()
+	if err != nil && err != redis.Nil {
+		return nil, fmt.Errorf("[Cache Layer] Failed to fetch results from cache for key %s: %w", key, err)
+	}
+
+	if len(result) == 0 {
+		return nil, nil
+	}
+
+	var job = new(athena.RefreshJob)
+	err = json.Unmarshal([]byte(result), job)
+	if err != nil {
+		return nil, fmt.Errorf("[Cache Layer] Failed to unmarshal results for key %s on struct: %w", key, err)
+	}
+
+	return job, nil
+
+}
+
+func (s *service) SetRefreshJob(ctx context.Context, job *athena.RefreshJob) error {
+
+	data, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("failed to marshal struct: %w", err)
+	}
+
+	key := fmt.Sprintf(keyProcessorJob, job.ID)
+	_, err = s.client.Set(ctx, key, data, refreshJobTTL).Result()
+	if err != nil {
+		return fmt.Errorf("[Cache Layer] Failed to cache job %s: %w", job.ID, err)
+	}
+
+	return nil
+
+}
@@ -0,0 +1,33 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+type rateLimitService interface {
+	IncrementRequestCount(ctx context.Context, identity string, window time.Duration) (int64, error)
+}
+
+const (
+	keyRateLimit = "athena::ratelimit::%s::%d"
+)
+
+// IncrementRequestCount increments the number of requests that an identity has made in the
+// current fixed window and returns the updated count
+func (s *service) IncrementRequestCount(ctx context.Context, identity string, window time.Duration) (int64, error) {
+
+	key := fmt.Sprintf(keyRateLimit, identity, time.Now().UnixNano()/int64(window))
+
+	pipe := s.client.TxPipeline()
+	incr := pipe.Incr(ctx, key)
+	pipe.Expire(ctx, key, window)
+	_, err := pipe.Exec(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("[Cache Service] Failed to increment request count of %s: %w", identity, err)
+	}
+
+	return incr.Val(), nil
+
+}
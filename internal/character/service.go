@@ -107,6 +107,14 @@ func (s *service) FetchCharacter(ctx context.Context, characterID uint) (*athena
 		entry.WithError(err).Error("failed to cache character")
 	}
 
+	// The corporation history of the character only changes when the character changes corporations
+	if existing != nil && existing.CorporationID != character.CorporationID {
+		_, err = s.FetchCharacterCorporationHistory(ctx, characterID)
+		if err != nil {
+			entry.WithError(err).Error("failed to refresh character corporation history")
+		}
+	}
+
 	return etag, nil
 
 }
@@ -118,8 +126,13 @@ func (s *service) FetchCharacterCorporationHistory(ctx context.Context, characte
 		return nil, fmt.Errorf("failed to fetch etag object: %w", err)
 	}
 
-	if etag != nil && etag.CachedUntil.After(time.Now()) {
-		return etag, nil
+	var petag string
+	if etag != nil {
+		if etag.CachedUntil.After(time.Now()) {
+			return etag, nil
+		}
+
+		petag = etag.Etag
 	}
 
 	entry := s.logger.WithContext(ctx).WithFields(logrus.Fields{
@@ -128,7 +141,6 @@ func (s *service) FetchCharacterCorporationHistory(ctx context.Context, characte
 		"method":       "FetchCharacterCorporationHistory",
 	})
 
-	petag := etag.Etag
 	history, etag, _, err := s.esi.GetCharacterCorporationHistory(ctx, characterID)
 	if err != nil {
 		entry.WithError(err).Error("failed to fetch character from ESI")
@@ -266,7 +278,7 @@ func (s *service) resolveHistoryAttributes(ctx context.Context, history []*athen
 	})
 
 	for _, record := range history {
-		_, err := s.corporation.Corporation(ctx, record.CorporationID)
+		_, err := s.corporation.FetchCorporation(ctx, record.CorporationID)
 		if err != nil {
 			entry.WithError(err).WithFields(logrus.Fields{
 				"record_id":      record.RecordID,
@@ -72,7 +72,7 @@ func (s *service) EmptyMemberClones(ctx context.Context, member *athena.Member)
 	clones, etag, _, err := s.esi.GetCharacterClones(ctx, member.ID, member.AccessToken.String)
 	if err != nil {
 		entry.WithError(err).Error("failed to fetch member clones from ESI")
-		return nil, fmt.Errorf("failed to fetch member clones from ESI")
+		return nil, fmt.Errorf("failed to fetch member clones from ESI: %w", err)
 	}
 
 	if petag != "" && etag.Etag == petag {
@@ -107,7 +107,12 @@ func (s *service) EmptyMemberClones(ctx context.Context, member *athena.Member)
 		entry.WithError(err).Error("failed to cache member clones")
 	}
 
-	return etag, err
+	err = s.cache.PublishMemberEvent(ctx, athena.NewMemberEvent(member.ID, athena.MemberClonesChanged))
+	if err != nil {
+		entry.WithError(err).Error("failed to publish member clones changed event")
+	}
+
+	return etag, nil
 
 }
 
@@ -229,7 +234,13 @@ func (s *service) EmptyMemberImplants(ctx context.Context, member *athena.Member
 	newImplants, etag, _, err := s.esi.GetCharacterImplants(ctx, member.ID, member.AccessToken.String)
 	if err != nil {
 		entry.WithError(err).Error("failed to fetch member implants from ESI")
-		return nil, fmt.Errorf("failed to fetch member implants from ESI")
+		return nil, fmt.Errorf("failed to fetch member implants from ESI: %w", err)
+	}
+
+	existing, err := s.clones.MemberImplants(ctx, member.ID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		entry.WithError(err).Error("failed to fetch member implants from DB")
+		return nil, fmt.Errorf("failed to fetch member implants from DB")
 	}
 
 	implants, err := s.resolveImplantAttributes(ctx, member, newImplants)
@@ -237,6 +248,13 @@ func (s *service) EmptyMemberImplants(ctx context.Context, member *athena.Member
 		return nil, fmt.Errorf("failed to resolve member implants")
 	}
 
+	if implantsChanged(existing, newImplants) {
+		err = s.cache.PublishMemberEvent(ctx, athena.NewMemberEvent(member.ID, athena.MemberImplantsChanged))
+		if err != nil {
+			entry.WithError(err).Error("failed to publish member implants changed event")
+		}
+	}
+
 	if len(implants) > 0 {
 		err = s.cache.SetMemberImplants(ctx, member.ID, implants)
 		if err != nil {
@@ -244,7 +262,7 @@ func (s *service) EmptyMemberImplants(ctx context.Context, member *athena.Member
 		}
 	}
 
-	return etag, err
+	return etag, nil
 
 }
 
@@ -327,3 +345,25 @@ func (s *service) resolveImplantAttributes(ctx context.Context, member *athena.M
 	return implants, err
 
 }
+
+// implantsChanged reports whether the implant type ids reported by ESI differ from the implants on record
+func implantsChanged(old []*athena.MemberImplant, new []uint) bool {
+
+	if len(old) != len(new) {
+		return true
+	}
+
+	existing := make(map[uint]bool, len(old))
+	for _, implant := range old {
+		existing[implant.ImplantID] = true
+	}
+
+	for _, implantID := range new {
+		if !existing[implantID] {
+			return true
+		}
+	}
+
+	return false
+
+}
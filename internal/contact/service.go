@@ -20,7 +20,8 @@ import (
 
 type Service interface {
 	EmptyMemberContacts(ctx context.Context, member *athena.Member) (*athena.Etag, error)
-	MemberContacts(ctx context.Context, memberID, page uint) ([]*athena.MemberContact, error)
+	MemberContacts(ctx context.Context, memberID uint, page *athena.Page, operators ...*athena.Operator) ([]*athena.MemberContact, error)
+	MemberContactCount(ctx context.Context, memberID uint, operators ...*athena.Operator) (uint, error)
 	EmptyMemberContactLabels(ctx context.Context, member *athena.Member) (*athena.Etag, error)
 	MemberContactLabels(ctx context.Context, memberID uint) ([]*athena.MemberContactLabel, error)
 }
@@ -79,7 +80,7 @@ func (s *service) EmptyMemberContacts(ctx context.Context, member *athena.Member
 	_, res, err := s.esi.HeadCharacterContacts(ctx, member.ID, 1, member.AccessToken.String)
 	if err != nil {
 		entry.WithError(err).Error("failed to exec head request for member contacts from ESI")
-		return nil, fmt.Errorf("failed to exec head request for member contacts from ESI")
+		return nil, fmt.Errorf("failed to exec head request for member contacts from ESI: %w", err)
 	}
 
 	pages := esi.RetrieveXPagesFromHeader(res.Header)
@@ -105,7 +106,7 @@ func (s *service) EmptyMemberContacts(ctx context.Context, member *athena.Member
 		contacts, etag, _, err := s.esi.GetCharacterContacts(ctx, member.ID, page, member.AccessToken.String)
 		if err != nil {
 			entry.WithError(err).Error("failed to fetch member contacts from ESI")
-			return nil, fmt.Errorf("failed to fetch member contacts from ESI")
+			return nil, fmt.Errorf("failed to fetch member contacts from ESI: %w", err)
 		}
 
 		if petag != "" && petag == etag.Etag {
@@ -119,25 +120,18 @@ func (s *service) EmptyMemberContacts(ctx context.Context, member *athena.Member
 
 		// Need to add page or sourcePage property to Struct so that the ESI Page that the record was discovered on can be tracked.
 		s.resolveContactAttributes(ctx, contacts)
-		contacts, err = s.diffAndUpdateContacts(ctx, member, page, existingContacts, contacts)
+		_, err = s.diffAndUpdateContacts(ctx, member, page, existingContacts, contacts)
 		if err != nil {
 			return nil, fmt.Errorf("failed to diff and update contacts")
 		}
 
-		if len(contacts) > 0 {
-			err := s.cache.SetMemberContacts(ctx, member.ID, page, contacts)
-			if err != nil {
-				entry.WithError(err).Error("failed to cache member contacts")
-			}
-		}
-
 	}
 
 	return etag, err
 
 }
 
-func (s *service) MemberContacts(ctx context.Context, memberID, page uint) ([]*athena.MemberContact, error) {
+func (s *service) MemberContacts(ctx context.Context, memberID uint, page *athena.Page, operators ...*athena.Operator) ([]*athena.MemberContact, error) {
 
 	entry := s.logger.WithContext(ctx).WithFields(logrus.Fields{
 		"member_id": memberID,
@@ -145,35 +139,29 @@ func (s *service) MemberContacts(ctx context.Context, memberID, page uint) ([]*a
 		"method":    "MemberContacts",
 	})
 
-	contacts, err := s.cache.MemberContacts(ctx, memberID, page)
-	if err != nil {
-		entry.WithError(err).Error("failed to fetch member contacts from cache")
-		return nil, fmt.Errorf("failed to fetch member contacts from cache")
-	}
-
-	if len(contacts) > 0 {
-		return contacts, nil
-	}
-
-	ops := make([]*athena.Operator, 0, 1)
-	if page > 0 {
-		ops = append(ops, athena.NewEqualOperator("source_page", page))
-	}
-
-	contacts, err = s.contacts.MemberContacts(ctx, memberID, ops...)
+	contacts, err := s.contacts.MemberContacts(ctx, memberID, append(operators, page.Operators("contact_id", athena.SortAsc)...)...)
 	if err != nil && !errors.Is(err, sql.ErrNoRows) {
 		entry.WithError(err).Error("failed to fetch member contacts from DB")
 		return nil, fmt.Errorf("failed to fetch member contacts from DB")
 	}
 
-	if len(contacts) > 0 {
-		err = s.cache.SetMemberContacts(ctx, memberID, page, contacts)
-		if err != nil {
-			entry.WithError(err).Error("failed to cache member contacts")
-		}
+	return contacts, nil
+
+}
+
+func (s *service) MemberContactCount(ctx context.Context, memberID uint, operators ...*athena.Operator) (uint, error) {
+
+	count, err := s.contacts.CountMemberContacts(ctx, memberID, operators...)
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithFields(logrus.Fields{
+			"member_id": memberID,
+			"service":   serviceIdentifier,
+			"method":    "MemberContactCount",
+		}).Error("failed to count member contacts in DB")
+		return 0, fmt.Errorf("failed to count member contacts in DB")
 	}
 
-	return contacts, nil
+	return count, nil
 
 }
 
@@ -332,7 +320,7 @@ func (s *service) EmptyMemberContactLabels(ctx context.Context, member *athena.M
 	labels, etag, _, err := s.esi.GetCharacterContactLabels(ctx, member.ID, member.AccessToken.String)
 	if err != nil {
 		entry.WithError(err).Error("failed to fetch member contact labels from ESI")
-		return nil, fmt.Errorf("failed to fetch member contact labels from ESI")
+		return nil, fmt.Errorf("failed to fetch member contact labels from ESI: %w", err)
 	}
 
 	if petag != "" && etag.Etag == petag {
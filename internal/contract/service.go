@@ -21,13 +21,16 @@ import (
 
 type Service interface {
 	FetchMemberContracts(ctx context.Context, member *athena.Member) (*athena.Etag, error)
-	MemberContracts(ctx context.Context, memberID, page uint) ([]*athena.MemberContract, error)
+	MemberContracts(ctx context.Context, memberID uint, page *athena.Page, operators ...*athena.Operator) ([]*athena.MemberContract, error)
+	MemberContractCount(ctx context.Context, memberID uint, operators ...*athena.Operator) (uint, error)
 
 	FetchMemberContractItems(ctx context.Context, member *athena.Member, contract *athena.MemberContract) (*athena.Etag, error)
 	MemberContractItems(ctx context.Context, memberID, contractID uint) ([]*athena.MemberContractItem, error)
+	MemberContractItemsByContractIDs(ctx context.Context, memberID uint, contractIDs []uint) ([]*athena.MemberContractItem, error)
 
 	FetchMemberContractBids(ctx context.Context, member *athena.Member, contract *athena.MemberContract) (*athena.Etag, error)
 	MemberContractBids(ctx context.Context, memberID, contractID uint) ([]*athena.MemberContractBid, error)
+	MemberContractBidsByContractIDs(ctx context.Context, memberID uint, contractIDs []uint) ([]*athena.MemberContractBid, error)
 }
 
 type service struct {
@@ -87,7 +90,7 @@ func (s *service) FetchMemberContracts(ctx context.Context, member *athena.Membe
 	etag, res, err := s.esi.HeadCharacterContracts(ctx, member.ID, 1, member.AccessToken.String)
 	if err != nil {
 		entry.WithError(err).Error("failed to exec head request for member contacts from ESI")
-		return nil, fmt.Errorf("failed to exec head request for member contacts from ESI")
+		return nil, fmt.Errorf("failed to exec head request for member contacts from ESI: %w", err)
 	}
 
 	if petag != "" && etag.Etag == petag {
@@ -117,7 +120,7 @@ func (s *service) FetchMemberContracts(ctx context.Context, member *athena.Membe
 		contracts, etag, _, err := s.esi.GetCharacterContracts(ctx, member.ID, page, member.AccessToken.String)
 		if err != nil {
 			entry.WithError(err).Error("failed to fetch member contracts from ESI")
-			return nil, fmt.Errorf("failed to fetch member contracts from ESI")
+			return nil, fmt.Errorf("failed to fetch member contracts from ESI: %w", err)
 		}
 
 		if petag != "" && petag == etag.Etag {
@@ -130,13 +133,6 @@ func (s *service) FetchMemberContracts(ctx context.Context, member *athena.Membe
 		}
 
 		_ = s.diffAndUpdateContracts(ctx, member, page, existingContracts, contracts)
-
-		if len(contracts) > 0 {
-			err = s.cache.SetMemberContracts(ctx, member.ID, page, contracts)
-			if err != nil {
-				entry.WithError(err).Error("failed to cache member contracts ")
-			}
-		}
 	}
 
 	return etag, nil
@@ -384,40 +380,37 @@ func (s *service) resolveContractAttributes(ctx context.Context, member *athena.
 
 }
 
-func (s *service) MemberContracts(ctx context.Context, memberID, page uint) ([]*athena.MemberContract, error) {
+func (s *service) MemberContracts(ctx context.Context, memberID uint, page *athena.Page, operators ...*athena.Operator) ([]*athena.MemberContract, error) {
 
 	entry := s.logger.WithContext(ctx).WithFields(logrus.Fields{
-		"service":     serviceIdentifier,
-		"method":      "Characters",
-		"member_id":   memberID,
-		"source_page": page,
+		"service":   serviceIdentifier,
+		"method":    "MemberContracts",
+		"member_id": memberID,
 	})
 
-	contracts, err := s.cache.MemberContracts(ctx, memberID, page)
-	if err != nil {
-		entry.WithError(err).Error("failed to fetch member contacts from cache")
-		return nil, fmt.Errorf("failed to fetch member contacts from cache")
+	contracts, err := s.contracts.MemberContracts(ctx, memberID, append(operators, page.Operators("contract_id", athena.SortDesc)...)...)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		entry.WithError(err).Error("failed to fetch member contracts from DB")
+		return nil, fmt.Errorf("failed to fetch member contracts from DB")
 	}
 
-	if len(contracts) > 0 {
-		return contracts, nil
-	}
+	return contracts, nil
 
-	contracts, err = s.contracts.MemberContracts(ctx, memberID, athena.NewEqualOperator("source_page", page))
-	if err != nil {
-		entry.WithError(err).Error("failed to fetch member contacts from DB")
-		return nil, fmt.Errorf("failed to fetch member contacts from DB")
-	}
+}
 
-	if len(contracts) > 0 {
-		err = s.cache.SetMemberContracts(ctx, memberID, page, contracts)
-		if err != nil {
-			entry.WithError(err).Error("failed to cache member contacts")
+func (s *service) MemberContractCount(ctx context.Context, memberID uint, operators ...*athena.Operator) (uint, error) {
 
-		}
+	count, err := s.contracts.CountMemberContracts(ctx, memberID, operators...)
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithFields(logrus.Fields{
+			"service":   serviceIdentifier,
+			"method":    "MemberContractCount",
+			"member_id": memberID,
+		}).Error("failed to count member contracts in DB")
+		return 0, fmt.Errorf("failed to count member contracts in DB")
 	}
 
-	return contracts, nil
+	return count, nil
 
 }
 
@@ -447,7 +440,7 @@ func (s *service) FetchMemberContractItems(ctx context.Context, member *athena.M
 	items, etag, _, err := s.esi.GetCharacterContractItems(ctx, member.ID, contract.ContractID, member.AccessToken.String)
 	if err != nil {
 		entry.WithError(err).Error("failed to fetch contract items from ESI")
-		return nil, fmt.Errorf("failed to fetch contract items from ESI")
+		return nil, fmt.Errorf("failed to fetch contract items from ESI: %w", err)
 	}
 
 	if petag == etag.Etag {
@@ -519,6 +512,26 @@ func (s *service) MemberContractItems(ctx context.Context, memberID, contractID
 	return items, err
 }
 
+// MemberContractItemsByContractIDs fetches the items of a batch of contracts of a member straight
+// from the DB for the dataloaders
+func (s *service) MemberContractItemsByContractIDs(ctx context.Context, memberID uint, contractIDs []uint) ([]*athena.MemberContractItem, error) {
+
+	entry := s.logger.WithContext(ctx).WithFields(logrus.Fields{
+		"member_id": memberID,
+		"service":   serviceIdentifier,
+		"method":    "MemberContractItemsByContractIDs",
+	})
+
+	items, err := s.contracts.MemberContractItemsByContractIDs(ctx, memberID, contractIDs)
+	if err != nil {
+		entry.WithError(err).Error("failed to fetch member contract items from db")
+		return nil, fmt.Errorf("failed to fetch member contract items from db")
+	}
+
+	return items, nil
+
+}
+
 func (s *service) FetchMemberContractBids(ctx context.Context, member *athena.Member, contract *athena.MemberContract) (*athena.Etag, error) {
 
 	etag, err := s.esi.Etag(ctx, esi.GetCharacterContractBids, esi.ModWithCharacterID(member.ID), esi.ModWithContractID(contract.ContractID), esi.ModWithPage(1))
@@ -545,7 +558,7 @@ func (s *service) FetchMemberContractBids(ctx context.Context, member *athena.Me
 	bids, etag, _, err := s.esi.GetCharacterContractBids(ctx, member.ID, contract.ContractID, member.AccessToken.String)
 	if err != nil {
 		entry.WithError(err).Error("failed to fetch contract bids from ESI")
-		return nil, fmt.Errorf("failed to fetch contract bids from ESI")
+		return nil, fmt.Errorf("failed to fetch contract bids from ESI: %w", err)
 	}
 
 	if petag == etag.Etag {
@@ -613,3 +626,23 @@ func (s *service) MemberContractBids(ctx context.Context, memberID, contractID u
 
 	return bids, err
 }
+
+// MemberContractBidsByContractIDs fetches the bids of a batch of contracts of a member straight
+// from the DB for the dataloaders
+func (s *service) MemberContractBidsByContractIDs(ctx context.Context, memberID uint, contractIDs []uint) ([]*athena.MemberContractBid, error) {
+
+	entry := s.logger.WithContext(ctx).WithFields(logrus.Fields{
+		"member_id": memberID,
+		"service":   serviceIdentifier,
+		"method":    "MemberContractBidsByContractIDs",
+	})
+
+	bids, err := s.contracts.MemberContractBidsByContractIDs(ctx, memberID, contractIDs)
+	if err != nil {
+		entry.WithError(err).Error("failed to fetch member contract bids from db")
+		return nil, fmt.Errorf("failed to fetch member contract bids from db")
+	}
+
+	return bids, nil
+
+}
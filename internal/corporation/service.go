@@ -109,7 +109,15 @@ func (s *service) FetchCorporation(ctx context.Context, corporationID uint) (*at
 		return nil, fmt.Errorf("failed to cache corporation")
 	}
 
-	return etag, err
+	// The alliance history of the corporation only changes when the corporation changes alliances
+	if existing != nil && existing.AllianceID != corporation.AllianceID {
+		_, err = s.FetchCorporationAllianceHistory(ctx, corporationID)
+		if err != nil {
+			entry.WithError(err).Error("failed to refresh corporation alliance history")
+		}
+	}
+
+	return etag, nil
 
 }
 
@@ -186,8 +194,13 @@ func (s *service) FetchCorporationAllianceHistory(ctx context.Context, corporati
 		return nil, fmt.Errorf("failed to fetch etag object: %w", err)
 	}
 
-	if etag != nil && etag.CachedUntil.After(time.Now()) {
-		return etag, nil
+	var petag string
+	if etag != nil {
+		if etag.CachedUntil.After(time.Now()) {
+			return etag, nil
+		}
+
+		petag = etag.Etag
 	}
 
 	entry := s.logger.WithContext(ctx).WithFields(logrus.Fields{
@@ -196,7 +209,6 @@ func (s *service) FetchCorporationAllianceHistory(ctx context.Context, corporati
 		"method":         "FetchCorporationAllianceHistory",
 	})
 
-	petag := etag.Etag
 	history, etag, _, err := s.esi.GetCorporationAllianceHistory(ctx, corporationID)
 	if err != nil {
 		entry.WithError(err).Error("failed to fetch corporation alliance history from ESI")
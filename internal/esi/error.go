@@ -1,5 +1,7 @@
 package esi
 
+import "fmt"
+
 type GenericError struct {
 	Message string `json:"message"`
 }
@@ -7,3 +9,13 @@ type GenericError struct {
 func (e GenericError) Error() string {
 	return e.Message
 }
+
+// ForbiddenError is returned when ESI responds to a request with a 403. For authenticated
+// endpoints this means the token that was used no longer grants the scope the endpoint requires.
+type ForbiddenError struct {
+	Path string
+}
+
+func (e ForbiddenError) Error() string {
+	return fmt.Sprintf("esi responded with 403 forbidden for %s", e.Path)
+}
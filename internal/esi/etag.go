@@ -3,6 +3,7 @@ package esi
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/eveisesi/athena"
 )
@@ -10,6 +11,25 @@ import (
 type etagInterface interface {
 	Etag(ctx context.Context, endpoint endpointID, modifierFunc ...modifierFunc) (*athena.Etag, error)
 	ResetEtag(ctx context.Context, etag *athena.Etag) error
+	ScopeEtags(ctx context.Context, scope athena.Scope, characterID uint) ([]*athena.Etag, error)
+}
+
+// scopeEndpoints are the endpoints of a character that are requested under each scope. Endpoints
+// that are keyed by more than the character, like the items of a contract, are not included
+var scopeEndpoints = map[athena.Scope][]endpointID{
+	athena.ReadAssetsV1:     {GetCharacterAssets},
+	athena.ReadClonesV1:     {GetCharacterClones},
+	athena.ReadImplantsV1:   {GetCharacterImplants},
+	athena.ReadContactsV1:   {GetCharacterContacts, GetCharacterContactLabels},
+	athena.ReadContractsV1:  {GetCharacterContracts},
+	athena.ReadFittingsV1:   {GetCharacterFittings},
+	athena.ReadLocationV1:   {GetCharacterLocation},
+	athena.ReadOnlineV1:     {GetCharacterOnline},
+	athena.ReadShipV1:       {GetCharacterShip},
+	athena.ReadMailV1:       {GetCharacterMailHeaders, GetCharacterMailLabels, GetCharacterMailLists},
+	athena.ReadSkillQueueV1: {GetCharacterSkillQueue},
+	athena.ReadSkillsV1:     {GetCharacterSkills, GetCharacterAttributes},
+	athena.ReadWalletV1:     {GetCharacterWalletBalance, GetCharacterWalletTransactions, GetCharacterWalletJournal},
 }
 
 func (s *service) Etag(ctx context.Context, endpoint endpointID, modifierFunc ...modifierFunc) (*athena.Etag, error) {
@@ -60,3 +80,29 @@ func (s *service) ResetEtag(ctx context.Context, etag *athena.Etag) error {
 	return nil
 
 }
+
+// ScopeEtags returns the etags of every page of the endpoints that are requested for the character
+// under the provided scope
+func (s *service) ScopeEtags(ctx context.Context, scope athena.Scope, characterID uint) ([]*athena.Etag, error) {
+
+	etags := make([]*athena.Etag, 0)
+	for _, endpoint := range scopeEndpoints[scope] {
+		key := endpoints[endpoint].KeyFunc(s.modifiers(ModWithCharacterID(characterID)))
+
+		matches, err := s.etag.Etags(ctx, athena.NewPrefixOperator("etag_id", key))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, etag := range matches {
+			// The prefix also matches the keys of characters whose id starts with the id of this
+			// character, so only keep the key itself and its pages
+			if etag.EtagID == key || strings.HasPrefix(etag.EtagID, key+"::") {
+				etags = append(etags, etag)
+			}
+		}
+	}
+
+	return etags, nil
+
+}
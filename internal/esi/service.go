@@ -405,6 +405,10 @@ func (s *service) request(
 		time.Sleep(time.Second * time.Duration(reset))
 	}
 
+	if response.StatusCode == http.StatusForbidden {
+		return data, response, ForbiddenError{Path: options.path}
+	}
+
 	return data, response, nil
 }
 
@@ -2,25 +2,32 @@ package fittings
 
 import (
 	"context"
+	"crypto/sha256"
+	"database/sql"
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/eveisesi/athena"
+	"github.com/eveisesi/athena/internal/cache"
 	"github.com/eveisesi/athena/internal/esi"
 	"github.com/eveisesi/athena/internal/glue"
 	"github.com/eveisesi/athena/internal/universe"
-	"github.com/go-redis/redis/v8"
-	"github.com/go-test/deep"
 	"github.com/sirupsen/logrus"
 )
 
-type Service interface{}
+type Service interface {
+	FetchMemberFittings(ctx context.Context, member *athena.Member) (*athena.Etag, error)
+	MemberFittings(ctx context.Context, memberID uint) ([]*athena.MemberFitting, error)
+	MemberFitting(ctx context.Context, memberID, fittingID uint) (*athena.MemberFitting, error)
+}
 
 type service struct {
 	logger *logrus.Logger
 
-	cache cache
-
+	cache    cache.Service
 	esi      esi.Service
 	universe universe.Service
 
@@ -28,14 +35,14 @@ type service struct {
 }
 
 const (
-	serviceIdentifier = "Contract Service"
+	serviceIdentifier = "Fittings Service"
 )
 
-func New(logger *logrus.Logger, redis *redis.Client, esi esi.Service, universe universe.Service, fittings athena.MemberFittingsRepository) Service {
+func NewService(logger *logrus.Logger, cache cache.Service, esi esi.Service, universe universe.Service, fittings athena.MemberFittingsRepository) Service {
 	return &service{
 		logger: logger,
 
-		cache:    newCacher(redis),
+		cache:    cache,
 		esi:      esi,
 		universe: universe,
 
@@ -68,46 +75,107 @@ func (s *service) FetchMemberFittings(ctx context.Context, member *athena.Member
 	etag, _, err = s.esi.HeadCharacterFittings(ctx, member.ID, member.AccessToken.String)
 	if err != nil {
 		entry.WithError(err).Error("failed to exec head request for member fittings from ESI")
-		return nil, fmt.Errorf("failed to exec head request for member fittings from ESI")
+		return nil, fmt.Errorf("failed to exec head request for member fittings from ESI: %w", err)
 	}
 
 	if petag != "" && etag.Etag == petag {
 		return etag, nil
 	}
 
-	fittings, _, _, err := s.esi.GetCharacterFittings(ctx, member.ID, member.AccessToken.String)
+	fittings, etag, _, err := s.esi.GetCharacterFittings(ctx, member.ID, member.AccessToken.String)
 	if err != nil {
 		entry.WithError(err).Error("failed to fetch member fittings from ESI")
-		return nil, fmt.Errorf("failed to fetch member fittings from ESI")
+		return nil, fmt.Errorf("failed to fetch member fittings from ESI: %w", err)
 	}
 
-	if len(fittings) == 0 {
+	if fittings == nil {
 		return etag, nil
 	}
 
-	s.resolveFittingAttributes(ctx, fittings)
+	s.resolveFittingAttributes(ctx, member, fittings)
 
 	existing, err := s.fittings.MemberFittings(ctx, member.ID)
-	if err != nil {
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
 		entry.WithError(err).Error("failed to fetch member fittings from DB")
 		return nil, fmt.Errorf("failed to fetch member fittings from DB")
 	}
 
-	fittings, err = s.processFittings(ctx, member, existing, fittings)
+	err = s.processFittings(ctx, member, existing, fittings)
 	if err != nil {
 		entry.WithError(err).Error("failed to process fittings")
 		return nil, fmt.Errorf("failed to process fittings")
 	}
 
-	if len(fittings) > 0 {
-		// Cache Fittings
+	err = s.cache.SetMemberFittings(ctx, member.ID, fittings)
+	if err != nil {
+		entry.WithError(err).Error("failed to cache member fittings")
 	}
 
 	return etag, nil
 
 }
 
-func (s *service) processFittings(ctx context.Context, member *athena.Member, old, new []*athena.MemberFitting) ([]*athena.MemberFitting, error) {
+func (s *service) MemberFittings(ctx context.Context, memberID uint) ([]*athena.MemberFitting, error) {
+
+	entry := s.logger.WithContext(ctx).WithFields(logrus.Fields{
+		"member_id": memberID,
+		"service":   serviceIdentifier,
+		"method":    "MemberFittings",
+	})
+
+	fittings, err := s.cache.MemberFittings(ctx, memberID)
+	if err != nil {
+		entry.WithError(err).Error("failed to fetch member fittings from cache")
+		return nil, fmt.Errorf("failed to fetch member fittings from cache")
+	}
+
+	if fittings != nil {
+		return fittings, nil
+	}
+
+	fittings, err = s.fittings.MemberFittings(ctx, memberID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		entry.WithError(err).Error("failed to fetch member fittings from DB")
+		return nil, fmt.Errorf("failed to fetch member fittings from DB")
+	}
+
+	for _, fitting := range fittings {
+		fitting.Items, err = s.fittings.MemberFittingItems(ctx, memberID, fitting.FittingID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			entry.WithError(err).WithField("fitting_id", fitting.FittingID).Error("failed to fetch member fitting items from DB")
+			return nil, fmt.Errorf("failed to fetch member fitting items from DB")
+		}
+	}
+
+	if len(fittings) > 0 {
+		err = s.cache.SetMemberFittings(ctx, memberID, fittings)
+		if err != nil {
+			entry.WithError(err).Error("failed to cache member fittings")
+		}
+	}
+
+	return fittings, nil
+
+}
+
+func (s *service) MemberFitting(ctx context.Context, memberID, fittingID uint) (*athena.MemberFitting, error) {
+
+	fittings, err := s.MemberFittings(ctx, memberID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, fitting := range fittings {
+		if fitting.FittingID == fittingID {
+			return fitting, nil
+		}
+	}
+
+	return nil, nil
+
+}
+
+func (s *service) processFittings(ctx context.Context, member *athena.Member, old, new []*athena.MemberFitting) error {
 
 	entry := s.logger.WithContext(ctx).WithFields(logrus.Fields{
 		"member_id": member.ID,
@@ -125,10 +193,15 @@ func (s *service) processFittings(ctx context.Context, member *athena.Member, ol
 	}
 
 	for _, fitting := range new {
-		if _, ok := oldFittingMap[fitting.FittingID]; !ok {
+		oldFitting, ok := oldFittingMap[fitting.FittingID]
+		if !ok {
 			fittingsToCreate = append(fittingsToCreate, fitting)
-			// Deep support recursion. This means it SHOULD dig into the item array and compare those as well?
-		} else if diff := deep.Equal(oldFittingMap[fitting.FittingID], fitting); len(diff) > 0 {
+			// Items are not loaded for the fittings that come out of the DB, so the
+			// hash of the items is compared instead of the items themselves
+		} else if oldFitting.Name != fitting.Name ||
+			oldFitting.Description != fitting.Description ||
+			oldFitting.ShipTypeID != fitting.ShipTypeID ||
+			oldFitting.ItemsHash != fitting.ItemsHash {
 			fittingsToUpdate = append(fittingsToUpdate, fitting)
 		}
 	}
@@ -144,12 +217,10 @@ func (s *service) processFittings(ctx context.Context, member *athena.Member, ol
 		}
 	}
 
-	if len(fittingsToDelete) > 0 {
-		for _, fitting := range fittingsToDelete {
-			_, err := s.fittings.DeleteMemberFitting(ctx, member.ID, fitting.FittingID)
-			if err != nil {
-				entry.WithField("fitting_id", fitting.FittingID).WithError(err).Error("failed to remove fitting")
-			}
+	for _, fitting := range fittingsToDelete {
+		_, err := s.fittings.DeleteMemberFitting(ctx, member.ID, fitting.FittingID)
+		if err != nil {
+			entry.WithField("fitting_id", fitting.FittingID).WithError(err).Error("failed to remove fitting")
 		}
 	}
 
@@ -157,39 +228,50 @@ func (s *service) processFittings(ctx context.Context, member *athena.Member, ol
 		_, err := s.fittings.CreateMemberFittings(ctx, member.ID, fittingsToCreate)
 		if err != nil {
 			entry.WithError(err).Error("failed to create member fittings in db")
-			return nil, fmt.Errorf("failed to create member fittings in db")
+			return fmt.Errorf("failed to create member fittings in db")
 		}
-	}
-
-	if len(fittingsToUpdate) > 0 {
-		for _, fitting := range fittingsToUpdate {
-			entry := entry.WithField("fitting_id", fitting.FittingID)
-			_, err := s.fittings.UpdateMemberFitting(ctx, member.ID, fitting.FittingID, fitting)
-			if err != nil {
-				entry.WithError(err).Error("failed to remove fitting")
-				continue
-			}
-
-			// err = s.cache.MemberFittings(ctx, )
 
-			_, err = s.fittings.DeleteMemberFittingItems(ctx, member.ID, fitting.FittingID)
-			if err != nil {
-				entry.WithError(err).Error("failed to drop fitting items for fit")
+		for _, fitting := range fittingsToCreate {
+			if len(fitting.Items) == 0 {
 				continue
 			}
 
 			_, err = s.fittings.CreateMemberFittingItems(ctx, member.ID, fitting.FittingID, fitting.Items)
 			if err != nil {
-				entry.WithError(err).Error("failed to create fitting items for fit")
+				entry.WithField("fitting_id", fitting.FittingID).WithError(err).Error("failed to create fitting items for fit")
 			}
 		}
 	}
 
-	return nil, nil
+	for _, fitting := range fittingsToUpdate {
+		entry := entry.WithField("fitting_id", fitting.FittingID)
+		_, err := s.fittings.UpdateMemberFitting(ctx, member.ID, fitting.FittingID, fitting)
+		if err != nil {
+			entry.WithError(err).Error("failed to update fitting")
+			continue
+		}
+
+		_, err = s.fittings.DeleteMemberFittingItems(ctx, member.ID, fitting.FittingID)
+		if err != nil {
+			entry.WithError(err).Error("failed to drop fitting items for fit")
+			continue
+		}
+
+		if len(fitting.Items) == 0 {
+			continue
+		}
+
+		_, err = s.fittings.CreateMemberFittingItems(ctx, member.ID, fitting.FittingID, fitting.Items)
+		if err != nil {
+			entry.WithError(err).Error("failed to create fitting items for fit")
+		}
+	}
+
+	return nil
 
 }
 
-func (s *service) resolveFittingAttributes(ctx context.Context, fittings []*athena.MemberFitting) {
+func (s *service) resolveFittingAttributes(ctx context.Context, member *athena.Member, fittings []*athena.MemberFitting) {
 
 	entry := s.logger.WithContext(ctx).WithFields(logrus.Fields{
 		"service": serviceIdentifier,
@@ -197,20 +279,23 @@ func (s *service) resolveFittingAttributes(ctx context.Context, fittings []*athe
 	})
 
 	for _, fitting := range fittings {
+		fitting.MemberID = member.ID
+		fitting.ItemsHash = hashFittingItems(fitting.Items)
+
 		entry := entry.WithField("ship_type_id", fitting.ShipTypeID)
 		_, err := s.universe.Type(ctx, fitting.ShipTypeID)
 		if err != nil {
 			entry.WithError(err).Error("failed to resolve fitting ship type id to name")
-			continue
 		}
 
-		for i, item := range fitting.Items {
+		for _, item := range fitting.Items {
 			// We could take care of this later since it is technically outside the scope
 			// of this function, but we're looping through it now
-			fitting.Items[i].FittingID = fitting.FittingID
+			item.MemberID = member.ID
+			item.FittingID = fitting.FittingID
 
 			entry := entry.WithField("item_type_id", item.TypeID)
-			_, err := s.universe.Type(ctx, fitting.ShipTypeID)
+			_, err := s.universe.Type(ctx, item.TypeID)
 			if err != nil {
 				entry.WithError(err).Error("failed to resolve fitting item to name")
 				continue
@@ -221,3 +306,18 @@ func (s *service) resolveFittingAttributes(ctx context.Context, fittings []*athe
 	}
 
 }
+
+// hashFittingItems returns a hash of the items on a fitting that does not depend on the order
+// that ESI returned the items in
+func hashFittingItems(items []*athena.MemberFittingItem) string {
+
+	parts := make([]string, 0, len(items))
+	for _, item := range items {
+		parts = append(parts, fmt.Sprintf("%s:%d:%d", item.Flag, item.TypeID, item.Quantity))
+	}
+
+	sort.Strings(parts)
+
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(strings.Join(parts, ","))))
+
+}
@@ -78,11 +78,37 @@ func characterCorporationHistoryLoader(ctx context.Context, c character.Service)
 				resultsByPrimaryKey[row.CharacterID] = append(resultsByPrimaryKey[row.CharacterID], row)
 			}
 
+			// History is only pulled from ESI once it is requested for the first time, so fetch it
+			// for any of the characters that we do not know the history of yet
+			missing := make([]uint, 0)
+			for _, v := range k {
+				if _, ok := resultsByPrimaryKey[v]; !ok {
+					missing = append(missing, v)
+				}
+			}
+
+			fetchErrs := fetchConcurrently(missing, func(v uint) error {
+				_, err := c.FetchCharacterCorporationHistory(ctx, v)
+				return err
+			})
+
+			if len(missing) > 0 {
+				rows, err = c.CharacterCorporationHistory(ctx, athena.NewInOperator("character_id", missing))
+				if err != nil {
+					errors = append(errors, err)
+					return nil, errors
+				}
+
+				for _, row := range rows {
+					resultsByPrimaryKey[row.CharacterID] = append(resultsByPrimaryKey[row.CharacterID], row)
+				}
+			}
+
 			for i, v := range keys {
-				results[i] = resultsByPrimaryKey[v]
+				results[i] = athena.ResolveCharacterCorporationHistory(resultsByPrimaryKey[v])
 			}
 
-			return results, nil
+			return results, keyErrors(keys, fetchErrs)
 
 		},
 	})
@@ -0,0 +1,93 @@
+package dataloaders
+
+import (
+	"context"
+
+	"github.com/eveisesi/athena"
+	"github.com/eveisesi/athena/internal/contract"
+	"github.com/eveisesi/athena/internal/graphql/dataloaders/generated"
+)
+
+type contractLoaders struct {
+	MemberContractItems *generated.MemberContractItemsLoader
+	MemberContractBids  *generated.MemberContractBidsLoader
+}
+
+func newContractLoaders(ctx context.Context, c contract.Service) *contractLoaders {
+	return &contractLoaders{
+		MemberContractItems: memberContractItemsLoader(ctx, c),
+		MemberContractBids:  memberContractBidsLoader(ctx, c),
+	}
+}
+
+// contractIDsByMember groups the contract IDs of a batch of keys by the member that they belong to
+func contractIDsByMember(keys []athena.MemberContractKey) map[uint][]uint {
+	ids := make(map[uint][]uint)
+	for _, key := range keys {
+		ids[key.MemberID] = append(ids[key.MemberID], key.ContractID)
+	}
+
+	return ids
+}
+
+func memberContractItemsLoader(ctx context.Context, c contract.Service) *generated.MemberContractItemsLoader {
+	return generated.NewMemberContractItemsLoader(generated.MemberContractItemsLoaderConfig{
+		Wait:     defaultWait,
+		MaxBatch: defaultMaxBatch,
+		Fetch: func(keys []athena.MemberContractKey) ([][]*athena.MemberContractItem, []error) {
+			var errors = make([]error, 0, len(keys))
+			var results = make([][]*athena.MemberContractItem, len(keys))
+
+			resultsByPrimaryKey := make(map[athena.MemberContractKey][]*athena.MemberContractItem)
+			for memberID, contractIDs := range contractIDsByMember(keys) {
+				rows, err := c.MemberContractItemsByContractIDs(ctx, memberID, contractIDs)
+				if err != nil {
+					errors = append(errors, err)
+					return nil, errors
+				}
+
+				for _, row := range rows {
+					key := athena.MemberContractKey{MemberID: row.MemberID, ContractID: row.ContractID}
+					resultsByPrimaryKey[key] = append(resultsByPrimaryKey[key], row)
+				}
+			}
+
+			for i, v := range keys {
+				results[i] = resultsByPrimaryKey[v]
+			}
+
+			return results, nil
+		},
+	})
+}
+
+func memberContractBidsLoader(ctx context.Context, c contract.Service) *generated.MemberContractBidsLoader {
+	return generated.NewMemberContractBidsLoader(generated.MemberContractBidsLoaderConfig{
+		Wait:     defaultWait,
+		MaxBatch: defaultMaxBatch,
+		Fetch: func(keys []athena.MemberContractKey) ([][]*athena.MemberContractBid, []error) {
+			var errors = make([]error, 0, len(keys))
+			var results = make([][]*athena.MemberContractBid, len(keys))
+
+			resultsByPrimaryKey := make(map[athena.MemberContractKey][]*athena.MemberContractBid)
+			for memberID, contractIDs := range contractIDsByMember(keys) {
+				rows, err := c.MemberContractBidsByContractIDs(ctx, memberID, contractIDs)
+				if err != nil {
+					errors = append(errors, err)
+					return nil, errors
+				}
+
+				for _, row := range rows {
+					key := athena.MemberContractKey{MemberID: row.MemberID, ContractID: row.ContractID}
+					resultsByPrimaryKey[key] = append(resultsByPrimaryKey[key], row)
+				}
+			}
+
+			for i, v := range keys {
+				results[i] = resultsByPrimaryKey[v]
+			}
+
+			return results, nil
+		},
+	})
+}
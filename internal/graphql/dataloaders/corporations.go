@@ -79,11 +79,37 @@ func corporationAllianceHistoryLoader(ctx context.Context, c corporation.Service
 				resultsByPrimaryKey[row.CorporationID] = append(resultsByPrimaryKey[row.CorporationID], row)
 			}
 
+			// History is only pulled from ESI once it is requested for the first time, so fetch it
+			// for any of the corporations that we do not know the history of yet
+			missing := make([]uint, 0)
+			for _, v := range k {
+				if _, ok := resultsByPrimaryKey[v]; !ok {
+					missing = append(missing, v)
+				}
+			}
+
+			fetchErrs := fetchConcurrently(missing, func(v uint) error {
+				_, err := c.FetchCorporationAllianceHistory(ctx, v)
+				return err
+			})
+
+			if len(missing) > 0 {
+				rows, err = c.CorporationAllianceHistory(ctx, athena.NewInOperator("corporation_id", missing))
+				if err != nil {
+					errors = append(errors, err)
+					return nil, errors
+				}
+
+				for _, row := range rows {
+					resultsByPrimaryKey[row.CorporationID] = append(resultsByPrimaryKey[row.CorporationID], row)
+				}
+			}
+
 			for i, v := range keys {
-				results[i] = resultsByPrimaryKey[v]
+				results[i] = athena.ResolveCorporationAllianceHistory(resultsByPrimaryKey[v])
 			}
 
-			return results, nil
+			return results, keyErrors(keys, fetchErrs)
 		},
 	})
 }
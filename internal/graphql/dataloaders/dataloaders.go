@@ -2,11 +2,15 @@ package dataloaders
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/eveisesi/athena/internal/alliance"
 	"github.com/eveisesi/athena/internal/character"
+	"github.com/eveisesi/athena/internal/contract"
 	"github.com/eveisesi/athena/internal/corporation"
+	"github.com/eveisesi/athena/internal/mail"
+	"github.com/eveisesi/athena/internal/member"
 	"github.com/eveisesi/athena/internal/universe"
 )
 
@@ -17,22 +21,33 @@ var CtxKey = ctxKeyType{name: "dataloaders"}
 const (
 	defaultWait     = 100 * time.Millisecond
 	defaultMaxBatch = 500
+
+	// maxConcurrentFetches bounds the number of requests to ESI that a single batch makes at once
+	maxConcurrentFetches = 10
 )
 
 type Loaders struct {
+	*memberLoaders
 	*allianceLoaders
 	*characterLoaders
 	*corporationLoaders
 	*universeLoaders
+
+	*contractLoaders
+	*mailLoaders
 }
 
-func New(ctx context.Context, a alliance.Service, ch character.Service, corp corporation.Service, u universe.Service) *Loaders {
+func New(ctx context.Context, mem member.Service, a alliance.Service, ch character.Service, corp corporation.Service, u universe.Service, con contract.Service, m mail.Service) *Loaders {
 
 	return &Loaders{
+		memberLoaders:      newMemberLoaders(ctx, mem),
 		allianceLoaders:    newAllianceLoaders(ctx, a),
 		characterLoaders:   newCharacterLoaders(ctx, ch),
 		corporationLoaders: newCorporationLoaders(ctx, corp),
 		universeLoaders:    newUniverseLoader(ctx, u),
+
+		contractLoaders: newContractLoaders(ctx, con),
+		mailLoaders:     newMailLoaders(ctx, m),
 	}
 
 }
@@ -41,3 +56,51 @@ func New(ctx context.Context, a alliance.Service, ch character.Service, corp cor
 func CtxLoaders(ctx context.Context) *Loaders {
 	return ctx.Value(CtxKey).(*Loaders)
 }
+
+// fetchConcurrently calls fetch for each of keys, at most maxConcurrentFetches at a time, and returns
+// the errors of the keys whose fetch failed
+func fetchConcurrently(keys []uint, fetch func(key uint) error) map[uint]error {
+
+	var mx sync.Mutex
+	var wg sync.WaitGroup
+	var errs = make(map[uint]error)
+	var sem = make(chan struct{}, maxConcurrentFetches)
+
+	for _, key := range keys {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(key uint) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			if err := fetch(key); err != nil {
+				mx.Lock()
+				errs[key] = err
+				mx.Unlock()
+			}
+		}(key)
+	}
+
+	wg.Wait()
+
+	return errs
+
+}
+
+// keyErrors returns the errors of a batch, one for each of keys, or nil when none of the keys failed
+func keyErrors(keys []uint, errs map[uint]error) []error {
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	errors := make([]error, len(keys))
+	for i, key := range keys {
+		errors[i] = errs[key]
+	}
+
+	return errors
+
+}
@@ -0,0 +1,55 @@
+package dataloaders
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFetchConcurrently(t *testing.T) {
+
+	keys := make([]uint, 0, 3*maxConcurrentFetches)
+	for i := uint(1); i <= 3*maxConcurrentFetches; i++ {
+		keys = append(keys, i)
+	}
+
+	var running, peak int32
+	errs := fetchConcurrently(keys, func(key uint) error {
+		n := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+
+		time.Sleep(time.Millisecond)
+
+		if key%2 == 0 {
+			return fmt.Errorf("failed to fetch %d", key)
+		}
+
+		return nil
+	})
+
+	if peak > maxConcurrentFetches {
+		t.Errorf("peak concurrent fetches = %d, want at most %d", peak, maxConcurrentFetches)
+	}
+
+	if len(errs) != len(keys)/2 {
+		t.Fatalf("len(errs) = %d, want %d", len(errs), len(keys)/2)
+	}
+
+	got := keyErrors([]uint{1, 2, 3}, errs)
+	if len(got) != 3 || got[0] != nil || got[1] == nil || got[2] != nil {
+		t.Errorf("keyErrors() = %v, want an error for key 2 only", got)
+	}
+
+	if got := keyErrors([]uint{1, 3}, map[uint]error{}); got != nil {
+		t.Errorf("keyErrors() = %v, want nil", got)
+	}
+
+}
@@ -1,3 +1,5 @@
+//go:generate go run github.com/vektah/dataloaden MemberLoader uint *github.com/eveisesi/athena.Member
+
 //go:generate go run github.com/vektah/dataloaden CharacterLoader uint *github.com/eveisesi/athena.Character
 //go:generate go run github.com/vektah/dataloaden CharacterCorporationHistoryLoader uint []*github.com/eveisesi/athena.CharacterCorporationHistory
 //go:generate go run github.com/vektah/dataloaden CorporationLoader uint *github.com/eveisesi/athena.Corporation
@@ -18,4 +20,11 @@
 //go:generate go run github.com/vektah/dataloaden GroupLoader uint *github.com/eveisesi/athena.Group
 //go:generate go run github.com/vektah/dataloaden TypeLoader uint *github.com/eveisesi/athena.Type
 
+//go:generate go run github.com/vektah/dataloaden MemberContractItemsLoader github.com/eveisesi/athena.MemberContractKey []*github.com/eveisesi/athena.MemberContractItem
+//go:generate go run github.com/vektah/dataloaden MemberContractBidsLoader github.com/eveisesi/athena.MemberContractKey []*github.com/eveisesi/athena.MemberContractBid
+
+//go:generate go run github.com/vektah/dataloaden MailHeaderLoader uint *github.com/eveisesi/athena.MailHeader
+//go:generate go run github.com/vektah/dataloaden MailRecipientsLoader uint []*github.com/eveisesi/athena.MailRecipient
+//go:generate go run github.com/vektah/dataloaden MailingListLoader uint *github.com/eveisesi/athena.MailingList
+
 package generated
@@ -0,0 +1,123 @@
+package dataloaders
+
+import (
+	"context"
+	"sort"
+
+	"github.com/eveisesi/athena"
+	"github.com/eveisesi/athena/internal/graphql/dataloaders/generated"
+	"github.com/eveisesi/athena/internal/mail"
+)
+
+type mailLoaders struct {
+	MailHeader     *generated.MailHeaderLoader
+	MailRecipients *generated.MailRecipientsLoader
+	MailingList    *generated.MailingListLoader
+}
+
+func newMailLoaders(ctx context.Context, m mail.Service) *mailLoaders {
+	return &mailLoaders{
+		MailHeader:     mailHeaderLoader(ctx, m),
+		MailRecipients: mailRecipientsLoader(ctx, m),
+		MailingList:    mailingListLoader(ctx, m),
+	}
+}
+
+func mailHeaderLoader(ctx context.Context, m mail.Service) *generated.MailHeaderLoader {
+	return generated.NewMailHeaderLoader(generated.MailHeaderLoaderConfig{
+		Wait:     defaultWait,
+		MaxBatch: defaultMaxBatch,
+		Fetch: func(keys []uint) ([]*athena.MailHeader, []error) {
+			var errors = make([]error, 0, len(keys))
+			var results = make([]*athena.MailHeader, len(keys))
+
+			k := append(make([]uint, 0, len(keys)), keys...)
+			sort.SliceStable(k, func(i, j int) bool {
+				return k[i] < k[j]
+			})
+
+			rows, err := m.MailHeadersByIDs(ctx, k)
+			if err != nil {
+				errors = append(errors, err)
+				return nil, errors
+			}
+
+			resultsByPrimaryKey := make(map[uint]*athena.MailHeader)
+			for _, row := range rows {
+				resultsByPrimaryKey[row.MailID] = row
+			}
+
+			for i, v := range keys {
+				results[i] = resultsByPrimaryKey[v]
+			}
+
+			return results, nil
+		},
+	})
+}
+
+func mailRecipientsLoader(ctx context.Context, m mail.Service) *generated.MailRecipientsLoader {
+	return generated.NewMailRecipientsLoader(generated.MailRecipientsLoaderConfig{
+		Wait:     defaultWait,
+		MaxBatch: defaultMaxBatch,
+		Fetch: func(keys []uint) ([][]*athena.MailRecipient, []error) {
+			var errors = make([]error, 0, len(keys))
+			var results = make([][]*athena.MailRecipient, len(keys))
+
+			k := append(make([]uint, 0, len(keys)), keys...)
+			sort.SliceStable(k, func(i, j int) bool {
+				return k[i] < k[j]
+			})
+
+			rows, err := m.MailRecipientsByMailIDs(ctx, k)
+			if err != nil {
+				errors = append(errors, err)
+				return nil, errors
+			}
+
+			resultsByPrimaryKey := make(map[uint][]*athena.MailRecipient)
+			for _, row := range rows {
+				resultsByPrimaryKey[row.MailID] = append(resultsByPrimaryKey[row.MailID], row)
+			}
+
+			for i, v := range keys {
+				results[i] = resultsByPrimaryKey[v]
+			}
+
+			return results, nil
+		},
+	})
+}
+
+func mailingListLoader(ctx context.Context, m mail.Service) *generated.MailingListLoader {
+	return generated.NewMailingListLoader(generated.MailingListLoaderConfig{
+		Wait:     defaultWait,
+		MaxBatch: defaultMaxBatch,
+		Fetch: func(keys []uint) ([]*athena.MailingList, []error) {
+			var errors = make([]error, 0, len(keys))
+			var results = make([]*athena.MailingList, len(keys))
+
+			k := append(make([]uint, 0, len(keys)), keys...)
+			sort.SliceStable(k, func(i, j int) bool {
+				return k[i] < k[j]
+			})
+
+			rows, err := m.MailingListsByIDs(ctx, k)
+			if err != nil {
+				errors = append(errors, err)
+				return nil, errors
+			}
+
+			resultsByPrimaryKey := make(map[uint]*athena.MailingList)
+			for _, row := range rows {
+				resultsByPrimaryKey[row.MailingListID] = row
+			}
+
+			for i, v := range keys {
+				results[i] = resultsByPrimaryKey[v]
+			}
+
+			return results, nil
+		},
+	})
+}
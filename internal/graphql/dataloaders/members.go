@@ -0,0 +1,55 @@
+package dataloaders
+
+import (
+	"context"
+	"sort"
+
+	"github.com/eveisesi/athena"
+	"github.com/eveisesi/athena/internal/graphql/dataloaders/generated"
+	"github.com/eveisesi/athena/internal/member"
+)
+
+type memberLoaders struct {
+	Member *generated.MemberLoader
+}
+
+func newMemberLoaders(ctx context.Context, m member.Service) *memberLoaders {
+	return &memberLoaders{
+		Member: memberLoader(ctx, m),
+	}
+}
+
+// memberLoader loads members by their character ID. Characters that have never logged in resolve
+// to a nil member
+func memberLoader(ctx context.Context, m member.Service) *generated.MemberLoader {
+	return generated.NewMemberLoader(generated.MemberLoaderConfig{
+		Wait:     defaultWait,
+		MaxBatch: defaultMaxBatch,
+		Fetch: func(keys []uint) ([]*athena.Member, []error) {
+			var errors = make([]error, 0, len(keys))
+			var results = make([]*athena.Member, len(keys))
+
+			k := append(make([]uint, 0, len(keys)), keys...)
+			sort.SliceStable(k, func(i, j int) bool {
+				return k[i] < k[j]
+			})
+
+			rows, err := m.Members(ctx, athena.NewInOperator("id", k))
+			if err != nil {
+				errors = append(errors, err)
+				return nil, errors
+			}
+
+			resultsByPrimaryKey := make(map[uint]*athena.Member)
+			for _, row := range rows {
+				resultsByPrimaryKey[row.ID] = row
+			}
+
+			for i, v := range keys {
+				results[i] = resultsByPrimaryKey[v]
+			}
+
+			return results, nil
+		},
+	})
+}
@@ -0,0 +1,118 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eveisesi/athena"
+	"github.com/eveisesi/athena/internal/apikey"
+	"github.com/eveisesi/athena/internal/graphql/service"
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/volatiletech/null"
+)
+
+func (r *aPIKeyResolver) AllowedQueries(ctx context.Context, obj *athena.APIKey) ([]string, error) {
+	return []string(obj.AllowedQueries), nil
+}
+
+func (r *aPIKeyResolver) AllowedMembers(ctx context.Context, obj *athena.APIKey) ([]uint, error) {
+	members := make([]uint, 0, len(obj.AllowedMembers))
+	for _, m := range obj.AllowedMembers {
+		members = append(members, uint(m))
+	}
+
+	return members, nil
+}
+
+func (r *mutationResolver) CreateAPIKey(ctx context.Context, input service.CreateAPIKeyInput) (*service.CreatedAPIKey, error) {
+	member := r.member.MemberFromContext(ctx)
+	if member == nil {
+		return nil, fmt.Errorf("request is not authenticated")
+	}
+
+	if !r.apikey.IsAdmin(member.ID) {
+		return nil, fmt.Errorf("not permitted to create api keys")
+	}
+
+	// Keys created through the API are scoped to the characters of the creator. Keys that may access
+	// any member can only be created with the CLI
+	allowedMembers := input.AllowedMembers
+	if len(allowedMembers) == 0 {
+		allowedMembers = []uint{member.ID}
+	}
+
+	linked, err := r.member.LinkedMembers(ctx, member.ID)
+	if err != nil {
+		newrelic.FromContext(ctx).NoticeError(err)
+		return nil, err
+	}
+
+	permitted := make(map[uint]bool, len(linked))
+	for _, m := range linked {
+		permitted[m.ID] = true
+	}
+
+	for _, m := range allowedMembers {
+		if !permitted[m] {
+			return nil, fmt.Errorf("not permitted to scope an api key to member %d", m)
+		}
+	}
+
+	rateLimit := apikey.DefaultRateLimit
+	if input.RateLimit != nil {
+		rateLimit = *input.RateLimit
+	}
+
+	key, plain, err := r.apikey.CreateAPIKey(ctx, input.Name, input.AllowedQueries, allowedMembers, rateLimit, null.UintFrom(member.ID))
+	if err != nil {
+		newrelic.FromContext(ctx).NoticeError(err)
+		return nil, err
+	}
+
+	return &service.CreatedAPIKey{
+		Key:    plain,
+		APIKey: key,
+	}, nil
+}
+
+func (r *mutationResolver) RevokeAPIKey(ctx context.Context, id uint) (bool, error) {
+	member := r.member.MemberFromContext(ctx)
+	if member == nil {
+		return false, fmt.Errorf("request is not authenticated")
+	}
+
+	key, err := r.apikey.APIKey(ctx, id)
+	if err != nil {
+		newrelic.FromContext(ctx).NoticeError(err)
+		return false, err
+	}
+
+	if key == nil || !key.CreatedBy.Valid || key.CreatedBy.Uint != member.ID {
+		return false, fmt.Errorf("api key %d does not exist", id)
+	}
+
+	err = r.apikey.RevokeAPIKey(ctx, key.ID)
+	if err != nil {
+		newrelic.FromContext(ctx).NoticeError(err)
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *queryResolver) APIKeys(ctx context.Context) ([]*athena.APIKey, error) {
+	member := r.member.MemberFromContext(ctx)
+	if member == nil {
+		return nil, fmt.Errorf("request is not authenticated")
+	}
+
+	return r.apikey.APIKeys(ctx, athena.NewEqualOperator("created_by", member.ID))
+}
+
+// APIKey returns service.APIKeyResolver implementation.
+func (r *resolver) APIKey() service.APIKeyResolver { return &aPIKeyResolver{r} }
+
+type aPIKeyResolver struct{ *resolver }
@@ -18,8 +18,55 @@ func (r *memberAssetResolver) LocationType(ctx context.Context, obj *athena.Memb
 	return obj.LocationType.String(), nil
 }
 
-func (r *queryResolver) MemberAssets(ctx context.Context, memberID uint, page uint) ([]*athena.MemberAsset, error) {
-	return r.asset.MemberAssets(ctx, memberID, page)
+func (r *queryResolver) MemberAssets(ctx context.Context, memberID uint, first *uint, after *string, filter *service.MemberAssetFilter, sort *service.MemberAssetSort) (*service.MemberAssetConnection, error) {
+	err := r.authorizeMember(ctx, memberID)
+	if err != nil {
+		return nil, err
+	}
+
+	operators, err := memberAssetFilterOperators(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var pageSort *athena.PageSort
+	if sort != nil {
+		pageSort, err = memberAssetSortColumns.pageSort(sort.Field, sort.Direction)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	page, err := athena.NewPage(first, after, pageSort)
+	if err != nil {
+		return nil, err
+	}
+
+	assets, err := r.asset.MemberAssets(ctx, memberID, page, operators...)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := r.asset.MemberAssetCount(ctx, memberID, operators...)
+	if err != nil {
+		return nil, err
+	}
+
+	n, info := newPageInfo(page, len(assets))
+	edges := make([]*service.MemberAssetEdge, 0, n)
+	cursors := make([]string, 0, n)
+	for _, asset := range assets[:n] {
+		cursor := athena.NewCursor(asset.ItemID)
+		if sort != nil {
+			cursor = athena.NewSortedCursor(asset.ItemID, memberAssetSortValue(sort.Field, asset))
+		}
+
+		edges = append(edges, &service.MemberAssetEdge{Cursor: cursor.String(), Node: asset})
+		cursors = append(cursors, cursor.String())
+	}
+	setPageCursors(info, cursors...)
+
+	return &service.MemberAssetConnection{Edges: edges, PageInfo: info, TotalCount: count}, nil
 }
 
 // MemberAsset returns service.MemberAssetResolver implementation.
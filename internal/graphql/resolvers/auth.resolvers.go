@@ -5,6 +5,7 @@ package resolvers
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/eveisesi/athena"
@@ -43,7 +44,27 @@ func (r *subscriptionResolver) AuthStatus(ctx context.Context, state string) (<-
 					return
 				}
 
-				pipe <- attempt
+				if attempt.Status == athena.CompletedAuthStatus {
+					claimed, err := r.auth.ClaimAuthAttempt(ctx, attempt)
+					if err != nil {
+						newrelic.FromContext(ctx).NoticeError(err)
+						ticker.Stop()
+						close(pipe)
+						return
+					}
+
+					if !claimed {
+						attempt = &athena.AuthAttempt{Status: athena.InvalidAuthStatus}
+					}
+				}
+
+				select {
+				case pipe <- attempt:
+				case <-ctx.Done():
+					ticker.Stop()
+					close(pipe)
+					return
+				}
 
 				if attempt.Status != athena.PendingAuthStatus {
 					ticker.Stop()
@@ -65,10 +86,39 @@ func (r *authAttemptResolver) Status(ctx context.Context, obj *athena.AuthAttemp
 }
 
 func (r *authAttemptResolver) URL(ctx context.Context, obj *athena.AuthAttempt, scopes []string) (string, error) {
-	return r.auth.AuthorizationURI(ctx, obj.State, scopes), nil
+	return r.auth.AuthorizationURI(ctx, obj, scopes), nil
+}
+
+func (r *mutationResolver) RefreshSession(ctx context.Context, refreshToken string) (*athena.SessionToken, error) {
+	token, err := r.auth.RefreshSession(ctx, refreshToken)
+	if err != nil {
+		newrelic.FromContext(ctx).NoticeError(err)
+		return nil, err
+	}
+
+	return token, nil
+}
+
+func (r *mutationResolver) RevokeSession(ctx context.Context) (bool, error) {
+	session := r.member.SessionFromContext(ctx)
+	if session == nil {
+		return false, fmt.Errorf("request is not authenticated")
+	}
+
+	err := r.auth.RevokeSession(ctx, session)
+	if err != nil {
+		newrelic.FromContext(ctx).NoticeError(err)
+		return false, err
+	}
+
+	return true, nil
 }
 
 // AuthAttempt returns service.AuthAttemptResolver implementation.
 func (r *resolver) AuthAttempt() service.AuthAttemptResolver { return &authAttemptResolver{r} }
 
+// Mutation returns service.MutationResolver implementation.
+func (r *resolver) Mutation() service.MutationResolver { return &mutationResolver{r} }
+
 type authAttemptResolver struct{ *resolver }
+type mutationResolver struct{ *resolver }
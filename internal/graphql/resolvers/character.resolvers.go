@@ -5,6 +5,7 @@ package resolvers
 
 import (
 	"context"
+	"time"
 
 	"github.com/eveisesi/athena"
 	"github.com/eveisesi/athena/internal/graphql/dataloaders"
@@ -37,7 +38,72 @@ func (r *characterResolver) Alliance(ctx context.Context, obj *athena.Character)
 	return dataloaders.CtxLoaders(ctx).Alliance.Load(obj.AllianceID.Uint)
 }
 
+func (r *characterResolver) CorporationHistory(ctx context.Context, obj *athena.Character) ([]*athena.CharacterCorporationHistory, error) {
+	return dataloaders.CtxLoaders(ctx).CharacterCorporationHistory.Load(obj.ID)
+}
+
+func (r *characterResolver) CorporationHistoryAnalysis(ctx context.Context, obj *athena.Character) (*athena.CorporationHistoryAnalysis, error) {
+	history, err := dataloaders.CtxLoaders(ctx).CharacterCorporationHistory.Load(obj.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.analysis.CorporationHistoryAnalysis(obj.ID, history), nil
+}
+
+func (r *characterResolver) OwnerChanged(ctx context.Context, obj *athena.Character) (bool, error) {
+	member, err := dataloaders.CtxLoaders(ctx).Member.Load(obj.ID)
+	if err != nil || member == nil {
+		return false, err
+	}
+
+	return member.OwnershipEpoch > 0, nil
+}
+
+func (r *characterResolver) OwnerChangedAt(ctx context.Context, obj *athena.Character) (*time.Time, error) {
+	member, err := dataloaders.CtxLoaders(ctx).Member.Load(obj.ID)
+	if err != nil || member == nil || !member.OwnerChangedAt.Valid {
+		return nil, err
+	}
+
+	return &member.OwnerChangedAt.Time, nil
+}
+
+func (r *characterCorporationHistoryResolver) TenureDays(ctx context.Context, obj *athena.CharacterCorporationHistory) (uint, error) {
+	return uint(obj.Tenure().Hours() / 24), nil
+}
+
+func (r *characterCorporationHistoryResolver) Corporation(ctx context.Context, obj *athena.CharacterCorporationHistory) (*athena.Corporation, error) {
+	return dataloaders.CtxLoaders(ctx).Corporation.Load(obj.CorporationID)
+}
+
+func (r *corporationReturnResolver) Corporation(ctx context.Context, obj *athena.CorporationReturn) (*athena.Corporation, error) {
+	return dataloaders.CtxLoaders(ctx).Corporation.Load(obj.CorporationID)
+}
+
+func (r *hostileCorporationOverlapResolver) Corporation(ctx context.Context, obj *athena.HostileCorporationOverlap) (*athena.Corporation, error) {
+	return dataloaders.CtxLoaders(ctx).Corporation.Load(obj.CorporationID)
+}
+
 // Character returns service.CharacterResolver implementation.
 func (r *resolver) Character() service.CharacterResolver { return &characterResolver{r} }
 
+// CharacterCorporationHistory returns service.CharacterCorporationHistoryResolver implementation.
+func (r *resolver) CharacterCorporationHistory() service.CharacterCorporationHistoryResolver {
+	return &characterCorporationHistoryResolver{r}
+}
+
+// CorporationReturn returns service.CorporationReturnResolver implementation.
+func (r *resolver) CorporationReturn() service.CorporationReturnResolver {
+	return &corporationReturnResolver{r}
+}
+
+// HostileCorporationOverlap returns service.HostileCorporationOverlapResolver implementation.
+func (r *resolver) HostileCorporationOverlap() service.HostileCorporationOverlapResolver {
+	return &hostileCorporationOverlapResolver{r}
+}
+
 type characterResolver struct{ *resolver }
+type characterCorporationHistoryResolver struct{ *resolver }
+type corporationReturnResolver struct{ *resolver }
+type hostileCorporationOverlapResolver struct{ *resolver }
@@ -10,6 +10,7 @@ import (
 	"github.com/eveisesi/athena"
 	"github.com/eveisesi/athena/internal/graphql/dataloaders"
 	"github.com/eveisesi/athena/internal/graphql/service"
+	"github.com/newrelic/go-agent/v3/newrelic"
 )
 
 func (r *memberHomeLocationResolver) Info(ctx context.Context, obj *athena.MemberHomeLocation) (service.CloneLocationInfo, error) {
@@ -28,14 +29,16 @@ func (r *memberImplantResolver) Type(ctx context.Context, obj *athena.MemberImpl
 }
 
 func (r *memberJumpCloneResolver) Implants(ctx context.Context, obj *athena.MemberJumpClone) ([]*athena.Type, error) {
-	items := make([]*athena.Type, 0, len(obj.Implants))
+	ids := make([]uint, 0, len(obj.Implants))
 	for _, t := range obj.Implants {
-		item, e := dataloaders.CtxLoaders(ctx).Item.Load(uint(t))
-		if e != nil {
-			return nil, e
-		}
+		ids = append(ids, uint(t))
+	}
 
-		items = append(items, item)
+	items, errs := dataloaders.CtxLoaders(ctx).Item.LoadAll(ids)
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	return items, nil
@@ -53,13 +56,79 @@ func (r *memberJumpCloneResolver) Info(ctx context.Context, obj *athena.MemberJu
 }
 
 func (r *queryResolver) MemberClones(ctx context.Context, memberID uint) (*athena.MemberClones, error) {
+	err := r.authorizeMember(ctx, memberID)
+	if err != nil {
+		return nil, err
+	}
+
 	return r.clone.MemberClones(ctx, memberID)
 }
 
 func (r *queryResolver) MemberImplants(ctx context.Context, memberID uint) ([]*athena.MemberImplant, error) {
+	err := r.authorizeMember(ctx, memberID)
+	if err != nil {
+		return nil, err
+	}
+
 	return r.clone.MemberImplants(ctx, memberID)
 }
 
+func (r *subscriptionResolver) MemberClonesChanged(ctx context.Context, memberID uint) (<-chan *athena.MemberClones, error) {
+	events, err := r.memberEvents(ctx, memberID, athena.MemberClonesChanged)
+	if err != nil {
+		return nil, err
+	}
+
+	pipe := make(chan *athena.MemberClones)
+	go func() {
+		defer close(pipe)
+
+		for range events {
+			result, err := r.clone.MemberClones(ctx, memberID)
+			if err != nil {
+				newrelic.FromContext(ctx).NoticeError(err)
+				continue
+			}
+
+			select {
+			case pipe <- result:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return pipe, nil
+}
+
+func (r *subscriptionResolver) MemberImplantsChanged(ctx context.Context, memberID uint) (<-chan []*athena.MemberImplant, error) {
+	events, err := r.memberEvents(ctx, memberID, athena.MemberImplantsChanged)
+	if err != nil {
+		return nil, err
+	}
+
+	pipe := make(chan []*athena.MemberImplant)
+	go func() {
+		defer close(pipe)
+
+		for range events {
+			result, err := r.clone.MemberImplants(ctx, memberID)
+			if err != nil {
+				newrelic.FromContext(ctx).NoticeError(err)
+				continue
+			}
+
+			select {
+			case pipe <- result:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return pipe, nil
+}
+
 // MemberHomeLocation returns service.MemberHomeLocationResolver implementation.
 func (r *resolver) MemberHomeLocation() service.MemberHomeLocationResolver {
 	return &memberHomeLocationResolver{r}
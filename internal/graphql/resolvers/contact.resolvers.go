@@ -31,8 +31,55 @@ func (r *memberContactResolver) Info(ctx context.Context, obj *athena.MemberCont
 	}
 }
 
-func (r *queryResolver) MemberContacts(ctx context.Context, memberID uint, page uint) ([]*athena.MemberContact, error) {
-	return r.contact.MemberContacts(ctx, memberID, page)
+func (r *queryResolver) MemberContacts(ctx context.Context, memberID uint, first *uint, after *string, filter *service.MemberContactFilter, sort *service.MemberContactSort) (*service.MemberContactConnection, error) {
+	err := r.authorizeMember(ctx, memberID)
+	if err != nil {
+		return nil, err
+	}
+
+	operators, err := memberContactFilterOperators(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var pageSort *athena.PageSort
+	if sort != nil {
+		pageSort, err = memberContactSortColumns.pageSort(sort.Field, sort.Direction)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	page, err := athena.NewPage(first, after, pageSort)
+	if err != nil {
+		return nil, err
+	}
+
+	contacts, err := r.contact.MemberContacts(ctx, memberID, page, operators...)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := r.contact.MemberContactCount(ctx, memberID, operators...)
+	if err != nil {
+		return nil, err
+	}
+
+	n, info := newPageInfo(page, len(contacts))
+	edges := make([]*service.MemberContactEdge, 0, n)
+	cursors := make([]string, 0, n)
+	for _, contact := range contacts[:n] {
+		cursor := athena.NewCursor(uint64(contact.ContactID))
+		if sort != nil {
+			cursor = athena.NewSortedCursor(uint64(contact.ContactID), memberContactSortValue(sort.Field, contact))
+		}
+
+		edges = append(edges, &service.MemberContactEdge{Cursor: cursor.String(), Node: contact})
+		cursors = append(cursors, cursor.String())
+	}
+	setPageCursors(info, cursors...)
+
+	return &service.MemberContactConnection{Edges: edges, PageInfo: info, TotalCount: count}, nil
 }
 
 // MemberContact returns service.MemberContactResolver implementation.
@@ -5,7 +5,6 @@ package resolvers
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/eveisesi/athena"
 	"github.com/eveisesi/athena/internal/graphql/dataloaders"
@@ -25,19 +24,66 @@ func (r *memberContractResolver) Type(ctx context.Context, obj *athena.MemberCon
 }
 
 func (r *memberContractResolver) Items(ctx context.Context, obj *athena.MemberContract) ([]*athena.MemberContractItem, error) {
-	panic(fmt.Errorf("not implemented"))
+	return dataloaders.CtxLoaders(ctx).MemberContractItems.Load(athena.MemberContractKey{MemberID: obj.MemberID, ContractID: obj.ContractID})
 }
 
 func (r *memberContractResolver) Bids(ctx context.Context, obj *athena.MemberContract) ([]*athena.MemberContractBid, error) {
-	panic(fmt.Errorf("not implemented"))
+	return dataloaders.CtxLoaders(ctx).MemberContractBids.Load(athena.MemberContractKey{MemberID: obj.MemberID, ContractID: obj.ContractID})
 }
 
 func (r *memberContractBidResolver) Bidder(ctx context.Context, obj *athena.MemberContractBid) (*athena.Character, error) {
 	return dataloaders.CtxLoaders(ctx).Character.Load(obj.BidderID)
 }
 
-func (r *queryResolver) MemberContracts(ctx context.Context, memberID uint, page uint) ([]*athena.MemberContract, error) {
-	return r.contract.MemberContracts(ctx, memberID, page)
+func (r *queryResolver) MemberContracts(ctx context.Context, memberID uint, first *uint, after *string, filter *service.MemberContractFilter, sort *service.MemberContractSort) (*service.MemberContractConnection, error) {
+	err := r.authorizeMember(ctx, memberID)
+	if err != nil {
+		return nil, err
+	}
+
+	operators, err := memberContractFilterOperators(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var pageSort *athena.PageSort
+	if sort != nil {
+		pageSort, err = memberContractSortColumns.pageSort(sort.Field, sort.Direction)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	page, err := athena.NewPage(first, after, pageSort)
+	if err != nil {
+		return nil, err
+	}
+
+	contracts, err := r.contract.MemberContracts(ctx, memberID, page, operators...)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := r.contract.MemberContractCount(ctx, memberID, operators...)
+	if err != nil {
+		return nil, err
+	}
+
+	n, info := newPageInfo(page, len(contracts))
+	edges := make([]*service.MemberContractEdge, 0, n)
+	cursors := make([]string, 0, n)
+	for _, contract := range contracts[:n] {
+		cursor := athena.NewCursor(uint64(contract.ContractID))
+		if sort != nil {
+			cursor = athena.NewSortedCursor(uint64(contract.ContractID), memberContractSortValue(sort.Field, contract))
+		}
+
+		edges = append(edges, &service.MemberContractEdge{Cursor: cursor.String(), Node: contract})
+		cursors = append(cursors, cursor.String())
+	}
+	setPageCursors(info, cursors...)
+
+	return &service.MemberContractConnection{Edges: edges, PageInfo: info, TotalCount: count}, nil
 }
 
 // MemberContract returns service.MemberContractResolver implementation.
@@ -5,17 +5,45 @@ package resolvers
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/eveisesi/athena"
+	"github.com/eveisesi/athena/internal/graphql/dataloaders"
 	"github.com/eveisesi/athena/internal/graphql/service"
 )
 
-func (r *corporationResolver) Shares(ctx context.Context, obj *athena.Corporation) (uint, error) {
-	panic(fmt.Errorf("not implemented"))
+func (r *corporationResolver) Alliance(ctx context.Context, obj *athena.Corporation) (*athena.Alliance, error) {
+	if !obj.AllianceID.Valid {
+		return nil, nil
+	}
+	return dataloaders.CtxLoaders(ctx).Alliance.Load(obj.AllianceID.Uint)
+}
+
+func (r *corporationResolver) AllianceHistory(ctx context.Context, obj *athena.Corporation) ([]*athena.CorporationAllianceHistory, error) {
+	return dataloaders.CtxLoaders(ctx).CorporationAllianceHistory.Load(obj.ID)
+}
+
+func (r *corporationAllianceHistoryResolver) IsDeleted(ctx context.Context, obj *athena.CorporationAllianceHistory) (bool, error) {
+	return obj.IsDeleteed.Valid && obj.IsDeleteed.Bool, nil
+}
+
+func (r *corporationAllianceHistoryResolver) TenureDays(ctx context.Context, obj *athena.CorporationAllianceHistory) (uint, error) {
+	return uint(obj.Tenure().Hours() / 24), nil
+}
+
+func (r *corporationAllianceHistoryResolver) Alliance(ctx context.Context, obj *athena.CorporationAllianceHistory) (*athena.Alliance, error) {
+	if !obj.AllianceID.Valid {
+		return nil, nil
+	}
+	return dataloaders.CtxLoaders(ctx).Alliance.Load(obj.AllianceID.Uint)
 }
 
 // Corporation returns service.CorporationResolver implementation.
 func (r *resolver) Corporation() service.CorporationResolver { return &corporationResolver{r} }
 
+// CorporationAllianceHistory returns service.CorporationAllianceHistoryResolver implementation.
+func (r *resolver) CorporationAllianceHistory() service.CorporationAllianceHistoryResolver {
+	return &corporationAllianceHistoryResolver{r}
+}
+
 type corporationResolver struct{ *resolver }
+type corporationAllianceHistoryResolver struct{ *resolver }
@@ -0,0 +1,66 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eveisesi/athena"
+	"github.com/newrelic/go-agent/v3/newrelic"
+)
+
+// memberEvents subscribes to the change events of a member that match one of the provided types.
+// The returned channel is closed once ctx is cancelled
+func (r *resolver) memberEvents(ctx context.Context, memberID uint, types ...athena.MemberEventType) (<-chan *athena.MemberEvent, error) {
+
+	err := r.authorizeMember(ctx, memberID)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := r.member.MemberEvents(ctx, memberID)
+	if err != nil {
+		newrelic.FromContext(ctx).NoticeError(err)
+		return nil, fmt.Errorf("failed to subscribe to events for member %d", memberID)
+	}
+
+	pipe := make(chan *athena.MemberEvent)
+	go func() {
+		defer close(pipe)
+
+		for event := range events {
+			if !hasMemberEventType(event, types) {
+				continue
+			}
+
+			select {
+			case pipe <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return pipe, nil
+
+}
+
+func hasMemberEventType(event *athena.MemberEvent, types []athena.MemberEventType) bool {
+	for _, t := range types {
+		if event.Type == t {
+			return true
+		}
+	}
+
+	return false
+}
+
+// authorizeMember verifies that the request may access the data of the member, see apikey.Service.AuthorizeMember
+func (r *resolver) authorizeMember(ctx context.Context, memberID uint) error {
+	return r.apikey.AuthorizeMember(ctx, r.member.MemberFromContext(ctx), memberID)
+}
+
+// authorizeApplicant verifies that the request may review the application of the member, see
+// apikey.Service.AuthorizeApplicant
+func (r *resolver) authorizeApplicant(ctx context.Context, memberID uint) error {
+	return r.apikey.AuthorizeApplicant(ctx, r.member.MemberFromContext(ctx), memberID)
+}
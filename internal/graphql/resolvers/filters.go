@@ -0,0 +1,361 @@
+package resolvers
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/eveisesi/athena"
+	"github.com/eveisesi/athena/internal/graphql/service"
+)
+
+// columnAllowlist maps the fields of a filter or sort input onto the column that they are applied
+// to. Clients only ever pick from the entries of an allowlist, column names are never read from a
+// request, so nothing a client sends can reach BuildFilters as a column
+type columnAllowlist map[string]string
+
+var memberAssetFilterColumns = columnAllowlist{
+	"typeID":          "type_id",
+	"locationID":      "location_id",
+	"locationFlag":    "location_flag",
+	"locationType":    "location_type",
+	"quantity":        "quantity",
+	"isBlueprintCopy": "is_blueprint_copy",
+	"isSingleton":     "is_singleton",
+}
+
+var memberAssetSortColumns = columnAllowlist{
+	service.MemberAssetSortFieldTypeID.String():     "type_id",
+	service.MemberAssetSortFieldLocationID.String(): "location_id",
+	service.MemberAssetSortFieldQuantity.String():   "quantity",
+}
+
+var memberContactFilterColumns = columnAllowlist{
+	"contactID":   "contact_id",
+	"contactType": "contact_type",
+	"standing":    "standing",
+	"isBlocked":   "is_blocked",
+	"isWatched":   "is_watched",
+}
+
+var memberContactSortColumns = columnAllowlist{
+	service.MemberContactSortFieldContactType.String(): "contact_type",
+	service.MemberContactSortFieldStanding.String():    "standing",
+}
+
+var memberContractFilterColumns = columnAllowlist{
+	"type":                "type",
+	"status":              "status",
+	"availability":        "availability",
+	"title":               "title",
+	"issuerID":            "issuer_id",
+	"issuerCorporationID": "issuer_corporation_id",
+	"assigneeID":          "assignee_id",
+	"acceptorID":          "acceptor_id",
+	"forCorporation":      "for_corporation",
+	"startLocationID":     "start_location_id",
+	"endLocationID":       "end_location_id",
+	"price":               "price",
+	"reward":              "reward",
+	"collateral":          "collateral",
+	"volume":              "volume",
+	"dateIssued":          "date_issued",
+	"dateExpired":         "date_expired",
+	"dateAccepted":        "date_accepted",
+	"dateCompleted":       "date_completed",
+}
+
+// type and status are ENUM columns, which MySQL orders by their position in the ENUM rather than
+// by their value. That would break the keyset comparison of a sorted page, so they are not sortable
+var memberContractSortColumns = columnAllowlist{
+	service.MemberContractSortFieldDateIssued.String():  "date_issued",
+	service.MemberContractSortFieldDateExpired.String(): "date_expired",
+}
+
+// pageSort returns the PageSort for the sort field of an input
+func (a columnAllowlist) pageSort(field fmt.Stringer, direction service.SortDirection) (*athena.PageSort, error) {
+
+	column, ok := a[field.String()]
+	if !ok {
+		return nil, fmt.Errorf("cannot sort by %s", field)
+	}
+
+	sort := athena.SortAsc
+	if direction == service.SortDirectionDesc {
+		sort = athena.SortDesc
+	}
+
+	return &athena.PageSort{Column: column, Sort: sort}, nil
+
+}
+
+// filterBuilder translates the fields of a filter input into operators on the columns of an allowlist
+type filterBuilder struct {
+	columns   columnAllowlist
+	operators []*athena.Operator
+	err       error
+}
+
+func newFilterBuilder(columns columnAllowlist) *filterBuilder {
+	return &filterBuilder{
+		columns:   columns,
+		operators: make([]*athena.Operator, 0),
+	}
+}
+
+func (b *filterBuilder) column(field string) (string, bool) {
+
+	if b.err != nil {
+		return "", false
+	}
+
+	column, ok := b.columns[field]
+	if !ok {
+		b.err = fmt.Errorf("cannot filter by %s", field)
+		return "", false
+	}
+
+	return column, true
+
+}
+
+func (b *filterBuilder) add(operators ...*athena.Operator) {
+	b.operators = append(b.operators, operators...)
+}
+
+// comparisons holds the fields of a filter input that compare a column against a value. Fields
+// that the input does not have, or that the client did not set, are left nil
+type comparisons struct {
+	eq, notEq, in, notIn, gt, gte, lt, lte interface{}
+}
+
+// compare adds an operator for each field of c that the client set. Pointers are dereferenced
+// and empty lists are skipped
+func (b *filterBuilder) compare(field string, c comparisons) {
+
+	column, ok := b.column(field)
+	if !ok {
+		return
+	}
+
+	for _, comparison := range []struct {
+		value interface{}
+		new   func(string, interface{}) *athena.Operator
+	}{
+		{c.eq, athena.NewEqualOperator},
+		{c.notEq, athena.NewNotEqualOperator},
+		{c.in, athena.NewInOperator},
+		{c.notIn, athena.NewNotInOperator},
+		{c.gt, athena.NewGreaterThanOperator},
+		{c.gte, athena.NewGreaterThanEqualToOperator},
+		{c.lt, athena.NewLessThanOperator},
+		{c.lte, athena.NewLessThanEqualToOperator},
+	} {
+		if value, ok := comparisonValue(comparison.value); ok {
+			b.add(comparison.new(column, value))
+		}
+	}
+
+}
+
+func comparisonValue(value interface{}) (interface{}, bool) {
+
+	if value == nil {
+		return nil, false
+	}
+
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return nil, false
+		}
+
+		return v.Elem().Interface(), true
+	case reflect.Slice:
+		return value, v.Len() > 0
+	}
+
+	return value, true
+
+}
+
+func (b *filterBuilder) uint(field string, f *service.UintFilter) {
+	if f != nil {
+		b.compare(field, comparisons{eq: f.Eq, notEq: f.NotEq, in: f.In, notIn: f.NotIn, gt: f.Gt, gte: f.Gte, lt: f.Lt, lte: f.Lte})
+	}
+}
+
+func (b *filterBuilder) uint64(field string, f *service.Uint64Filter) {
+	if f != nil {
+		b.compare(field, comparisons{eq: f.Eq, notEq: f.NotEq, in: f.In, notIn: f.NotIn, gt: f.Gt, gte: f.Gte, lt: f.Lt, lte: f.Lte})
+	}
+}
+
+func (b *filterBuilder) int(field string, f *service.IntFilter) {
+	if f != nil {
+		b.compare(field, comparisons{eq: f.Eq, notEq: f.NotEq, gt: f.Gt, gte: f.Gte, lt: f.Lt, lte: f.Lte})
+	}
+}
+
+func (b *filterBuilder) float(field string, f *service.FloatFilter) {
+	if f != nil {
+		b.compare(field, comparisons{gt: f.Gt, gte: f.Gte, lt: f.Lt, lte: f.Lte})
+	}
+}
+
+func (b *filterBuilder) string(field string, f *service.StringFilter) {
+
+	if f == nil {
+		return
+	}
+
+	column, ok := b.column(field)
+	if !ok {
+		return
+	}
+
+	if f.Eq != nil {
+		b.add(athena.NewEqualOperator(column, *f.Eq))
+	}
+	if f.NotEq != nil {
+		b.add(athena.NewNotEqualOperator(column, *f.NotEq))
+	}
+	if len(f.In) > 0 {
+		b.add(athena.NewInOperator(column, f.In))
+	}
+	if len(f.NotIn) > 0 {
+		b.add(athena.NewNotInOperator(column, f.NotIn))
+	}
+	if f.Like != nil {
+		b.add(athena.NewLikeOperator(column, *f.Like))
+	}
+
+}
+
+func (b *filterBuilder) time(field string, f *service.TimeFilter) {
+	if f != nil {
+		b.compare(field, comparisons{gt: f.Gt, gte: f.Gte, lt: f.Lt, lte: f.Lte})
+	}
+}
+
+func (b *filterBuilder) boolean(field string, v *bool) {
+
+	if v == nil {
+		return
+	}
+
+	column, ok := b.column(field)
+	if !ok {
+		return
+	}
+
+	b.add(athena.NewEqualOperator(column, *v))
+
+}
+
+func (b *filterBuilder) build() ([]*athena.Operator, error) {
+	return b.operators, b.err
+}
+
+func memberAssetFilterOperators(filter *service.MemberAssetFilter) ([]*athena.Operator, error) {
+
+	b := newFilterBuilder(memberAssetFilterColumns)
+	if filter == nil {
+		return b.build()
+	}
+
+	b.uint("typeID", filter.TypeID)
+	b.uint64("locationID", filter.LocationID)
+	b.string("locationFlag", filter.LocationFlag)
+	b.string("locationType", filter.LocationType)
+	b.int("quantity", filter.Quantity)
+	b.boolean("isBlueprintCopy", filter.IsBlueprintCopy)
+	b.boolean("isSingleton", filter.IsSingleton)
+
+	return b.build()
+
+}
+
+func memberAssetSortValue(field service.MemberAssetSortField, asset *athena.MemberAsset) interface{} {
+	switch field {
+	case service.MemberAssetSortFieldTypeID:
+		return asset.TypeID
+	case service.MemberAssetSortFieldLocationID:
+		return asset.LocationID
+	case service.MemberAssetSortFieldQuantity:
+		return asset.Quantity
+	}
+
+	return nil
+}
+
+func memberContactFilterOperators(filter *service.MemberContactFilter) ([]*athena.Operator, error) {
+
+	b := newFilterBuilder(memberContactFilterColumns)
+	if filter == nil {
+		return b.build()
+	}
+
+	b.uint("contactID", filter.ContactID)
+	b.string("contactType", filter.ContactType)
+	b.float("standing", filter.Standing)
+	b.boolean("isBlocked", filter.IsBlocked)
+	b.boolean("isWatched", filter.IsWatched)
+
+	return b.build()
+
+}
+
+func memberContactSortValue(field service.MemberContactSortField, contact *athena.MemberContact) interface{} {
+	switch field {
+	case service.MemberContactSortFieldContactType:
+		return contact.ContactType
+	case service.MemberContactSortFieldStanding:
+		// standing is a FLOAT column
+		return float32(contact.Standing)
+	}
+
+	return nil
+}
+
+func memberContractFilterOperators(filter *service.MemberContractFilter) ([]*athena.Operator, error) {
+
+	b := newFilterBuilder(memberContractFilterColumns)
+	if filter == nil {
+		return b.build()
+	}
+
+	b.string("type", filter.Type)
+	b.string("status", filter.Status)
+	b.string("availability", filter.Availability)
+	b.string("title", filter.Title)
+	b.uint("issuerID", filter.IssuerID)
+	b.uint("issuerCorporationID", filter.IssuerCorporationID)
+	b.uint("assigneeID", filter.AssigneeID)
+	b.uint("acceptorID", filter.AcceptorID)
+	b.boolean("forCorporation", filter.ForCorporation)
+	b.uint64("startLocationID", filter.StartLocationID)
+	b.uint64("endLocationID", filter.EndLocationID)
+	b.float("price", filter.Price)
+	b.float("reward", filter.Reward)
+	b.float("collateral", filter.Collateral)
+	b.float("volume", filter.Volume)
+	b.time("dateIssued", filter.DateIssued)
+	b.time("dateExpired", filter.DateExpired)
+	b.time("dateAccepted", filter.DateAccepted)
+	b.time("dateCompleted", filter.DateCompleted)
+
+	return b.build()
+
+}
+
+func memberContractSortValue(field service.MemberContractSortField, contract *athena.MemberContract) interface{} {
+	switch field {
+	case service.MemberContractSortFieldDateIssued:
+		return contract.DateIssued
+	case service.MemberContractSortFieldDateExpired:
+		return contract.DateExpired
+	}
+
+	return nil
+}
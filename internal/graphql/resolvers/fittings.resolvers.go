@@ -0,0 +1,81 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+	"sort"
+
+	"github.com/eveisesi/athena"
+	"github.com/eveisesi/athena/internal/graphql/dataloaders"
+	"github.com/eveisesi/athena/internal/graphql/service"
+)
+
+func (r *memberFittingResolver) Ship(ctx context.Context, obj *athena.MemberFitting) (*athena.Type, error) {
+	return dataloaders.CtxLoaders(ctx).Item.Load(obj.ShipTypeID)
+}
+
+func (r *memberFittingResolver) Slots(ctx context.Context, obj *athena.MemberFitting) ([]*service.FittingSlotGroup, error) {
+	slotMap := make(map[athena.FittingSlot]*service.FittingSlotGroup)
+	for _, item := range obj.Items {
+		slot := item.Flag.Slot()
+		if _, ok := slotMap[slot]; !ok {
+			slotMap[slot] = &service.FittingSlotGroup{
+				Slot:  slot.String(),
+				Items: make([]*athena.MemberFittingItem, 0),
+			}
+		}
+
+		slotMap[slot].Items = append(slotMap[slot].Items, item)
+	}
+
+	groups := make([]*service.FittingSlotGroup, 0, len(slotMap))
+	for _, slot := range athena.AllFittingSlots {
+		if group, ok := slotMap[slot]; ok {
+			sort.SliceStable(group.Items, func(i, j int) bool {
+				return group.Items[i].Flag < group.Items[j].Flag
+			})
+			groups = append(groups, group)
+		}
+	}
+
+	return groups, nil
+}
+
+func (r *memberFittingItemResolver) Flag(ctx context.Context, obj *athena.MemberFittingItem) (string, error) {
+	return obj.Flag.String(), nil
+}
+
+func (r *memberFittingItemResolver) Type(ctx context.Context, obj *athena.MemberFittingItem) (*athena.Type, error) {
+	return dataloaders.CtxLoaders(ctx).Item.Load(obj.TypeID)
+}
+
+func (r *queryResolver) MemberFittings(ctx context.Context, memberID uint) ([]*athena.MemberFitting, error) {
+	err := r.authorizeMember(ctx, memberID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.fittings.MemberFittings(ctx, memberID)
+}
+
+func (r *queryResolver) MemberFitting(ctx context.Context, memberID uint, fittingID uint) (*athena.MemberFitting, error) {
+	err := r.authorizeMember(ctx, memberID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.fittings.MemberFitting(ctx, memberID, fittingID)
+}
+
+// MemberFitting returns service.MemberFittingResolver implementation.
+func (r *resolver) MemberFitting() service.MemberFittingResolver { return &memberFittingResolver{r} }
+
+// MemberFittingItem returns service.MemberFittingItemResolver implementation.
+func (r *resolver) MemberFittingItem() service.MemberFittingItemResolver {
+	return &memberFittingItemResolver{r}
+}
+
+type memberFittingResolver struct{ *resolver }
+type memberFittingItemResolver struct{ *resolver }
@@ -9,6 +9,7 @@ import (
 	"github.com/eveisesi/athena"
 	"github.com/eveisesi/athena/internal/graphql/dataloaders"
 	"github.com/eveisesi/athena/internal/graphql/service"
+	"github.com/newrelic/go-agent/v3/newrelic"
 )
 
 func (r *memberLocationResolver) System(ctx context.Context, obj *athena.MemberLocation) (*athena.SolarSystem, error) {
@@ -34,17 +35,116 @@ func (r *memberShipResolver) Ship(ctx context.Context, obj *athena.MemberShip) (
 }
 
 func (r *queryResolver) MemberLocation(ctx context.Context, memberID uint) (*athena.MemberLocation, error) {
+	err := r.authorizeMember(ctx, memberID)
+	if err != nil {
+		return nil, err
+	}
+
 	return r.location.MemberLocation(ctx, memberID)
 }
 
 func (r *queryResolver) MemberOnline(ctx context.Context, memberID uint) (*athena.MemberOnline, error) {
+	err := r.authorizeMember(ctx, memberID)
+	if err != nil {
+		return nil, err
+	}
+
 	return r.location.MemberOnline(ctx, memberID)
 }
 
 func (r *queryResolver) MemberShip(ctx context.Context, memberID uint) (*athena.MemberShip, error) {
+	err := r.authorizeMember(ctx, memberID)
+	if err != nil {
+		return nil, err
+	}
+
 	return r.location.MemberShip(ctx, memberID)
 }
 
+func (r *subscriptionResolver) MemberLocationChanged(ctx context.Context, memberID uint) (<-chan *athena.MemberLocation, error) {
+	events, err := r.memberEvents(ctx, memberID, athena.MemberLocationChanged)
+	if err != nil {
+		return nil, err
+	}
+
+	pipe := make(chan *athena.MemberLocation)
+	go func() {
+		defer close(pipe)
+
+		for range events {
+			result, err := r.location.MemberLocation(ctx, memberID)
+			if err != nil {
+				newrelic.FromContext(ctx).NoticeError(err)
+				continue
+			}
+
+			select {
+			case pipe <- result:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return pipe, nil
+}
+
+func (r *subscriptionResolver) MemberOnlineChanged(ctx context.Context, memberID uint) (<-chan *athena.MemberOnline, error) {
+	events, err := r.memberEvents(ctx, memberID, athena.MemberOnlineChanged)
+	if err != nil {
+		return nil, err
+	}
+
+	pipe := make(chan *athena.MemberOnline)
+	go func() {
+		defer close(pipe)
+
+		for range events {
+			result, err := r.location.MemberOnline(ctx, memberID)
+			if err != nil {
+				newrelic.FromContext(ctx).NoticeError(err)
+				continue
+			}
+
+			select {
+			case pipe <- result:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return pipe, nil
+}
+
+func (r *subscriptionResolver) MemberShipChanged(ctx context.Context, memberID uint) (<-chan *athena.MemberShip, error) {
+	events, err := r.memberEvents(ctx, memberID, athena.MemberShipChanged)
+	if err != nil {
+		return nil, err
+	}
+
+	pipe := make(chan *athena.MemberShip)
+	go func() {
+		defer close(pipe)
+
+		for range events {
+			result, err := r.location.MemberShip(ctx, memberID)
+			if err != nil {
+				newrelic.FromContext(ctx).NoticeError(err)
+				continue
+			}
+
+			select {
+			case pipe <- result:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return pipe, nil
+}
+
 // MemberLocation returns service.MemberLocationResolver implementation.
 func (r *resolver) MemberLocation() service.MemberLocationResolver { return &memberLocationResolver{r} }
 
@@ -0,0 +1,31 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/eveisesi/athena"
+	"github.com/eveisesi/athena/internal/graphql/dataloaders"
+	"github.com/eveisesi/athena/internal/graphql/service"
+)
+
+// mailParty resolves the sender or a recipient of a mail to the entity that it references.
+// Parties of an unknown type resolve to nil
+func (r *resolver) mailParty(ctx context.Context, id uint, partyType string) (service.MailParty, error) {
+	switch partyType {
+	case string(athena.RecipientTypeCharacter):
+		return dataloaders.CtxLoaders(ctx).Character.Load(id)
+	case string(athena.RecipientTypeCorporation):
+		return dataloaders.CtxLoaders(ctx).Corporation.Load(id)
+	case string(athena.RecipientTypeAlliance):
+		return dataloaders.CtxLoaders(ctx).Alliance.Load(id)
+	case string(athena.RecipientTypeMailingList):
+		list, err := dataloaders.CtxLoaders(ctx).MailingList.Load(id)
+		if err != nil || list == nil {
+			return nil, err
+		}
+
+		return list, nil
+	default:
+		return nil, nil
+	}
+}
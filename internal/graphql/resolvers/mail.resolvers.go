@@ -0,0 +1,159 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eveisesi/athena"
+	"github.com/eveisesi/athena/internal/graphql/dataloaders"
+	"github.com/eveisesi/athena/internal/graphql/service"
+)
+
+func (r *mailHeaderResolver) Sender(ctx context.Context, obj *athena.MailHeader) (service.MailParty, error) {
+	if !obj.Sender.Valid || !obj.SenderType.Valid {
+		return nil, nil
+	}
+
+	return r.mailParty(ctx, obj.Sender.Uint, obj.SenderType.String)
+}
+
+func (r *mailHeaderResolver) Recipients(ctx context.Context, obj *athena.MailHeader) ([]*athena.MailRecipient, error) {
+	if obj.Recipients != nil {
+		return obj.Recipients, nil
+	}
+
+	return dataloaders.CtxLoaders(ctx).MailRecipients.Load(obj.MailID)
+}
+
+func (r *mailRecipientResolver) RecipientType(ctx context.Context, obj *athena.MailRecipient) (string, error) {
+	return string(obj.RecipientType), nil
+}
+
+func (r *mailRecipientResolver) Recipient(ctx context.Context, obj *athena.MailRecipient) (service.MailParty, error) {
+	return r.mailParty(ctx, obj.RecipientID, string(obj.RecipientType))
+}
+
+func (r *memberMailHeaderResolver) Labels(ctx context.Context, obj *athena.MemberMailHeader) ([]uint64, error) {
+	return []uint64(obj.Labels), nil
+}
+
+func (r *memberMailHeaderResolver) Mail(ctx context.Context, obj *athena.MemberMailHeader) (*athena.MailHeader, error) {
+	return dataloaders.CtxLoaders(ctx).MailHeader.Load(obj.MailID)
+}
+
+func (r *memberMailLabelsResolver) Labels(ctx context.Context, obj *athena.MemberMailLabels) ([]*athena.MailLabel, error) {
+	labels := make([]*athena.MailLabel, 0, len(obj.Labels))
+	for i := range obj.Labels {
+		labels = append(labels, &obj.Labels[i])
+	}
+
+	return labels, nil
+}
+
+func (r *queryResolver) MemberMailHeaders(ctx context.Context, memberID uint, first *uint, after *string, filter *service.MemberMailHeaderFilter) (*service.MemberMailHeaderConnection, error) {
+	err := r.authorizeMember(ctx, memberID)
+	if err != nil {
+		return nil, err
+	}
+
+	var mailFilter *athena.MemberMailHeaderFilter
+	if filter != nil {
+		mailFilter = new(athena.MemberMailHeaderFilter)
+		if filter.LabelID != nil {
+			mailFilter.LabelID.SetValid(*filter.LabelID)
+		}
+		if filter.SenderID != nil {
+			mailFilter.SenderID.SetValid(*filter.SenderID)
+		}
+		if filter.RecipientType != nil {
+			mailFilter.RecipientType = athena.RecipientType(*filter.RecipientType)
+			if !mailFilter.RecipientType.IsValid() {
+				return nil, fmt.Errorf("%s is not a valid recipient type", *filter.RecipientType)
+			}
+		}
+		if filter.From != nil {
+			mailFilter.From.SetValid(*filter.From)
+		}
+		if filter.To != nil {
+			mailFilter.To.SetValid(*filter.To)
+		}
+	}
+
+	page, err := athena.NewPage(first, after, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	headers, err := r.mail.MemberMailHeaders(ctx, memberID, page, mailFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := r.mail.MemberMailHeaderCount(ctx, memberID, mailFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	n, info := newPageInfo(page, len(headers))
+	edges := make([]*service.MemberMailHeaderEdge, 0, n)
+	cursors := make([]string, 0, n)
+	for _, header := range headers[:n] {
+		cursor := athena.NewCursor(uint64(header.MailID)).String()
+		edges = append(edges, &service.MemberMailHeaderEdge{Cursor: cursor, Node: header})
+		cursors = append(cursors, cursor)
+	}
+	setPageCursors(info, cursors...)
+
+	return &service.MemberMailHeaderConnection{Edges: edges, PageInfo: info, TotalCount: count}, nil
+}
+
+func (r *queryResolver) MemberMailHeader(ctx context.Context, memberID uint, mailID uint) (*athena.MemberMailHeader, error) {
+	err := r.authorizeMember(ctx, memberID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.mail.MemberMailHeader(ctx, memberID, mailID)
+}
+
+func (r *queryResolver) MemberMailLabels(ctx context.Context, memberID uint) (*athena.MemberMailLabels, error) {
+	err := r.authorizeMember(ctx, memberID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.mail.MemberMailLabels(ctx, memberID)
+}
+
+func (r *queryResolver) MemberMailingLists(ctx context.Context, memberID uint) ([]*athena.MailingList, error) {
+	err := r.authorizeMember(ctx, memberID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.mail.MemberMailingLists(ctx, memberID)
+}
+
+// MailHeader returns service.MailHeaderResolver implementation.
+func (r *resolver) MailHeader() service.MailHeaderResolver { return &mailHeaderResolver{r} }
+
+// MailRecipient returns service.MailRecipientResolver implementation.
+func (r *resolver) MailRecipient() service.MailRecipientResolver { return &mailRecipientResolver{r} }
+
+// MemberMailHeader returns service.MemberMailHeaderResolver implementation.
+func (r *resolver) MemberMailHeader() service.MemberMailHeaderResolver {
+	return &memberMailHeaderResolver{r}
+}
+
+// MemberMailLabels returns service.MemberMailLabelsResolver implementation.
+func (r *resolver) MemberMailLabels() service.MemberMailLabelsResolver {
+	return &memberMailLabelsResolver{r}
+}
+
+type mailHeaderResolver struct{ *resolver }
+type mailRecipientResolver struct{ *resolver }
+type memberMailHeaderResolver struct{ *resolver }
+type memberMailLabelsResolver struct{ *resolver }
@@ -10,6 +10,7 @@ import (
 	"github.com/eveisesi/athena"
 	"github.com/eveisesi/athena/internal/graphql/dataloaders"
 	"github.com/eveisesi/athena/internal/graphql/service"
+	"github.com/newrelic/go-agent/v3/newrelic"
 )
 
 func (r *memberResolver) Scopes(ctx context.Context, obj *athena.Member) ([]string, error) {
@@ -21,6 +22,15 @@ func (r *memberResolver) Scopes(ctx context.Context, obj *athena.Member) ([]stri
 	return s, nil
 }
 
+func (r *memberResolver) ScopeDetails(ctx context.Context, obj *athena.Member) ([]*athena.MemberScope, error) {
+	s := make([]*athena.MemberScope, 0, len(obj.Scopes))
+	for i := range obj.Scopes {
+		s = append(s, &obj.Scopes[i])
+	}
+
+	return s, nil
+}
+
 func (r *memberResolver) Main(ctx context.Context, obj *athena.Member) (*athena.Character, error) {
 	if !obj.MainID.Valid {
 		return nil, nil
@@ -33,13 +43,100 @@ func (r *memberResolver) Character(ctx context.Context, obj *athena.Member) (*at
 	return dataloaders.CtxLoaders(ctx).Character.Load(obj.ID)
 }
 
+func (r *memberScopeResolver) Scope(ctx context.Context, obj *athena.MemberScope) (string, error) {
+	return obj.Scope.String(), nil
+}
+
+func (r *mutationResolver) PurgeMember(ctx context.Context) (bool, error) {
+	member := r.member.MemberFromContext(ctx)
+	if member == nil {
+		return false, fmt.Errorf("request is not authenticated")
+	}
+
+	err := r.member.PurgeMember(ctx, member.ID, athena.GraphQLAuditSource)
+	if err != nil {
+		newrelic.FromContext(ctx).NoticeError(err)
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *mutationResolver) RefreshMember(ctx context.Context, memberID uint, scopes []string, force *bool) (*athena.RefreshJob, error) {
+	err := r.authorizeApplicant(ctx, memberID)
+	if err != nil {
+		return nil, err
+	}
+
+	s := make([]athena.Scope, 0, len(scopes))
+	for _, scope := range scopes {
+		s = append(s, athena.Scope(scope))
+	}
+
+	job, err := r.member.RefreshMember(ctx, memberID, s, force != nil && *force)
+	if err != nil {
+		newrelic.FromContext(ctx).NoticeError(err)
+		return nil, err
+	}
+
+	return job, nil
+}
+
 func (r *queryResolver) Member(ctx context.Context) (*athena.Member, error) {
-	// r.member.MemberFromContext(ctx)
+	member := r.member.MemberFromContext(ctx)
+	if member == nil {
+		return nil, fmt.Errorf("Failed to fetch member from context")
+	}
+
+	return member, nil
+}
+
+func (r *refreshJobResolver) Status(ctx context.Context, obj *athena.RefreshJob) (string, error) {
+	return obj.Status.String(), nil
+}
+
+func (r *refreshJobScopeResolver) Scope(ctx context.Context, obj *athena.RefreshJobScope) (string, error) {
+	return obj.Scope.String(), nil
+}
+
+func (r *refreshJobScopeResolver) Status(ctx context.Context, obj *athena.RefreshJobScope) (string, error) {
+	return obj.Status.String(), nil
+}
 
-	return nil, fmt.Errorf("Failed to fetch member from context")
+func (r *subscriptionResolver) RefreshJobProgress(ctx context.Context, jobID string) (<-chan *athena.RefreshJob, error) {
+	job, err := r.member.RefreshJob(ctx, jobID)
+	if err != nil {
+		newrelic.FromContext(ctx).NoticeError(err)
+		return nil, fmt.Errorf("failed to fetch job %s", jobID)
+	}
+
+	if job == nil {
+		return nil, fmt.Errorf("job %s does not exist", jobID)
+	}
+
+	err = r.authorizeApplicant(ctx, job.MemberID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.member.RefreshJobProgress(ctx, jobID)
 }
 
 // Member returns service.MemberResolver implementation.
 func (r *resolver) Member() service.MemberResolver { return &memberResolver{r} }
 
+// MemberScope returns service.MemberScopeResolver implementation.
+func (r *resolver) MemberScope() service.MemberScopeResolver { return &memberScopeResolver{r} }
+
+// RefreshJob returns service.RefreshJobResolver implementation.
+func (r *resolver) RefreshJob() service.RefreshJobResolver { return &refreshJobResolver{r} }
+
+// RefreshJobScope returns service.RefreshJobScopeResolver implementation.
+func (r *resolver) RefreshJobScope() service.RefreshJobScopeResolver {
+	return &refreshJobScopeResolver{r}
+}
+
 type memberResolver struct{ *resolver }
+type memberScopeResolver struct{ *resolver }
+type refreshJobResolver struct{ *resolver }
+type refreshJobScopeResolver struct{ *resolver }
@@ -0,0 +1,36 @@
+package resolvers
+
+import (
+	"github.com/eveisesi/athena"
+	"github.com/eveisesi/athena/internal/graphql/service"
+)
+
+// newPageInfo returns the number of the n fetched records that belong to page along with the
+// PageInfo of the page. athena.Page fetches one record past the end of the page to tell whether
+// another page follows it, that record is not part of the page
+func newPageInfo(page *athena.Page, n int) (int, *service.PageInfo) {
+
+	info := &service.PageInfo{
+		HasPreviousPage: page.After != nil,
+	}
+
+	if n > int(page.First) {
+		info.HasNextPage = true
+		n = int(page.First)
+	}
+
+	return n, info
+
+}
+
+// setPageCursors records the cursors of the first and last edges of a page on info
+func setPageCursors(info *service.PageInfo, cursors ...string) {
+
+	if len(cursors) == 0 {
+		return
+	}
+
+	info.StartCursor = &cursors[0]
+	info.EndCursor = &cursors[len(cursors)-1]
+
+}
@@ -0,0 +1,44 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+	"strings"
+
+	"github.com/eveisesi/athena"
+	"github.com/eveisesi/athena/internal/graphql/service"
+)
+
+func (r *memberRedFlagResolver) Entity(ctx context.Context, obj *athena.MemberRedFlag) (string, error) {
+	return obj.Entity.String(), nil
+}
+
+func (r *memberRedFlagResolver) Severity(ctx context.Context, obj *athena.MemberRedFlag) (service.RedFlagSeverity, error) {
+	return service.RedFlagSeverity(strings.ToUpper(obj.Severity.String())), nil
+}
+
+func (r *queryResolver) MemberRedFlags(ctx context.Context, memberID uint, severities []service.RedFlagSeverity) ([]*athena.MemberRedFlag, error) {
+	err := r.authorizeApplicant(ctx, memberID)
+	if err != nil {
+		return nil, err
+	}
+
+	operators := make([]*athena.Operator, 0, 1)
+	if len(severities) > 0 {
+		values := make([]string, 0, len(severities))
+		for _, severity := range severities {
+			values = append(values, strings.ToLower(severity.String()))
+		}
+
+		operators = append(operators, athena.NewInOperator("severity", values))
+	}
+
+	return r.redflag.MemberRedFlags(ctx, memberID, operators...)
+}
+
+// MemberRedFlag returns service.MemberRedFlagResolver implementation.
+func (r *resolver) MemberRedFlag() service.MemberRedFlagResolver { return &memberRedFlagResolver{r} }
+
+type memberRedFlagResolver struct{ *resolver }
@@ -0,0 +1,40 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+	"strings"
+
+	"github.com/eveisesi/athena"
+	"github.com/eveisesi/athena/internal/graphql/service"
+)
+
+func (r *queryResolver) MemberReport(ctx context.Context, memberID uint, format *service.ReportFormat) (*service.MemberReport, error) {
+	err := r.authorizeApplicant(ctx, memberID)
+	if err != nil {
+		return nil, err
+	}
+
+	if format == nil {
+		json := service.ReportFormatJSON
+		format = &json
+	}
+
+	// The report covers every member linked to memberID, which the caller may not be permitted to see
+	include := func(linkedID uint) bool {
+		return r.authorizeApplicant(ctx, linkedID) == nil
+	}
+
+	content, err := r.report.RenderMemberReport(ctx, memberID, athena.ReportFormat(strings.ToLower(format.String())), include)
+	if err != nil {
+		return nil, err
+	}
+
+	return &service.MemberReport{
+		MemberID: memberID,
+		Format:   *format,
+		Content:  string(content),
+	}, nil
+}
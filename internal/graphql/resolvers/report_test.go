@@ -0,0 +1,164 @@
+package resolvers
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/eveisesi/athena"
+	"github.com/eveisesi/athena/internal/apikey"
+	"github.com/eveisesi/athena/internal/cache"
+	"github.com/eveisesi/athena/internal/member"
+	"github.com/eveisesi/athena/internal/report"
+	"github.com/sirupsen/logrus"
+)
+
+// testAPIKeyCache serves a single API Key to apikey.Service.Authenticate
+type testAPIKeyCache struct {
+	cache.Service
+	key *athena.APIKey
+}
+
+func (c *testAPIKeyCache) APIKey(ctx context.Context, hash string) (*athena.APIKey, error) {
+	return c.key, nil
+}
+
+func (c *testAPIKeyCache) IncrementAPIKeyUsage(ctx context.Context, id uint, window time.Duration) (int64, error) {
+	return 1, nil
+}
+
+// testSessionMembers reports the member that a request is logged in as
+type testSessionMembers struct {
+	member.Service
+	session *athena.Member
+}
+
+func (m *testSessionMembers) MemberFromContext(ctx context.Context) *athena.Member {
+	return m.session
+}
+
+// testLinkedReports renders the IDs of the linked members that a report would include
+type testLinkedReports struct {
+	report.Service
+	linked []uint
+}
+
+func (r *testLinkedReports) RenderMemberReport(ctx context.Context, memberID uint, format athena.ReportFormat, include func(memberID uint) bool) ([]byte, error) {
+
+	included := make([]uint, 0, len(r.linked))
+	for _, id := range r.linked {
+		if id == memberID || include == nil || include(id) {
+			included = append(included, id)
+		}
+	}
+
+	return json.Marshal(included)
+
+}
+
+func TestMemberReportAuthorization(t *testing.T) {
+
+	const recruiterID = 10
+
+	var tests = []struct {
+		name     string
+		key      *athena.APIKey
+		session  *athena.Member
+		memberID uint
+		want     []uint
+		wantErr  bool
+	}{
+		{
+			name:     "key scoped to one member leaves out the linked members",
+			key:      &athena.APIKey{AllowedMembers: athena.SliceUint{2}},
+			memberID: 2,
+			want:     []uint{2},
+		},
+		{
+			name:     "key scoped to one member cannot read another",
+			key:      &athena.APIKey{AllowedMembers: athena.SliceUint{2}},
+			memberID: 1,
+			wantErr:  true,
+		},
+		{
+			name:     "key scoped to the linked members",
+			key:      &athena.APIKey{AllowedMembers: athena.SliceUint{1, 2, 3}},
+			memberID: 2,
+			want:     []uint{1, 2, 3},
+		},
+		{
+			name:     "member sees only themselves",
+			session:  &athena.Member{ID: 2},
+			memberID: 2,
+			want:     []uint{2},
+		},
+		{
+			name:     "member cannot read another member",
+			session:  &athena.Member{ID: 2},
+			memberID: 1,
+			wantErr:  true,
+		},
+		{
+			name:     "recruiter sees every linked member",
+			session:  &athena.Member{ID: recruiterID},
+			memberID: 2,
+			want:     []uint{1, 2, 3},
+		},
+		{
+			name:     "recruiter is not widened by a scoped key",
+			key:      &athena.APIKey{AllowedMembers: athena.SliceUint{2}},
+			session:  &athena.Member{ID: recruiterID},
+			memberID: 2,
+			want:     []uint{2},
+		},
+		{
+			name:     "unauthenticated",
+			memberID: 2,
+			wantErr:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			keys := apikey.NewService(logrus.New(), &testAPIKeyCache{key: test.key}, nil, nil, []uint{recruiterID})
+
+			ctx := context.Background()
+			if test.key != nil {
+				test.key.RateLimit = apikey.DefaultRateLimit
+
+				var err error
+				ctx, err = keys.ContextWithAPIKey(ctx, "athena_test")
+				if err != nil {
+					t.Fatalf("ContextWithAPIKey() error = %v", err)
+				}
+			}
+
+			r := &queryResolver{&resolver{
+				apikey: keys,
+				member: &testSessionMembers{session: test.session},
+				report: &testLinkedReports{linked: []uint{1, 2, 3}},
+			}}
+
+			got, err := r.MemberReport(ctx, test.memberID, nil)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("MemberReport() error = %v, wantErr %t", err, test.wantErr)
+			}
+			if test.wantErr {
+				return
+			}
+
+			var included []uint
+			err = json.Unmarshal([]byte(got.Content), &included)
+			if err != nil {
+				t.Fatalf("failed to decode report: %v", err)
+			}
+
+			if !reflect.DeepEqual(included, test.want) {
+				t.Errorf("MemberReport() included %v, want %v", included, test.want)
+			}
+		})
+	}
+
+}
@@ -2,6 +2,8 @@ package resolvers
 
 import (
 	"github.com/eveisesi/athena/internal/alliance"
+	"github.com/eveisesi/athena/internal/analysis"
+	"github.com/eveisesi/athena/internal/apikey"
 	"github.com/eveisesi/athena/internal/asset"
 	"github.com/eveisesi/athena/internal/auth"
 	"github.com/eveisesi/athena/internal/character"
@@ -9,10 +11,16 @@ import (
 	"github.com/eveisesi/athena/internal/contact"
 	"github.com/eveisesi/athena/internal/contract"
 	"github.com/eveisesi/athena/internal/corporation"
+	"github.com/eveisesi/athena/internal/fittings"
 	"github.com/eveisesi/athena/internal/graphql/service"
 	"github.com/eveisesi/athena/internal/location"
+	"github.com/eveisesi/athena/internal/mail"
 	"github.com/eveisesi/athena/internal/member"
+	"github.com/eveisesi/athena/internal/redflag"
+	"github.com/eveisesi/athena/internal/report"
+	"github.com/eveisesi/athena/internal/skill"
 	"github.com/eveisesi/athena/internal/universe"
+	"github.com/eveisesi/athena/internal/wallet"
 	"github.com/sirupsen/logrus"
 )
 
@@ -23,6 +31,7 @@ import (
 type resolver struct {
 	logger *logrus.Logger
 
+	apikey      apikey.Service
 	auth        auth.Service
 	member      member.Service
 	character   character.Service
@@ -34,10 +43,18 @@ type resolver struct {
 	contact     contact.Service
 	contract    contract.Service
 	asset       asset.Service
+	skill       skill.Service
+	wallet      wallet.Service
+	mail        mail.Service
+	fittings    fittings.Service
+	report      report.Service
+	redflag     redflag.Service
+	analysis    analysis.Service
 }
 
 func New(
 	logger *logrus.Logger,
+	apikey apikey.Service,
 	auth auth.Service,
 	member member.Service,
 	character character.Service,
@@ -49,9 +66,17 @@ func New(
 	contact contact.Service,
 	contract contract.Service,
 	asset asset.Service,
+	skill skill.Service,
+	wallet wallet.Service,
+	mail mail.Service,
+	fittings fittings.Service,
+	report report.Service,
+	redflag redflag.Service,
+	analysis analysis.Service,
 ) service.ResolverRoot {
 	return &resolver{
 		logger:      logger,
+		apikey:      apikey,
 		auth:        auth,
 		member:      member,
 		character:   character,
@@ -63,6 +88,13 @@ func New(
 		contact:     contact,
 		contract:    contract,
 		asset:       asset,
+		skill:       skill,
+		wallet:      wallet,
+		mail:        mail,
+		fittings:    fittings,
+		report:      report,
+		redflag:     redflag,
+		analysis:    analysis,
 	}
 }
 
@@ -0,0 +1,221 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+	"sort"
+
+	"github.com/eveisesi/athena"
+	"github.com/eveisesi/athena/internal/graphql/dataloaders"
+	"github.com/eveisesi/athena/internal/graphql/service"
+	"github.com/newrelic/go-agent/v3/newrelic"
+)
+
+func (r *memberSkillQueueResolver) Type(ctx context.Context, obj *athena.MemberSkillQueue) (*athena.Type, error) {
+	return dataloaders.CtxLoaders(ctx).Item.Load(obj.SkillID)
+}
+
+func (r *memberSkillsResolver) Groups(ctx context.Context, obj *athena.MemberSkills) ([]*service.SkillGroup, error) {
+	skillIDs := make([]uint, 0, len(obj.Skills))
+	for _, skill := range obj.Skills {
+		skillIDs = append(skillIDs, skill.SkillID)
+	}
+
+	items, errs := dataloaders.CtxLoaders(ctx).Item.LoadAll(skillIDs)
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	groupMap := make(map[uint]*service.SkillGroup)
+	groupIDs := make([]uint, 0)
+	for i, skill := range obj.Skills {
+		item := items[i]
+		if item == nil {
+			continue
+		}
+
+		if _, ok := groupMap[item.GroupID]; !ok {
+			groupMap[item.GroupID] = &service.SkillGroup{
+				GroupID: item.GroupID,
+				Skills:  make([]*athena.Skill, 0),
+			}
+			groupIDs = append(groupIDs, item.GroupID)
+		}
+
+		groupMap[item.GroupID].Skillpoints += skill.SkillpointsInSkill
+		groupMap[item.GroupID].Skills = append(groupMap[item.GroupID].Skills, skill)
+	}
+
+	groups, errs := dataloaders.CtxLoaders(ctx).Group.LoadAll(groupIDs)
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	results := make([]*service.SkillGroup, 0, len(groupIDs))
+	for i, groupID := range groupIDs {
+		groupMap[groupID].Group = groups[i]
+		results = append(results, groupMap[groupID])
+	}
+
+	// Groups that are unknown to the universe service sort last
+	sort.Slice(results, func(i, j int) bool {
+		a, b := results[i].Group, results[j].Group
+		if a == nil || b == nil {
+			if a != b {
+				return b == nil
+			}
+
+			return results[i].GroupID < results[j].GroupID
+		}
+
+		return a.Name < b.Name
+	})
+
+	return results, nil
+}
+
+func (r *queryResolver) MemberSkills(ctx context.Context, memberID uint) (*athena.MemberSkills, error) {
+	err := r.authorizeMember(ctx, memberID)
+	if err != nil {
+		return nil, err
+	}
+
+	properties, err := r.skill.MemberSkillProperties(ctx, memberID)
+	if err != nil || properties == nil {
+		return nil, err
+	}
+
+	properties.Skills, err = r.skill.MemberSkills(ctx, memberID)
+	if err != nil {
+		return nil, err
+	}
+
+	return properties, nil
+}
+
+func (r *queryResolver) MemberSkillQueue(ctx context.Context, memberID uint) ([]*athena.MemberSkillQueue, error) {
+	err := r.authorizeMember(ctx, memberID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.skill.MemberSkillQueue(ctx, memberID)
+}
+
+func (r *queryResolver) MemberAttributes(ctx context.Context, memberID uint) (*athena.MemberAttributes, error) {
+	err := r.authorizeMember(ctx, memberID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.skill.MemberAttributes(ctx, memberID)
+}
+
+func (r *skillResolver) Type(ctx context.Context, obj *athena.Skill) (*athena.Type, error) {
+	return dataloaders.CtxLoaders(ctx).Item.Load(obj.SkillID)
+}
+
+func (r *subscriptionResolver) MemberSkillsChanged(ctx context.Context, memberID uint) (<-chan *athena.MemberSkills, error) {
+	events, err := r.memberEvents(ctx, memberID, athena.MemberSkillsChanged)
+	if err != nil {
+		return nil, err
+	}
+
+	pipe := make(chan *athena.MemberSkills)
+	go func() {
+		defer close(pipe)
+
+		for range events {
+			result, err := r.Query().MemberSkills(ctx, memberID)
+			if err != nil {
+				newrelic.FromContext(ctx).NoticeError(err)
+				continue
+			}
+
+			select {
+			case pipe <- result:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return pipe, nil
+}
+
+func (r *subscriptionResolver) MemberSkillQueueChanged(ctx context.Context, memberID uint) (<-chan []*athena.MemberSkillQueue, error) {
+	events, err := r.memberEvents(ctx, memberID, athena.MemberSkillQueueChanged)
+	if err != nil {
+		return nil, err
+	}
+
+	pipe := make(chan []*athena.MemberSkillQueue)
+	go func() {
+		defer close(pipe)
+
+		for range events {
+			result, err := r.skill.MemberSkillQueue(ctx, memberID)
+			if err != nil {
+				newrelic.FromContext(ctx).NoticeError(err)
+				continue
+			}
+
+			select {
+			case pipe <- result:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return pipe, nil
+}
+
+func (r *subscriptionResolver) MemberAttributesChanged(ctx context.Context, memberID uint) (<-chan *athena.MemberAttributes, error) {
+	events, err := r.memberEvents(ctx, memberID, athena.MemberAttributesChanged)
+	if err != nil {
+		return nil, err
+	}
+
+	pipe := make(chan *athena.MemberAttributes)
+	go func() {
+		defer close(pipe)
+
+		for range events {
+			result, err := r.skill.MemberAttributes(ctx, memberID)
+			if err != nil {
+				newrelic.FromContext(ctx).NoticeError(err)
+				continue
+			}
+
+			select {
+			case pipe <- result:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return pipe, nil
+}
+
+// MemberSkillQueue returns service.MemberSkillQueueResolver implementation.
+func (r *resolver) MemberSkillQueue() service.MemberSkillQueueResolver {
+	return &memberSkillQueueResolver{r}
+}
+
+// MemberSkills returns service.MemberSkillsResolver implementation.
+func (r *resolver) MemberSkills() service.MemberSkillsResolver { return &memberSkillsResolver{r} }
+
+// Skill returns service.SkillResolver implementation.
+func (r *resolver) Skill() service.SkillResolver { return &skillResolver{r} }
+
+type memberSkillQueueResolver struct{ *resolver }
+type memberSkillsResolver struct{ *resolver }
+type skillResolver struct{ *resolver }
@@ -0,0 +1,52 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+
+	"github.com/eveisesi/athena"
+)
+
+func (r *queryResolver) Type(ctx context.Context, id uint) (*athena.Type, error) {
+	return r.universe.Type(ctx, id)
+}
+
+func (r *queryResolver) Group(ctx context.Context, id uint) (*athena.Group, error) {
+	return r.universe.Group(ctx, id)
+}
+
+func (r *queryResolver) Category(ctx context.Context, id uint) (*athena.Category, error) {
+	return r.universe.Category(ctx, id)
+}
+
+func (r *queryResolver) SolarSystem(ctx context.Context, id uint) (*athena.SolarSystem, error) {
+	return r.universe.SolarSystem(ctx, id)
+}
+
+func (r *queryResolver) Constellation(ctx context.Context, id uint) (*athena.Constellation, error) {
+	return r.universe.Constellation(ctx, id)
+}
+
+func (r *queryResolver) Region(ctx context.Context, id uint) (*athena.Region, error) {
+	return r.universe.Region(ctx, id)
+}
+
+func (r *queryResolver) SearchTypes(ctx context.Context, term string, limit *uint) ([]*athena.Type, error) {
+	var l uint
+	if limit != nil {
+		l = *limit
+	}
+
+	return r.universe.SearchTypes(ctx, term, l)
+}
+
+func (r *queryResolver) SearchSolarSystems(ctx context.Context, term string, limit *uint) ([]*athena.SolarSystem, error) {
+	var l uint
+	if limit != nil {
+		l = *limit
+	}
+
+	return r.universe.SearchSolarSystems(ctx, term, l)
+}
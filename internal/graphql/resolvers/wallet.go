@@ -0,0 +1,25 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/eveisesi/athena/internal/graphql/dataloaders"
+	"github.com/eveisesi/athena/internal/graphql/service"
+)
+
+// walletParty resolves a party on a journal entry or transaction to the entity that it references.
+// Parties that are not a character, corporation, alliance or faction resolve to nil
+func walletParty(ctx context.Context, id uint, partyType string) (service.WalletParty, error) {
+	switch partyType {
+	case "character":
+		return dataloaders.CtxLoaders(ctx).Character.Load(id)
+	case "corporation":
+		return dataloaders.CtxLoaders(ctx).Corporation.Load(id)
+	case "alliance":
+		return dataloaders.CtxLoaders(ctx).Alliance.Load(id)
+	case "faction":
+		return dataloaders.CtxLoaders(ctx).Faction.Load(id)
+	default:
+		return nil, nil
+	}
+}
@@ -0,0 +1,278 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/eveisesi/athena"
+	"github.com/eveisesi/athena/internal/graphql/dataloaders"
+	"github.com/eveisesi/athena/internal/graphql/service"
+	"github.com/volatiletech/null"
+)
+
+func (r *memberWalletJournalResolver) RefType(ctx context.Context, obj *athena.MemberWalletJournal) (string, error) {
+	return obj.RefType.String(), nil
+}
+
+func (r *memberWalletJournalResolver) ContextType(ctx context.Context, obj *athena.MemberWalletJournal) (*string, error) {
+	if !obj.ContextType.Valid {
+		return nil, nil
+	}
+
+	contextType := obj.ContextType.ContextIDType.String()
+	return &contextType, nil
+}
+
+func (r *memberWalletJournalResolver) FirstParty(ctx context.Context, obj *athena.MemberWalletJournal) (service.WalletParty, error) {
+	if !obj.FirstPartyID.Valid || !obj.FirstPartyType.Valid {
+		return nil, nil
+	}
+
+	return walletParty(ctx, obj.FirstPartyID.Uint, obj.FirstPartyType.String)
+}
+
+func (r *memberWalletJournalResolver) SecondParty(ctx context.Context, obj *athena.MemberWalletJournal) (service.WalletParty, error) {
+	if !obj.SecondPartyID.Valid || !obj.SecondPartyType.Valid {
+		return nil, nil
+	}
+
+	return walletParty(ctx, obj.SecondPartyID.Uint, obj.SecondPartyType.String)
+}
+
+func (r *memberWalletJournalResolver) TaxReceiver(ctx context.Context, obj *athena.MemberWalletJournal) (*athena.Corporation, error) {
+	if !obj.TaxReceiverID.Valid {
+		return nil, nil
+	}
+
+	return dataloaders.CtxLoaders(ctx).Corporation.Load(obj.TaxReceiverID.Uint)
+}
+
+func (r *memberWalletTransactionResolver) ClientType(ctx context.Context, obj *athena.MemberWalletTransaction) (string, error) {
+	return obj.ClientType.String(), nil
+}
+
+func (r *memberWalletTransactionResolver) LocationType(ctx context.Context, obj *athena.MemberWalletTransaction) (string, error) {
+	return obj.LocationType.String(), nil
+}
+
+func (r *memberWalletTransactionResolver) Client(ctx context.Context, obj *athena.MemberWalletTransaction) (service.WalletParty, error) {
+	return walletParty(ctx, obj.ClientID, obj.ClientType.String())
+}
+
+func (r *memberWalletTransactionResolver) Type(ctx context.Context, obj *athena.MemberWalletTransaction) (*athena.Type, error) {
+	return dataloaders.CtxLoaders(ctx).Item.Load(obj.TypeID)
+}
+
+func (r *memberWalletTransactionResolver) Location(ctx context.Context, obj *athena.MemberWalletTransaction) (service.CloneLocationInfo, error) {
+	switch obj.LocationType {
+	case athena.LocationTypeStation:
+		return dataloaders.CtxLoaders(ctx).Station.Load(uint(obj.LocationID))
+	case athena.LocationTypeStructure:
+		return dataloaders.CtxLoaders(ctx).Structure.Load(obj.LocationID)
+	default:
+		return nil, nil
+	}
+}
+
+func (r *queryResolver) MemberWalletBalance(ctx context.Context, memberID uint) (*athena.MemberWalletBalance, error) {
+	err := r.authorizeMember(ctx, memberID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.wallet.MemberBalance(ctx, memberID)
+}
+
+func (r *queryResolver) MemberWalletJournal(ctx context.Context, memberID uint, first *uint, after *string, filter *service.MemberWalletJournalFilter) (*service.MemberWalletJournalConnection, error) {
+	err := r.authorizeMember(ctx, memberID)
+	if err != nil {
+		return nil, err
+	}
+
+	operators := make([]*athena.Operator, 0)
+	if filter != nil {
+		if filter.From != nil {
+			operators = append(operators, athena.NewGreaterThanEqualToOperator("date", *filter.From))
+		}
+		if filter.To != nil {
+			operators = append(operators, athena.NewLessThanEqualToOperator("date", *filter.To))
+		}
+		if len(filter.RefTypes) > 0 {
+			refTypes := make([]athena.RefType, 0, len(filter.RefTypes))
+			for _, refType := range filter.RefTypes {
+				if !athena.RefType(refType).Valid() {
+					return nil, fmt.Errorf("%s is not a valid ref type", refType)
+				}
+				refTypes = append(refTypes, athena.RefType(refType))
+			}
+			operators = append(operators, athena.NewInOperator("ref_type", refTypes))
+		}
+		if filter.PartyID != nil {
+			operators = append(operators, athena.NewOrOperator(
+				athena.NewEqualOperator("first_party_id", *filter.PartyID),
+				athena.NewEqualOperator("second_party_id", *filter.PartyID),
+			))
+		}
+		if filter.MinAmount != nil {
+			operators = append(operators, athena.NewGreaterThanEqualToOperator("amount", *filter.MinAmount))
+		}
+		if filter.MaxAmount != nil {
+			operators = append(operators, athena.NewLessThanEqualToOperator("amount", *filter.MaxAmount))
+		}
+		if filter.MinAbsoluteAmount != nil {
+			operators = append(operators, athena.NewOrOperator(
+				athena.NewGreaterThanEqualToOperator("amount", *filter.MinAbsoluteAmount),
+				athena.NewLessThanEqualToOperator("amount", -*filter.MinAbsoluteAmount),
+			))
+		}
+	}
+
+	page, err := athena.NewPage(first, after, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := r.wallet.MemberWalletJournals(ctx, memberID, page, operators...)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := r.wallet.MemberWalletJournalCount(ctx, memberID, operators...)
+	if err != nil {
+		return nil, err
+	}
+
+	n, info := newPageInfo(page, len(entries))
+	edges := make([]*service.MemberWalletJournalEdge, 0, n)
+	cursors := make([]string, 0, n)
+	for _, entry := range entries[:n] {
+		cursor := athena.NewCursor(entry.JournalID).String()
+		edges = append(edges, &service.MemberWalletJournalEdge{Cursor: cursor, Node: entry})
+		cursors = append(cursors, cursor)
+	}
+	setPageCursors(info, cursors...)
+
+	return &service.MemberWalletJournalConnection{Edges: edges, PageInfo: info, TotalCount: count}, nil
+}
+
+func (r *queryResolver) MemberWalletTransactions(ctx context.Context, memberID uint, first *uint, after *string, filter *service.MemberWalletTransactionFilter) (*service.MemberWalletTransactionConnection, error) {
+	err := r.authorizeMember(ctx, memberID)
+	if err != nil {
+		return nil, err
+	}
+
+	operators := make([]*athena.Operator, 0)
+	if filter != nil {
+		if filter.From != nil {
+			operators = append(operators, athena.NewGreaterThanEqualToOperator("date", *filter.From))
+		}
+		if filter.To != nil {
+			operators = append(operators, athena.NewLessThanEqualToOperator("date", *filter.To))
+		}
+		if filter.TypeID != nil {
+			operators = append(operators, athena.NewEqualOperator("type_id", *filter.TypeID))
+		}
+		if filter.IsBuy != nil {
+			operators = append(operators, athena.NewEqualOperator("is_buy", *filter.IsBuy))
+		}
+		if filter.LocationID != nil {
+			operators = append(operators, athena.NewEqualOperator("location_id", *filter.LocationID))
+		}
+	}
+
+	page, err := athena.NewPage(first, after, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions, err := r.wallet.MemberWalletTransactions(ctx, memberID, page, operators...)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := r.wallet.MemberWalletTransactionCount(ctx, memberID, operators...)
+	if err != nil {
+		return nil, err
+	}
+
+	n, info := newPageInfo(page, len(transactions))
+	edges := make([]*service.MemberWalletTransactionEdge, 0, n)
+	cursors := make([]string, 0, n)
+	for _, transaction := range transactions[:n] {
+		cursor := athena.NewCursor(transaction.TransactionID).String()
+		edges = append(edges, &service.MemberWalletTransactionEdge{Cursor: cursor, Node: transaction})
+		cursors = append(cursors, cursor)
+	}
+	setPageCursors(info, cursors...)
+
+	return &service.MemberWalletTransactionConnection{Edges: edges, PageInfo: info, TotalCount: count}, nil
+}
+
+func (r *queryResolver) MemberWalletJournalAnalysis(ctx context.Context, memberID uint, from *time.Time, to *time.Time) (*athena.WalletJournalAnalysis, error) {
+	err := r.authorizeMember(ctx, memberID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.analysis.WalletJournalAnalysis(ctx, memberID, null.TimeFromPtr(from), null.TimeFromPtr(to))
+}
+
+func (r *walletJournalAnomalyResolver) Kind(ctx context.Context, obj *athena.WalletJournalAnomaly) (service.WalletJournalAnomalyKind, error) {
+	return service.WalletJournalAnomalyKind(strings.ToUpper(obj.Kind.String())), nil
+}
+
+func (r *walletJournalAnomalyResolver) RefType(ctx context.Context, obj *athena.WalletJournalAnomaly) (string, error) {
+	return obj.RefType.String(), nil
+}
+
+func (r *walletJournalAnomalyResolver) Counterparty(ctx context.Context, obj *athena.WalletJournalAnomaly) (service.WalletParty, error) {
+	if !obj.CounterpartyID.Valid || !obj.CounterpartyType.Valid {
+		return nil, nil
+	}
+
+	return walletParty(ctx, obj.CounterpartyID.Uint, obj.CounterpartyType.String)
+}
+
+func (r *walletJournalCategorySummaryResolver) Category(ctx context.Context, obj *athena.WalletJournalCategorySummary) (service.WalletJournalCategory, error) {
+	return service.WalletJournalCategory(strings.ToUpper(obj.Category.String())), nil
+}
+
+func (r *walletJournalCounterpartyResolver) Party(ctx context.Context, obj *athena.WalletJournalCounterparty) (service.WalletParty, error) {
+	return walletParty(ctx, obj.PartyID, obj.PartyType)
+}
+
+// MemberWalletJournal returns service.MemberWalletJournalResolver implementation.
+func (r *resolver) MemberWalletJournal() service.MemberWalletJournalResolver {
+	return &memberWalletJournalResolver{r}
+}
+
+// MemberWalletTransaction returns service.MemberWalletTransactionResolver implementation.
+func (r *resolver) MemberWalletTransaction() service.MemberWalletTransactionResolver {
+	return &memberWalletTransactionResolver{r}
+}
+
+// WalletJournalAnomaly returns service.WalletJournalAnomalyResolver implementation.
+func (r *resolver) WalletJournalAnomaly() service.WalletJournalAnomalyResolver {
+	return &walletJournalAnomalyResolver{r}
+}
+
+// WalletJournalCategorySummary returns service.WalletJournalCategorySummaryResolver implementation.
+func (r *resolver) WalletJournalCategorySummary() service.WalletJournalCategorySummaryResolver {
+	return &walletJournalCategorySummaryResolver{r}
+}
+
+// WalletJournalCounterparty returns service.WalletJournalCounterpartyResolver implementation.
+func (r *resolver) WalletJournalCounterparty() service.WalletJournalCounterpartyResolver {
+	return &walletJournalCounterpartyResolver{r}
+}
+
+type memberWalletJournalResolver struct{ *resolver }
+type memberWalletTransactionResolver struct{ *resolver }
+type walletJournalAnomalyResolver struct{ *resolver }
+type walletJournalCategorySummaryResolver struct{ *resolver }
+type walletJournalCounterpartyResolver struct{ *resolver }
@@ -0,0 +1,37 @@
+package null
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/volatiletech/null"
+)
+
+func MarshalInt(ni null.Int) graphql.Marshaler {
+	if !ni.Valid {
+		return graphql.Null
+	}
+
+	return graphql.WriterFunc(func(w io.Writer) {
+		_, _ = io.WriteString(w, strconv.Itoa(ni.Int))
+	})
+}
+
+func UnmarshalInt(i interface{}) (null.Int, error) {
+
+	if i == nil {
+		return null.Int{}, nil
+	}
+
+	switch i := i.(type) {
+	case int:
+		return null.NewInt(i, true), nil
+	case int64:
+		return null.NewInt(int(i), true), nil
+	default:
+		return null.Int{}, fmt.Errorf("%v is not a valid int", i)
+	}
+
+}
@@ -0,0 +1,23 @@
+package service
+
+import "time"
+
+// The inputs below compare against ISK amounts, which do not fit in the float32 that gqlgen generates
+// for Float inputs by default, so they are bound to these models instead
+
+type FloatFilter struct {
+	Gt  *float64 `json:"gt"`
+	Gte *float64 `json:"gte"`
+	Lt  *float64 `json:"lt"`
+	Lte *float64 `json:"lte"`
+}
+
+type MemberWalletJournalFilter struct {
+	From              *time.Time `json:"from"`
+	To                *time.Time `json:"to"`
+	RefTypes          []string   `json:"refTypes"`
+	PartyID           *uint      `json:"partyID"`
+	MinAmount         *float64   `json:"minAmount"`
+	MaxAmount         *float64   `json:"maxAmount"`
+	MinAbsoluteAmount *float64   `json:"minAbsoluteAmount"`
+}
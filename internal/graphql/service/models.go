@@ -2,6 +2,15 @@
 
 package service
 
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/eveisesi/athena"
+)
+
 type CloneLocationInfo interface {
 	IsCloneLocationInfo()
 }
@@ -9,3 +18,575 @@ type CloneLocationInfo interface {
 type ContactInfo interface {
 	IsContactInfo()
 }
+
+type MailParty interface {
+	IsMailParty()
+}
+
+type WalletParty interface {
+	IsWalletParty()
+}
+
+type CreateAPIKeyInput struct {
+	Name           string   `json:"name"`
+	AllowedQueries []string `json:"allowedQueries"`
+	AllowedMembers []uint   `json:"allowedMembers"`
+	RateLimit      *uint    `json:"rateLimit"`
+}
+
+type CreatedAPIKey struct {
+	Key    string         `json:"key"`
+	APIKey *athena.APIKey `json:"apiKey"`
+}
+
+type FittingSlotGroup struct {
+	Slot  string                      `json:"slot"`
+	Items []*athena.MemberFittingItem `json:"items"`
+}
+
+type IntFilter struct {
+	Eq    *int `json:"eq"`
+	NotEq *int `json:"notEq"`
+	Gt    *int `json:"gt"`
+	Gte   *int `json:"gte"`
+	Lt    *int `json:"lt"`
+	Lte   *int `json:"lte"`
+}
+
+type MemberAssetConnection struct {
+	Edges      []*MemberAssetEdge `json:"edges"`
+	PageInfo   *PageInfo          `json:"pageInfo"`
+	TotalCount uint               `json:"totalCount"`
+}
+
+type MemberAssetEdge struct {
+	Cursor string              `json:"cursor"`
+	Node   *athena.MemberAsset `json:"node"`
+}
+
+type MemberAssetFilter struct {
+	TypeID          *UintFilter   `json:"typeID"`
+	LocationID      *Uint64Filter `json:"locationID"`
+	LocationFlag    *StringFilter `json:"locationFlag"`
+	LocationType    *StringFilter `json:"locationType"`
+	Quantity        *IntFilter    `json:"quantity"`
+	IsBlueprintCopy *bool         `json:"isBlueprintCopy"`
+	IsSingleton     *bool         `json:"isSingleton"`
+}
+
+type MemberAssetSort struct {
+	Field     MemberAssetSortField `json:"field"`
+	Direction SortDirection        `json:"direction"`
+}
+
+type MemberContactConnection struct {
+	Edges      []*MemberContactEdge `json:"edges"`
+	PageInfo   *PageInfo            `json:"pageInfo"`
+	TotalCount uint                 `json:"totalCount"`
+}
+
+type MemberContactEdge struct {
+	Cursor string                `json:"cursor"`
+	Node   *athena.MemberContact `json:"node"`
+}
+
+type MemberContactFilter struct {
+	ContactID   *UintFilter   `json:"contactID"`
+	ContactType *StringFilter `json:"contactType"`
+	Standing    *FloatFilter  `json:"standing"`
+	IsBlocked   *bool         `json:"isBlocked"`
+	IsWatched   *bool         `json:"isWatched"`
+}
+
+type MemberContactSort struct {
+	Field     MemberContactSortField `json:"field"`
+	Direction SortDirection          `json:"direction"`
+}
+
+type MemberContractConnection struct {
+	Edges      []*MemberContractEdge `json:"edges"`
+	PageInfo   *PageInfo             `json:"pageInfo"`
+	TotalCount uint                  `json:"totalCount"`
+}
+
+type MemberContractEdge struct {
+	Cursor string                 `json:"cursor"`
+	Node   *athena.MemberContract `json:"node"`
+}
+
+type MemberContractFilter struct {
+	Type                *StringFilter `json:"type"`
+	Status              *StringFilter `json:"status"`
+	Availability        *StringFilter `json:"availability"`
+	Title               *StringFilter `json:"title"`
+	IssuerID            *UintFilter   `json:"issuerID"`
+	IssuerCorporationID *UintFilter   `json:"issuerCorporationID"`
+	AssigneeID          *UintFilter   `json:"assigneeID"`
+	AcceptorID          *UintFilter   `json:"acceptorID"`
+	ForCorporation      *bool         `json:"forCorporation"`
+	StartLocationID     *Uint64Filter `json:"startLocationID"`
+	EndLocationID       *Uint64Filter `json:"endLocationID"`
+	Price               *FloatFilter  `json:"price"`
+	Reward              *FloatFilter  `json:"reward"`
+	Collateral          *FloatFilter  `json:"collateral"`
+	Volume              *FloatFilter  `json:"volume"`
+	DateIssued          *TimeFilter   `json:"dateIssued"`
+	DateExpired         *TimeFilter   `json:"dateExpired"`
+	DateAccepted        *TimeFilter   `json:"dateAccepted"`
+	DateCompleted       *TimeFilter   `json:"dateCompleted"`
+}
+
+type MemberContractSort struct {
+	Field     MemberContractSortField `json:"field"`
+	Direction SortDirection           `json:"direction"`
+}
+
+type MemberMailHeaderConnection struct {
+	Edges      []*MemberMailHeaderEdge `json:"edges"`
+	PageInfo   *PageInfo               `json:"pageInfo"`
+	TotalCount uint                    `json:"totalCount"`
+}
+
+type MemberMailHeaderEdge struct {
+	Cursor string                   `json:"cursor"`
+	Node   *athena.MemberMailHeader `json:"node"`
+}
+
+type MemberMailHeaderFilter struct {
+	LabelID       *uint      `json:"labelID"`
+	SenderID      *uint      `json:"senderID"`
+	RecipientType *string    `json:"recipientType"`
+	From          *time.Time `json:"from"`
+	To            *time.Time `json:"to"`
+}
+
+type MemberReport struct {
+	MemberID uint         `json:"memberID"`
+	Format   ReportFormat `json:"format"`
+	Content  string       `json:"content"`
+}
+
+type MemberWalletJournalConnection struct {
+	Edges      []*MemberWalletJournalEdge `json:"edges"`
+	PageInfo   *PageInfo                  `json:"pageInfo"`
+	TotalCount uint                       `json:"totalCount"`
+}
+
+type MemberWalletJournalEdge struct {
+	Cursor string                      `json:"cursor"`
+	Node   *athena.MemberWalletJournal `json:"node"`
+}
+
+type MemberWalletTransactionConnection struct {
+	Edges      []*MemberWalletTransactionEdge `json:"edges"`
+	PageInfo   *PageInfo                      `json:"pageInfo"`
+	TotalCount uint                           `json:"totalCount"`
+}
+
+type MemberWalletTransactionEdge struct {
+	Cursor string                          `json:"cursor"`
+	Node   *athena.MemberWalletTransaction `json:"node"`
+}
+
+type MemberWalletTransactionFilter struct {
+	From       *time.Time `json:"from"`
+	To         *time.Time `json:"to"`
+	TypeID     *uint      `json:"typeID"`
+	IsBuy      *bool      `json:"isBuy"`
+	LocationID *uint64    `json:"locationID"`
+}
+
+type PageInfo struct {
+	HasNextPage     bool    `json:"hasNextPage"`
+	HasPreviousPage bool    `json:"hasPreviousPage"`
+	StartCursor     *string `json:"startCursor"`
+	EndCursor       *string `json:"endCursor"`
+}
+
+type SkillGroup struct {
+	GroupID     uint            `json:"groupID"`
+	Group       *athena.Group   `json:"group"`
+	Skillpoints uint            `json:"skillpoints"`
+	Skills      []*athena.Skill `json:"skills"`
+}
+
+type StringFilter struct {
+	Eq    *string  `json:"eq"`
+	NotEq *string  `json:"notEq"`
+	In    []string `json:"in"`
+	NotIn []string `json:"notIn"`
+	Like  *string  `json:"like"`
+}
+
+type TimeFilter struct {
+	Gt  *time.Time `json:"gt"`
+	Gte *time.Time `json:"gte"`
+	Lt  *time.Time `json:"lt"`
+	Lte *time.Time `json:"lte"`
+}
+
+type Uint64Filter struct {
+	Eq    *uint64  `json:"eq"`
+	NotEq *uint64  `json:"notEq"`
+	In    []uint64 `json:"in"`
+	NotIn []uint64 `json:"notIn"`
+	Gt    *uint64  `json:"gt"`
+	Gte   *uint64  `json:"gte"`
+	Lt    *uint64  `json:"lt"`
+	Lte   *uint64  `json:"lte"`
+}
+
+type UintFilter struct {
+	Eq    *uint  `json:"eq"`
+	NotEq *uint  `json:"notEq"`
+	In    []uint `json:"in"`
+	NotIn []uint `json:"notIn"`
+	Gt    *uint  `json:"gt"`
+	Gte   *uint  `json:"gte"`
+	Lt    *uint  `json:"lt"`
+	Lte   *uint  `json:"lte"`
+}
+
+type MemberAssetSortField string
+
+const (
+	MemberAssetSortFieldTypeID     MemberAssetSortField = "TYPE_ID"
+	MemberAssetSortFieldLocationID MemberAssetSortField = "LOCATION_ID"
+	MemberAssetSortFieldQuantity   MemberAssetSortField = "QUANTITY"
+)
+
+var AllMemberAssetSortField = []MemberAssetSortField{
+	MemberAssetSortFieldTypeID,
+	MemberAssetSortFieldLocationID,
+	MemberAssetSortFieldQuantity,
+}
+
+func (e MemberAssetSortField) IsValid() bool {
+	switch e {
+	case MemberAssetSortFieldTypeID, MemberAssetSortFieldLocationID, MemberAssetSortFieldQuantity:
+		return true
+	}
+	return false
+}
+
+func (e MemberAssetSortField) String() string {
+	return string(e)
+}
+
+func (e *MemberAssetSortField) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = MemberAssetSortField(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid MemberAssetSortField", str)
+	}
+	return nil
+}
+
+func (e MemberAssetSortField) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type MemberContactSortField string
+
+const (
+	MemberContactSortFieldContactType MemberContactSortField = "CONTACT_TYPE"
+	MemberContactSortFieldStanding    MemberContactSortField = "STANDING"
+)
+
+var AllMemberContactSortField = []MemberContactSortField{
+	MemberContactSortFieldContactType,
+	MemberContactSortFieldStanding,
+}
+
+func (e MemberContactSortField) IsValid() bool {
+	switch e {
+	case MemberContactSortFieldContactType, MemberContactSortFieldStanding:
+		return true
+	}
+	return false
+}
+
+func (e MemberContactSortField) String() string {
+	return string(e)
+}
+
+func (e *MemberContactSortField) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = MemberContactSortField(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid MemberContactSortField", str)
+	}
+	return nil
+}
+
+func (e MemberContactSortField) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type MemberContractSortField string
+
+const (
+	MemberContractSortFieldDateIssued  MemberContractSortField = "DATE_ISSUED"
+	MemberContractSortFieldDateExpired MemberContractSortField = "DATE_EXPIRED"
+)
+
+var AllMemberContractSortField = []MemberContractSortField{
+	MemberContractSortFieldDateIssued,
+	MemberContractSortFieldDateExpired,
+}
+
+func (e MemberContractSortField) IsValid() bool {
+	switch e {
+	case MemberContractSortFieldDateIssued, MemberContractSortFieldDateExpired:
+		return true
+	}
+	return false
+}
+
+func (e MemberContractSortField) String() string {
+	return string(e)
+}
+
+func (e *MemberContractSortField) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = MemberContractSortField(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid MemberContractSortField", str)
+	}
+	return nil
+}
+
+func (e MemberContractSortField) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type RedFlagSeverity string
+
+const (
+	RedFlagSeverityLow      RedFlagSeverity = "LOW"
+	RedFlagSeverityMedium   RedFlagSeverity = "MEDIUM"
+	RedFlagSeverityHigh     RedFlagSeverity = "HIGH"
+	RedFlagSeverityCritical RedFlagSeverity = "CRITICAL"
+)
+
+var AllRedFlagSeverity = []RedFlagSeverity{
+	RedFlagSeverityLow,
+	RedFlagSeverityMedium,
+	RedFlagSeverityHigh,
+	RedFlagSeverityCritical,
+}
+
+func (e RedFlagSeverity) IsValid() bool {
+	switch e {
+	case RedFlagSeverityLow, RedFlagSeverityMedium, RedFlagSeverityHigh, RedFlagSeverityCritical:
+		return true
+	}
+	return false
+}
+
+func (e RedFlagSeverity) String() string {
+	return string(e)
+}
+
+func (e *RedFlagSeverity) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = RedFlagSeverity(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid RedFlagSeverity", str)
+	}
+	return nil
+}
+
+func (e RedFlagSeverity) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type ReportFormat string
+
+const (
+	ReportFormatJSON     ReportFormat = "JSON"
+	ReportFormatMarkdown ReportFormat = "MARKDOWN"
+	ReportFormatHTML     ReportFormat = "HTML"
+)
+
+var AllReportFormat = []ReportFormat{
+	ReportFormatJSON,
+	ReportFormatMarkdown,
+	ReportFormatHTML,
+}
+
+func (e ReportFormat) IsValid() bool {
+	switch e {
+	case ReportFormatJSON, ReportFormatMarkdown, ReportFormatHTML:
+		return true
+	}
+	return false
+}
+
+func (e ReportFormat) String() string {
+	return string(e)
+}
+
+func (e *ReportFormat) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ReportFormat(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ReportFormat", str)
+	}
+	return nil
+}
+
+func (e ReportFormat) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type SortDirection string
+
+const (
+	SortDirectionAsc  SortDirection = "ASC"
+	SortDirectionDesc SortDirection = "DESC"
+)
+
+var AllSortDirection = []SortDirection{
+	SortDirectionAsc,
+	SortDirectionDesc,
+}
+
+func (e SortDirection) IsValid() bool {
+	switch e {
+	case SortDirectionAsc, SortDirectionDesc:
+		return true
+	}
+	return false
+}
+
+func (e SortDirection) String() string {
+	return string(e)
+}
+
+func (e *SortDirection) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SortDirection(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SortDirection", str)
+	}
+	return nil
+}
+
+func (e SortDirection) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type WalletJournalAnomalyKind string
+
+const (
+	WalletJournalAnomalyKindLargeDonation          WalletJournalAnomalyKind = "LARGE_DONATION"
+	WalletJournalAnomalyKindPreApplicationTransfer WalletJournalAnomalyKind = "PRE_APPLICATION_TRANSFER"
+)
+
+var AllWalletJournalAnomalyKind = []WalletJournalAnomalyKind{
+	WalletJournalAnomalyKindLargeDonation,
+	WalletJournalAnomalyKindPreApplicationTransfer,
+}
+
+func (e WalletJournalAnomalyKind) IsValid() bool {
+	switch e {
+	case WalletJournalAnomalyKindLargeDonation, WalletJournalAnomalyKindPreApplicationTransfer:
+		return true
+	}
+	return false
+}
+
+func (e WalletJournalAnomalyKind) String() string {
+	return string(e)
+}
+
+func (e *WalletJournalAnomalyKind) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = WalletJournalAnomalyKind(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid WalletJournalAnomalyKind", str)
+	}
+	return nil
+}
+
+func (e WalletJournalAnomalyKind) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type WalletJournalCategory string
+
+const (
+	WalletJournalCategoryBounties  WalletJournalCategory = "BOUNTIES"
+	WalletJournalCategoryMarket    WalletJournalCategory = "MARKET"
+	WalletJournalCategoryIndustry  WalletJournalCategory = "INDUSTRY"
+	WalletJournalCategoryDonations WalletJournalCategory = "DONATIONS"
+	WalletJournalCategoryContracts WalletJournalCategory = "CONTRACTS"
+	WalletJournalCategoryOther     WalletJournalCategory = "OTHER"
+)
+
+var AllWalletJournalCategory = []WalletJournalCategory{
+	WalletJournalCategoryBounties,
+	WalletJournalCategoryMarket,
+	WalletJournalCategoryIndustry,
+	WalletJournalCategoryDonations,
+	WalletJournalCategoryContracts,
+	WalletJournalCategoryOther,
+}
+
+func (e WalletJournalCategory) IsValid() bool {
+	switch e {
+	case WalletJournalCategoryBounties, WalletJournalCategoryMarket, WalletJournalCategoryIndustry, WalletJournalCategoryDonations, WalletJournalCategoryContracts, WalletJournalCategoryOther:
+		return true
+	}
+	return false
+}
+
+func (e WalletJournalCategory) String() string {
+	return string(e)
+}
+
+func (e *WalletJournalCategory) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = WalletJournalCategory(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid WalletJournalCategory", str)
+	}
+	return nil
+}
+
+func (e WalletJournalCategory) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
@@ -79,7 +79,7 @@ func (s *service) EmptyMemberLocation(ctx context.Context, member *athena.Member
 	location, etag, _, err := s.esi.GetCharacterLocation(ctx, member.ID, member.AccessToken.String)
 	if err != nil {
 		entry.WithError(err).Error("failed to fetch member location from ESI")
-		return nil, fmt.Errorf("failed to fetch member location from ESI")
+		return nil, fmt.Errorf("failed to fetch member location from ESI: %w", err)
 	}
 
 	if petag != "" && etag.Etag == petag {
@@ -114,6 +114,16 @@ func (s *service) EmptyMemberLocation(ctx context.Context, member *athena.Member
 		entry.WithError(err).Error("failed to cache member location")
 	}
 
+	if existing == nil ||
+		existing.SolarSystemID != location.SolarSystemID ||
+		existing.StationID != location.StationID ||
+		existing.StructureID != location.StructureID {
+		err = s.cache.PublishMemberEvent(ctx, athena.NewMemberEvent(member.ID, athena.MemberLocationChanged))
+		if err != nil {
+			entry.WithError(err).Error("failed to publish member location changed event")
+		}
+	}
+
 	return etag, nil
 
 }
@@ -219,7 +229,7 @@ func (s *service) EmptyMemberShip(ctx context.Context, member *athena.Member) (*
 	ship, etag, _, err := s.esi.GetCharacterShip(ctx, member.ID, member.AccessToken.String)
 	if err != nil {
 		entry.WithError(err).Error("failed to fetch member ship from ESI")
-		return nil, fmt.Errorf("failed to fetch member ship from ESI")
+		return nil, fmt.Errorf("failed to fetch member ship from ESI: %w", err)
 	}
 
 	if petag != "" && etag.Etag == petag {
@@ -228,7 +238,7 @@ func (s *service) EmptyMemberShip(ctx context.Context, member *athena.Member) (*
 
 	s.resolveShipAttributes(ctx, member, ship)
 
-	existing, err := s.location.MemberOnline(ctx, member.ID)
+	existing, err := s.location.MemberShip(ctx, member.ID)
 	if err != nil && !errors.Is(err, sql.ErrNoRows) {
 		entry.WithError(err).Error("failed to fetch member ship from DB")
 		return nil, fmt.Errorf("failed to fetch member ship from DB")
@@ -254,6 +264,16 @@ func (s *service) EmptyMemberShip(ctx context.Context, member *athena.Member) (*
 		entry.WithError(err).Error("failed to cache member ship")
 	}
 
+	if existing == nil ||
+		existing.ShipItemID != ship.ShipItemID ||
+		existing.ShipTypeID != ship.ShipTypeID ||
+		existing.ShipName != ship.ShipName {
+		err = s.cache.PublishMemberEvent(ctx, athena.NewMemberEvent(member.ID, athena.MemberShipChanged))
+		if err != nil {
+			entry.WithError(err).Error("failed to publish member ship changed event")
+		}
+	}
+
 	return etag, nil
 
 }
@@ -334,7 +354,7 @@ func (s *service) EmptyMemberOnline(ctx context.Context, member *athena.Member)
 	online, etag, _, err := s.esi.GetCharacterOnline(ctx, member.ID, member.AccessToken.String)
 	if err != nil {
 		entry.WithError(err).Error("failed to fetch member online from ESI")
-		return nil, fmt.Errorf("failed to fetch member online from ESI")
+		return nil, fmt.Errorf("failed to fetch member online from ESI: %w", err)
 	}
 
 	if petag != "" && etag.Etag == petag {
@@ -367,6 +387,13 @@ func (s *service) EmptyMemberOnline(ctx context.Context, member *athena.Member)
 		entry.WithError(err).Error("failed to cache member online")
 	}
 
+	if existing == nil || existing.Online != online.Online {
+		err = s.cache.PublishMemberEvent(ctx, athena.NewMemberEvent(member.ID, athena.MemberOnlineChanged))
+		if err != nil {
+			entry.WithError(err).Error("failed to publish member online changed event")
+		}
+	}
+
 	return etag, nil
 
 }
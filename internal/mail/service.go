@@ -22,6 +22,17 @@ import (
 type Service interface {
 	EmptyMemberMailHeaders(ctx context.Context, member *athena.Member) (*athena.Etag, error)
 	EmptyMemberMailingLists(ctx context.Context, member *athena.Member) (*athena.Etag, error)
+	MemberMailHeaders(ctx context.Context, memberID uint, page *athena.Page, filter *athena.MemberMailHeaderFilter) ([]*athena.MemberMailHeader, error)
+	MemberMailHeaderCount(ctx context.Context, memberID uint, filter *athena.MemberMailHeaderFilter) (uint, error)
+	MemberMailHeader(ctx context.Context, memberID, mailID uint) (*athena.MemberMailHeader, error)
+	MailHeader(ctx context.Context, mailID uint) (*athena.MailHeader, error)
+	MailHeadersByIDs(ctx context.Context, mailIDs []uint) ([]*athena.MailHeader, error)
+	MailRecipients(ctx context.Context, mailID uint) ([]*athena.MailRecipient, error)
+	MailRecipientsByMailIDs(ctx context.Context, mailIDs []uint) ([]*athena.MailRecipient, error)
+	MemberMailLabels(ctx context.Context, memberID uint) (*athena.MemberMailLabels, error)
+	MemberMailingLists(ctx context.Context, memberID uint) ([]*athena.MailingList, error)
+	MailingList(ctx context.Context, mailingListID uint) (*athena.MailingList, error)
+	MailingListsByIDs(ctx context.Context, mailingListIDs []uint) ([]*athena.MailingList, error)
 	// EmptyMemberMailLabels(ctx context.Context, member *athena.Member) (*athena.Etag, error)
 }
 
@@ -424,6 +435,24 @@ func (s *service) MailingList(ctx context.Context, mailingListID uint) (*athena.
 
 }
 
+// MailingListsByIDs fetches a batch of mailing lists straight from the DB for the dataloaders
+func (s *service) MailingListsByIDs(ctx context.Context, mailingListIDs []uint) ([]*athena.MailingList, error) {
+
+	entry := s.logger.WithContext(ctx).WithFields(logrus.Fields{
+		"service": serviceIdentifier,
+		"method":  "MailingListsByIDs",
+	})
+
+	lists, err := s.mail.MailingLists(ctx, athena.NewInOperator("mailing_list_id", mailingListIDs))
+	if err != nil {
+		entry.WithError(err).Error("failed to fetch mailing lists from DB")
+		return nil, fmt.Errorf("failed to fetch mailing lists from DB")
+	}
+
+	return lists, nil
+
+}
+
 func (s *service) FetchMemberMailingLists(ctx context.Context, member *athena.Member, etag *athena.Etag) (*athena.Etag, error) {
 
 	if etag != nil && etag.CachedUntil.After(time.Now()) {
@@ -439,7 +468,7 @@ func (s *service) FetchMemberMailingLists(ctx context.Context, member *athena.Me
 	lists, etag, _, err := s.esi.GetCharacterMailLists(ctx, member.ID, member.AccessToken.String)
 	if err != nil {
 		entry.WithError(err).Error("failed to fetch member mailing lists from ESI")
-		return nil, fmt.Errorf("failed to fetch member mailing lists from ESI")
+		return nil, fmt.Errorf("failed to fetch member mailing lists from ESI: %w", err)
 	}
 
 	if len(lists) > 0 {
@@ -530,3 +559,250 @@ func (s *service) processMailingLists(ctx context.Context, member *athena.Member
 	}
 
 }
+
+func (s *service) MemberMailHeaders(ctx context.Context, memberID uint, page *athena.Page, filter *athena.MemberMailHeaderFilter) ([]*athena.MemberMailHeader, error) {
+
+	entry := s.logger.WithContext(ctx).WithFields(logrus.Fields{
+		"member_id": memberID,
+		"service":   serviceIdentifier,
+		"method":    "MemberMailHeaders",
+	})
+
+	headers, err := s.mail.SearchMemberMailHeaders(ctx, memberID, filter, page.Operators("mmh.mail_id", athena.SortDesc)...)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		entry.WithError(err).Error("failed to fetch member mail headers from DB")
+		return nil, fmt.Errorf("failed to fetch member mail headers from DB")
+	}
+
+	return headers, nil
+
+}
+
+func (s *service) MemberMailHeaderCount(ctx context.Context, memberID uint, filter *athena.MemberMailHeaderFilter) (uint, error) {
+
+	count, err := s.mail.CountMemberMailHeaders(ctx, memberID, filter)
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithFields(logrus.Fields{
+			"member_id": memberID,
+			"service":   serviceIdentifier,
+			"method":    "MemberMailHeaderCount",
+		}).Error("failed to count member mail headers in DB")
+		return 0, fmt.Errorf("failed to count member mail headers in DB")
+	}
+
+	return count, nil
+
+}
+
+func (s *service) MemberMailHeader(ctx context.Context, memberID, mailID uint) (*athena.MemberMailHeader, error) {
+
+	entry := s.logger.WithContext(ctx).WithFields(logrus.Fields{
+		"member_id": memberID,
+		"mail_id":   mailID,
+		"service":   serviceIdentifier,
+		"method":    "MemberMailHeader",
+	})
+
+	headers, err := s.mail.MemberMailHeaders(ctx, athena.NewEqualOperator("member_id", memberID), athena.NewEqualOperator("mail_id", mailID))
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		entry.WithError(err).Error("failed to fetch member mail header from DB")
+		return nil, fmt.Errorf("failed to fetch member mail header from DB")
+	}
+
+	if len(headers) == 0 {
+		return nil, nil
+	}
+
+	return headers[0], nil
+
+}
+
+func (s *service) MailHeader(ctx context.Context, mailID uint) (*athena.MailHeader, error) {
+
+	entry := s.logger.WithContext(ctx).WithFields(logrus.Fields{
+		"mail_id": mailID,
+		"service": serviceIdentifier,
+		"method":  "MailHeader",
+	})
+
+	header, err := s.cache.MailHeader(ctx, mailID)
+	if err != nil {
+		entry.WithError(err).Error("failed to fetch mail header from cache")
+		return nil, fmt.Errorf("failed to fetch mail header from cache")
+	}
+
+	if header != nil {
+		return header, nil
+	}
+
+	header, err = s.mail.MailHeader(ctx, mailID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		entry.WithError(err).Error("failed to fetch mail header from DB")
+		return nil, fmt.Errorf("failed to fetch mail header from DB")
+	}
+
+	if header == nil {
+		return nil, nil
+	}
+
+	err = s.cache.SetMailHeader(ctx, header)
+	if err != nil {
+		entry.WithError(err).Error("failed to cache mail header")
+	}
+
+	return header, nil
+
+}
+
+// MailHeadersByIDs fetches a batch of mail headers straight from the DB for the dataloaders
+func (s *service) MailHeadersByIDs(ctx context.Context, mailIDs []uint) ([]*athena.MailHeader, error) {
+
+	entry := s.logger.WithContext(ctx).WithFields(logrus.Fields{
+		"service": serviceIdentifier,
+		"method":  "MailHeadersByIDs",
+	})
+
+	headers, err := s.mail.MailHeaders(ctx, athena.NewInOperator("id", mailIDs))
+	if err != nil {
+		entry.WithError(err).Error("failed to fetch mail headers from DB")
+		return nil, fmt.Errorf("failed to fetch mail headers from DB")
+	}
+
+	return headers, nil
+
+}
+
+func (s *service) MailRecipients(ctx context.Context, mailID uint) ([]*athena.MailRecipient, error) {
+
+	entry := s.logger.WithContext(ctx).WithFields(logrus.Fields{
+		"mail_id": mailID,
+		"service": serviceIdentifier,
+		"method":  "MailRecipients",
+	})
+
+	recipients, err := s.cache.MailHeaderRecipients(ctx, mailID)
+	if err != nil {
+		entry.WithError(err).Error("failed to fetch mail recipients from cache")
+		return nil, fmt.Errorf("failed to fetch mail recipients from cache")
+	}
+
+	if len(recipients) > 0 {
+		return recipients, nil
+	}
+
+	recipients, err = s.mail.MailRecipients(ctx, athena.NewEqualOperator("mail_id", mailID))
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		entry.WithError(err).Error("failed to fetch mail recipients from DB")
+		return nil, fmt.Errorf("failed to fetch mail recipients from DB")
+	}
+
+	if len(recipients) > 0 {
+		err = s.cache.SetMailHeaderRecipients(ctx, mailID, recipients)
+		if err != nil {
+			entry.WithError(err).Error("failed to cache mail recipients")
+		}
+	}
+
+	return recipients, nil
+
+}
+
+// MailRecipientsByMailIDs fetches the recipients of a batch of mails straight from the DB for the dataloaders
+func (s *service) MailRecipientsByMailIDs(ctx context.Context, mailIDs []uint) ([]*athena.MailRecipient, error) {
+
+	entry := s.logger.WithContext(ctx).WithFields(logrus.Fields{
+		"service": serviceIdentifier,
+		"method":  "MailRecipientsByMailIDs",
+	})
+
+	recipients, err := s.mail.MailRecipients(ctx, athena.NewInOperator("mail_id", mailIDs))
+	if err != nil {
+		entry.WithError(err).Error("failed to fetch mail recipients from DB")
+		return nil, fmt.Errorf("failed to fetch mail recipients from DB")
+	}
+
+	return recipients, nil
+
+}
+
+func (s *service) MemberMailLabels(ctx context.Context, memberID uint) (*athena.MemberMailLabels, error) {
+
+	entry := s.logger.WithContext(ctx).WithFields(logrus.Fields{
+		"member_id": memberID,
+		"service":   serviceIdentifier,
+		"method":    "MemberMailLabels",
+	})
+
+	labels, err := s.cache.MemberMailLabels(ctx, memberID)
+	if err != nil {
+		entry.WithError(err).Error("failed to fetch member mail labels from cache")
+		return nil, fmt.Errorf("failed to fetch member mail labels from cache")
+	}
+
+	if labels != nil {
+		return labels, nil
+	}
+
+	labels, err = s.mail.MemberMailLabels(ctx, memberID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		entry.WithError(err).Error("failed to fetch member mail labels from DB")
+		return nil, fmt.Errorf("failed to fetch member mail labels from DB")
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	err = s.cache.SetMemberMailLabels(ctx, memberID, labels)
+	if err != nil {
+		entry.WithError(err).Error("failed to cache member mail labels")
+	}
+
+	return labels, nil
+
+}
+
+func (s *service) MemberMailingLists(ctx context.Context, memberID uint) ([]*athena.MailingList, error) {
+
+	entry := s.logger.WithContext(ctx).WithFields(logrus.Fields{
+		"member_id": memberID,
+		"service":   serviceIdentifier,
+		"method":    "MemberMailingLists",
+	})
+
+	memberLists, err := s.cache.MemberMailingLists(ctx, memberID)
+	if err != nil {
+		entry.WithError(err).Error("failed to fetch member mailing lists from cache")
+		return nil, fmt.Errorf("failed to fetch member mailing lists from cache")
+	}
+
+	if len(memberLists) == 0 {
+		memberLists, err = s.mail.MemberMailingLists(ctx, memberID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			entry.WithError(err).Error("failed to fetch member mailing lists from DB")
+			return nil, fmt.Errorf("failed to fetch member mailing lists from DB")
+		}
+
+		if len(memberLists) > 0 {
+			err = s.cache.SetMemberMailingLists(ctx, memberID, memberLists)
+			if err != nil {
+				entry.WithError(err).Error("failed to cache member mailing lists")
+			}
+		}
+	}
+
+	lists := make([]*athena.MailingList, 0, len(memberLists))
+	for _, memberList := range memberLists {
+		list, err := s.MailingList(ctx, memberList.MailingListID)
+		if err != nil {
+			return nil, err
+		}
+
+		if list != nil {
+			lists = append(lists, list)
+		}
+	}
+
+	return lists, nil
+
+}
@@ -2,6 +2,8 @@ package member
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -16,19 +18,35 @@ import (
 	"github.com/eveisesi/athena"
 	"github.com/eveisesi/athena/internal/auth"
 	"github.com/lestrrat-go/jwx/jwt"
+	"github.com/sirupsen/logrus"
+	"github.com/volatiletech/null"
 )
 
 type Service interface {
 	Member(ctx context.Context, memberID uint) (*athena.Member, error)
+	Members(ctx context.Context, operators ...*athena.Operator) ([]*athena.Member, error)
+	LinkedMembers(ctx context.Context, memberID uint) ([]*athena.Member, error)
 	UpdateMember(ctx context.Context, member *athena.Member) (*athena.Member, error)
+	UpdateMemberScopes(ctx context.Context, memberID uint, scopes athena.MemberScopes) (*athena.Member, error)
 	Login(ctx context.Context, code, state string) error
 	ValidateToken(ctx context.Context, member *athena.Member) (*athena.Member, error)
+	ExpiredTokens(ctx context.Context, within time.Duration) ([]*athena.Member, error)
+	RefreshExpiredTokens(ctx context.Context, within time.Duration, concurrency int) (int, error)
 	Middleware(next http.Handler) http.Handler
+	ContextWithSession(ctx context.Context, token string) (context.Context, error)
 	MemberFromToken(ctx context.Context, token jwt.Token) (*athena.Member, error)
 	MemberFromContext(ctx context.Context) *athena.Member
+	SessionFromContext(ctx context.Context) *athena.Session
+	PurgeMember(ctx context.Context, memberID uint, source athena.AuditSource) error
+	MemberEvents(ctx context.Context, memberID uint) (<-chan *athena.MemberEvent, error)
+	RefreshMember(ctx context.Context, memberID uint, scopes []athena.Scope, force bool) (*athena.RefreshJob, error)
+	RefreshJob(ctx context.Context, jobID string) (*athena.RefreshJob, error)
+	RefreshJobProgress(ctx context.Context, jobID string) (<-chan *athena.RefreshJob, error)
 }
 
 type service struct {
+	logger *logrus.Logger
+
 	auth        auth.Service
 	cache       cache.Service
 	character   character.Service
@@ -42,14 +60,17 @@ type ctxKey struct {
 	name string
 }
 
-// const (
-// 	serviceIdentifier = "Member Service"
-// )
+const (
+	serviceIdentifier = "Member Service"
+)
 
 var userCtxKey = ctxKey{name: "user"}
+var sessionCtxKey = ctxKey{name: "session"}
 
-func NewService(auth auth.Service, cache cache.Service, alliance alliance.Service, character character.Service, corporation corporation.Service, member athena.MemberRepository) Service {
+func NewService(logger *logrus.Logger, auth auth.Service, cache cache.Service, alliance alliance.Service, character character.Service, corporation corporation.Service, member athena.MemberRepository) Service {
 	return &service{
+		logger: logger,
+
 		auth:        auth,
 		cache:       cache,
 		character:   character,
@@ -60,16 +81,68 @@ func NewService(auth auth.Service, cache cache.Service, alliance alliance.Servic
 	}
 }
 
-// func (s *service) ExpiredTokens(ctx context.Context) ([]*athena.Member, error) {
+// ExpiredTokens returns the enabled members whose access token has expired or will expire within the provided window
+func (s *service) ExpiredTokens(ctx context.Context, within time.Duration) ([]*athena.Member, error) {
+
+	members, err := s.member.Members(
+		ctx,
+		athena.NewLessThanOperator("expires", time.Now().Add(within)),
+		athena.NewEqualOperator("disabled", 0),
+		athena.NewNotEqualOperator("refresh_token", nil),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch members with expired tokens: %w", err)
+	}
+
+	return members, nil
+
+}
+
+// RefreshExpiredTokens refreshes the tokens of every member whose token expires within the provided window and
+// persists the new tokens. A failure event is published for every member whose token could not be refreshed.
+// The number of members whose tokens were refreshed is returned.
+func (s *service) RefreshExpiredTokens(ctx context.Context, within time.Duration, concurrency int) (int, error) {
+
+	entry := s.logger.WithContext(ctx).WithFields(logrus.Fields{
+		"service": serviceIdentifier,
+		"method":  "RefreshExpiredTokens",
+	})
+
+	members, err := s.ExpiredTokens(ctx, within)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(members) == 0 {
+		return 0, nil
+	}
+
+	refreshed, failures := s.auth.RefreshExpiredTokens(ctx, members, concurrency)
 
-// 	members, err := s.member.Members(ctx, athena.NewLessThanOperator("expires", time.Now()))
-// 	if err != nil {
-// 		return nil, err
-// 	}
+	// CCP rotates the refresh token on every refresh, so the new tokens are persisted before anything
+	// else can fail. The previous refresh token can not be used again
+	count := 0
+	for _, member := range refreshed {
+		updated, err := s.member.UpdateMemberToken(ctx, member.ID, member)
+		if err != nil {
+			entry.WithError(err).WithField("member_id", member.ID).Error("failed to persist refreshed token")
+			continue
+		}
+
+		_ = s.cache.SetMember(ctx, updated.ID, updated)
+		count++
+	}
+
+	for _, failure := range failures {
+		err = s.cache.PublishTokenRefreshFailure(ctx, failure)
+		if err != nil {
+			entry.WithError(err).WithField("member_id", failure.MemberID).Error("failed to publish token refresh failure")
+		}
+	}
 
-// 	spew.Dump(members)
+	return count, nil
 
-// }
+}
 
 func (s *service) ValidateToken(ctx context.Context, member *athena.Member) (*athena.Member, error) {
 
@@ -80,7 +153,7 @@ func (s *service) ValidateToken(ctx context.Context, member *athena.Member) (*at
 	}
 
 	if member.AccessToken != currentToken {
-		_, err = s.member.UpdateMember(ctx, member.ID, member)
+		_, err = s.member.UpdateMemberToken(ctx, member.ID, member)
 		if err != nil {
 			return nil, err
 		}
@@ -110,10 +183,114 @@ func (s *service) Member(ctx context.Context, memberID uint) (*athena.Member, er
 
 }
 
+func (s *service) Members(ctx context.Context, operators ...*athena.Operator) ([]*athena.Member, error) {
+	return s.member.Members(ctx, operators...)
+}
+
+// LinkedMembers returns the main of the member followed by every alt that is linked to that main.
+// A member without a main is treated as the main of its own alts
+func (s *service) LinkedMembers(ctx context.Context, memberID uint) ([]*athena.Member, error) {
+
+	member, err := s.Member(ctx, memberID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch member %d: %w", memberID, err)
+	}
+
+	if member == nil {
+		return nil, fmt.Errorf("member %d does not exist", memberID)
+	}
+
+	main := member
+	if member.MainID.Valid && member.MainID.Uint != member.ID {
+		main, err = s.Member(ctx, member.MainID.Uint)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch main %d of member %d: %w", member.MainID.Uint, memberID, err)
+		}
+
+		if main == nil {
+			return nil, fmt.Errorf("main %d of member %d does not exist", member.MainID.Uint, memberID)
+		}
+	}
+
+	alts, err := s.member.Members(
+		ctx,
+		athena.NewEqualOperator("main_id", main.ID),
+		athena.NewNotEqualOperator("id", main.ID),
+		athena.NewOrderOperator("id", athena.SortAsc),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch alts of member %d: %w", main.ID, err)
+	}
+
+	return append([]*athena.Member{main}, alts...), nil
+
+}
+
 func (s *service) UpdateMember(ctx context.Context, member *athena.Member) (*athena.Member, error) {
 	return s.member.UpdateMember(ctx, member.ID, member)
 }
 
+// UpdateMemberScopes only updates the scopes of the member, leaving its token untouched
+func (s *service) UpdateMemberScopes(ctx context.Context, memberID uint, scopes athena.MemberScopes) (*athena.Member, error) {
+	return s.member.UpdateMemberScopes(ctx, memberID, scopes)
+}
+
+// PurgeMember permanently removes the member and all data that has been collected for the member.
+// Sessions are revoked and cached data is removed once the database purge has been committed.
+func (s *service) PurgeMember(ctx context.Context, memberID uint, source athena.AuditSource) error {
+
+	member, err := s.member.Member(ctx, memberID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch member %d: %w", memberID, err)
+	}
+
+	if member == nil {
+		return fmt.Errorf("member %d does not exist", memberID)
+	}
+
+	keys, err := s.member.PurgeMember(ctx, member.ID, &athena.AuditEntry{
+		MemberID: member.ID,
+		Action:   athena.MemberPurgedAuditAction,
+		Source:   source,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to purge member %d: %w", member.ID, err)
+	}
+
+	err = s.dropCachedAPIKeys(ctx, keys)
+	if err != nil {
+		return fmt.Errorf("failed to drop cached api keys of purged member %d: %w", member.ID, err)
+	}
+
+	err = s.auth.RevokeMemberSessions(ctx, member.ID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke sessions for purged member %d: %w", member.ID, err)
+	}
+
+	err = s.cache.PurgeMember(ctx, member.ID)
+	if err != nil {
+		return fmt.Errorf("failed to purge cache for member %d: %w", member.ID, err)
+	}
+
+	return nil
+
+}
+
+// dropCachedAPIKeys removes the cached copies of API keys that a purge has changed, so that
+// requests stop being authenticated against the access that the keys had before
+func (s *service) dropCachedAPIKeys(ctx context.Context, hashes []string) error {
+
+	for _, hash := range hashes {
+		err := s.cache.DeleteAPIKey(ctx, hash)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+
+}
+
 func (s *service) Login(ctx context.Context, code, state string) error {
 
 	attempt, err := s.auth.AuthAttempt(ctx, state)
@@ -121,11 +298,17 @@ func (s *service) Login(ctx context.Context, code, state string) error {
 		return err
 	}
 
-	if attempt != nil && attempt.Status == athena.InvalidAuthStatus {
+	switch attempt.Status {
+	case athena.PendingAuthStatus:
+	case athena.ExpiredAuthStatus:
+		return fmt.Errorf("attempt has expired")
+	case athena.CompletedAuthStatus:
+		return fmt.Errorf("attempt has already been completed")
+	default:
 		return fmt.Errorf("attempt is no longer valid")
 	}
 
-	bearer, err := s.auth.BearerForCode(ctx, code)
+	bearer, err := s.auth.BearerForCode(ctx, code, attempt)
 	if err != nil {
 		return fmt.Errorf("failed to exchange state and code for token: %w", err)
 	}
@@ -152,8 +335,14 @@ func (s *service) Login(ctx context.Context, code, state string) error {
 	s.cache.PushIDToProcessorQueue(ctx, member.ID)
 	_ = s.cache.SetMember(ctx, member.ID, member)
 
+	session, err := s.auth.CreateSession(ctx, member.ID)
+	if err != nil {
+		return fmt.Errorf("failed to create session for member: %w", err)
+	}
+
 	attempt.Status = athena.CompletedAuthStatus
-	attempt.Token = member.AccessToken
+	attempt.Token.SetValid(session.AccessToken)
+	attempt.RefreshToken.SetValid(session.RefreshToken)
 
 	_, err = s.auth.UpdateAuthAttempt(ctx, attempt.State, attempt)
 	if err != nil {
@@ -170,45 +359,46 @@ func (s *service) Middleware(next http.Handler) http.Handler {
 		var ctx = r.Context()
 
 		token := r.Header.Get("authorization")
-		if token == "" {
+		if !strings.HasPrefix(strings.ToLower(token), "bearer ") {
 			next.ServeHTTP(w, r)
 			return
 		}
 
-		token = token[7:]
-		parsed, err := s.auth.ParseAndVerifyToken(ctx, token)
+		ctx, err := s.ContextWithSession(ctx, token[7:])
 		if err != nil {
-			fmt.Printf("Error Parsing Token: %v, stop auth, proceeding with request\n", err)
+			fmt.Printf("%s, proceeding with request\n", err)
 			next.ServeHTTP(w, r)
 			return
 		}
 
-		sub := parsed.Subject()
-		if sub == "" {
-			fmt.Printf("Invalid Token Subject: %s\n", sub)
-			next.ServeHTTP(w, r)
-			return
-		}
+		next.ServeHTTP(w, r.WithContext(ctx))
 
-		memberID, err := memberIDFromSubject(sub)
-		if err != nil {
-			fmt.Printf("unable to parse character ID from token sub: %s: %s\n", sub, err)
-			next.ServeHTTP(w, r)
-			return
-		}
+	})
+}
 
-		member, err := s.member.Member(ctx, memberID)
-		if err != nil {
-			fmt.Printf("failed to retrieve member with ID from token: %s\n", err)
-			next.ServeHTTP(w, r)
-			return
-		}
+// ContextWithSession parses a session token and returns a copy of ctx that carries the session
+// along with the member that it belongs to
+func (s *service) ContextWithSession(ctx context.Context, token string) (context.Context, error) {
 
-		ctx = context.WithValue(ctx, userCtxKey, member)
+	session, err := s.auth.ParseSessionToken(ctx, token)
+	if err != nil {
+		return ctx, fmt.Errorf("failed to parse session token: %w", err)
+	}
 
-		next.ServeHTTP(w, r.WithContext(ctx))
+	member, err := s.Member(ctx, session.MemberID)
+	if err != nil {
+		return ctx, fmt.Errorf("failed to retrieve member with ID from session: %w", err)
+	}
+
+	if member == nil || member.Disabled {
+		return ctx, fmt.Errorf("session belongs to a member that does not exist or is disabled: %d", session.MemberID)
+	}
+
+	ctx = context.WithValue(ctx, userCtxKey, member)
+	ctx = context.WithValue(ctx, sessionCtxKey, session)
+
+	return ctx, nil
 
-	})
 }
 
 func (s *service) MemberFromContext(ctx context.Context) *athena.Member {
@@ -226,6 +416,17 @@ func (s *service) MemberFromContext(ctx context.Context) *athena.Member {
 
 }
 
+func (s *service) SessionFromContext(ctx context.Context) *athena.Session {
+
+	session, ok := ctx.Value(sessionCtxKey).(*athena.Session)
+	if !ok {
+		return nil
+	}
+
+	return session
+
+}
+
 func (s *service) MemberFromToken(ctx context.Context, token jwt.Token) (*athena.Member, error) {
 
 	sub := token.Subject()
@@ -281,11 +482,19 @@ func (s *service) MemberFromToken(ctx context.Context, token jwt.Token) (*athena
 
 	claims := token.PrivateClaims()
 
-	if _, ok := claims["owner"]; !ok {
+	owner, ok := claims["owner"].(string)
+	if !ok || owner == "" {
 		return nil, fmt.Errorf("failed to process token. owner hash is missing")
 	}
 
-	member.OwnerHash.SetValid(claims["owner"].(string))
+	if !member.IsNew && member.OwnerHash.Valid && member.OwnerHash.String != owner {
+		member, err = s.startOwnershipEpoch(ctx, member)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	member.OwnerHash.SetValid(owner)
 
 	if _, ok := claims["scp"]; ok {
 		scp := []athena.MemberScope{}
@@ -321,6 +530,45 @@ func (s *service) MemberFromToken(ctx context.Context, token jwt.Token) (*athena
 
 }
 
+// startOwnershipEpoch is called when the owner hash of a token does not match the owner hash on record,
+// which means the character has been transferred to a different account. Everything that was collected
+// for the previous owner is removed, alt links and sessions are invalidated and the member is flagged
+// so that the transfer is visible to recruiters.
+func (s *service) startOwnershipEpoch(ctx context.Context, member *athena.Member) (*athena.Member, error) {
+
+	keys, err := s.member.ResetMember(ctx, member.ID, &athena.AuditEntry{
+		MemberID: member.ID,
+		Action:   athena.MemberOwnerChangedAuditAction,
+		Source:   athena.SSOAuditSource,
+		Details:  null.StringFrom(fmt.Sprintf("ownership epoch %d ended", member.OwnershipEpoch)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to reset member %d after owner change: %w", member.ID, err)
+	}
+
+	err = s.dropCachedAPIKeys(ctx, keys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to drop cached api keys of member %d after owner change: %w", member.ID, err)
+	}
+
+	err = s.auth.RevokeMemberSessions(ctx, member.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to revoke sessions for member %d after owner change: %w", member.ID, err)
+	}
+
+	err = s.cache.PurgeMember(ctx, member.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to purge cache for member %d after owner change: %w", member.ID, err)
+	}
+
+	member.MainID = null.NewUint(0, false)
+	member.OwnershipEpoch++
+	member.OwnerChangedAt.SetValid(time.Now())
+
+	return member, nil
+
+}
+
 func memberIDFromSubject(sub string) (uint, error) {
 
 	parts := strings.Split(sub, ":")
@@ -334,3 +582,108 @@ func memberIDFromSubject(sub string) (uint, error) {
 	return uint(id), err
 
 }
+
+// MemberEvents streams the change events that the processor publishes for a member until ctx is cancelled
+func (s *service) MemberEvents(ctx context.Context, memberID uint) (<-chan *athena.MemberEvent, error) {
+	return s.cache.SubscribeMemberEvents(ctx, memberID)
+}
+
+// RefreshMember queues a job that syncs the provided scopes of the member ahead of the regular
+// processor queue. All scopes that the member has granted are synced when none are provided
+func (s *service) RefreshMember(ctx context.Context, memberID uint, scopes []athena.Scope, force bool) (*athena.RefreshJob, error) {
+
+	member, err := s.Member(ctx, memberID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch member %d: %w", memberID, err)
+	}
+
+	if member == nil {
+		return nil, fmt.Errorf("member %d does not exist", memberID)
+	}
+
+	granted := make(map[athena.Scope]bool, len(member.Scopes))
+	for _, scope := range member.Scopes {
+		granted[scope.Scope] = true
+	}
+
+	if len(scopes) == 0 {
+		for _, scope := range member.Scopes {
+			scopes = append(scopes, scope.Scope)
+		}
+	}
+
+	for _, scope := range scopes {
+		if !granted[scope] {
+			return nil, fmt.Errorf("member %d has not granted scope %s", memberID, scope)
+		}
+	}
+
+	b := make([]byte, 16)
+	_, err = rand.Read(b)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate job id: %w", err)
+	}
+
+	job := athena.NewRefreshJob(hex.EncodeToString(b), member.ID, scopes, force)
+
+	err = s.cache.PushRefreshJob(ctx, job)
+	if err != nil {
+		return nil, fmt.Errorf("failed to queue refresh job for member %d: %w", member.ID, err)
+	}
+
+	return job, nil
+
+}
+
+func (s *service) RefreshJob(ctx context.Context, jobID string) (*athena.RefreshJob, error) {
+	return s.cache.RefreshJob(ctx, jobID)
+}
+
+// RefreshJobProgress streams the state of the job every time it changes, starting with its current
+// state. The returned channel is closed once the job is done or ctx is cancelled
+func (s *service) RefreshJobProgress(ctx context.Context, jobID string) (<-chan *athena.RefreshJob, error) {
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	// Subscribe before reading the current state of the job, so that no progress
+	// is lost between the two
+	updates, err := s.cache.SubscribeRefreshJob(ctx, jobID)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	job, err := s.cache.RefreshJob(ctx, jobID)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	if job == nil {
+		cancel()
+		return nil, fmt.Errorf("job %s does not exist", jobID)
+	}
+
+	progress := make(chan *athena.RefreshJob)
+	go func() {
+		defer close(progress)
+		defer cancel()
+
+		for job != nil {
+			select {
+			case progress <- job:
+			case <-ctx.Done():
+				return
+			}
+
+			if job.IsDone() {
+				return
+			}
+
+			job = <-updates
+		}
+	}()
+
+	return progress, nil
+
+}
@@ -0,0 +1,115 @@
+package mysqldb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/eveisesi/athena"
+	"github.com/jmoiron/sqlx"
+)
+
+type apiKeyRepository struct {
+	db    *sqlx.DB
+	table string
+}
+
+func NewAPIKeyRepository(db *sql.DB) athena.APIKeyRepository {
+	return &apiKeyRepository{
+		db:    sqlx.NewDb(db, "mysql"),
+		table: "api_keys",
+	}
+}
+
+func (r *apiKeyRepository) APIKey(ctx context.Context, id uint) (*athena.APIKey, error) {
+
+	keys, err := r.APIKeys(ctx, athena.NewEqualOperator("id", id))
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	if len(keys) != 1 {
+		return nil, nil
+	}
+
+	return keys[0], nil
+
+}
+
+func (r *apiKeyRepository) APIKeys(ctx context.Context, operators ...*athena.Operator) ([]*athena.APIKey, error) {
+
+	query, args, err := BuildFilters(sq.Select(
+		"id", "name", "prefix", "hash", "allowed_queries", "allowed_members",
+		"rate_limit", "created_by", "revoked", "revoked_at", "created_at", "updated_at",
+	).From(r.table), operators...).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("[APIKey Repository] Failed to generate sql: %w", err)
+	}
+
+	var keys = make([]*athena.APIKey, 0)
+	err = r.db.SelectContext(ctx, &keys, query, args...)
+
+	return keys, err
+
+}
+
+func (r *apiKeyRepository) CreateAPIKey(ctx context.Context, key *athena.APIKey) (*athena.APIKey, error) {
+
+	query, args, err := sq.Insert(r.table).Columns(
+		"name", "prefix", "hash", "allowed_queries", "allowed_members",
+		"rate_limit", "created_by", "revoked", "revoked_at", "created_at", "updated_at",
+	).Values(
+		key.Name,
+		key.Prefix,
+		key.Hash,
+		key.AllowedQueries,
+		key.AllowedMembers,
+		key.RateLimit,
+		key.CreatedBy,
+		key.Revoked,
+		key.RevokedAt,
+		sq.Expr(`NOW()`), sq.Expr(`NOW()`),
+	).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("[APIKey Repository] Failed to generate sql query: %w", err)
+	}
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("[APIKey Repository] Failed to insert record: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("[APIKey Repository] Failed to fetch id of inserted record: %w", err)
+	}
+
+	return r.APIKey(ctx, uint(id))
+
+}
+
+func (r *apiKeyRepository) UpdateAPIKey(ctx context.Context, id uint, key *athena.APIKey) (*athena.APIKey, error) {
+
+	query, args, err := sq.Update(r.table).
+		Set("name", key.Name).
+		Set("allowed_queries", key.AllowedQueries).
+		Set("allowed_members", key.AllowedMembers).
+		Set("rate_limit", key.RateLimit).
+		Set("revoked", key.Revoked).
+		Set("revoked_at", key.RevokedAt).
+		Set("updated_at", sq.Expr(`NOW()`)).
+		Where(sq.Eq{"id": id}).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("[APIKey Repository] Failed to generate sql query: %w", err)
+	}
+
+	_, err = r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("[APIKey Repository] Failed to update record: %w", err)
+	}
+
+	return r.APIKey(ctx, id)
+
+}
@@ -60,6 +60,20 @@ func (r *memberAssetsRepository) MemberAssets(ctx context.Context, memberID uint
 
 }
 
+func (r *memberAssetsRepository) CountMemberAssets(ctx context.Context, memberID uint, operators ...*athena.Operator) (uint, error) {
+
+	query, args, err := BuildFilters(sq.Select("COUNT(*)").From(r.table), operators...).Where(sq.Eq{"member_id": memberID}).ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("[Asset Repository] Failed to generate query: %w", err)
+	}
+
+	var count uint
+	err = r.db.GetContext(ctx, &count, query, args...)
+
+	return count, err
+
+}
+
 func (r *memberAssetsRepository) CreateMemberAssets(ctx context.Context, memberID uint, assets []*athena.MemberAsset) ([]*athena.MemberAsset, error) {
 
 	i := sq.Insert(r.table).Columns(
@@ -0,0 +1,81 @@
+package mysqldb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/eveisesi/athena"
+	"github.com/jmoiron/sqlx"
+)
+
+type auditRepository struct {
+	db    *sqlx.DB
+	table string
+}
+
+func NewAuditRepository(db *sql.DB) athena.AuditRepository {
+	return &auditRepository{
+		db:    sqlx.NewDb(db, "mysql"),
+		table: "audit_log",
+	}
+}
+
+func (r *auditRepository) AuditEntries(ctx context.Context, operators ...*athena.Operator) ([]*athena.AuditEntry, error) {
+
+	query, args, err := BuildFilters(sq.Select(
+		"id", "member_id", "action", "source", "details", "created_at",
+	).From(r.table), operators...).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("[Audit Repository] Failed to generate sql: %w", err)
+	}
+
+	var entries = make([]*athena.AuditEntry, 0)
+	err = r.db.SelectContext(ctx, &entries, query, args...)
+
+	return entries, err
+
+}
+
+func (r *auditRepository) CreateAuditEntry(ctx context.Context, entry *athena.AuditEntry) (*athena.AuditEntry, error) {
+
+	query, args, err := insertAuditEntryQuery(r.table, entry)
+	if err != nil {
+		return nil, fmt.Errorf("[Audit Repository] Failed to generate sql query: %w", err)
+	}
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("[Audit Repository] Failed to insert record: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("[Audit Repository] Failed to fetch id of inserted record: %w", err)
+	}
+
+	entries, err := r.AuditEntries(ctx, athena.NewEqualOperator("id", id))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(entries) != 1 {
+		return nil, fmt.Errorf("[Audit Repository] Failed to fetch inserted record")
+	}
+
+	return entries[0], nil
+
+}
+
+func insertAuditEntryQuery(table string, entry *athena.AuditEntry) (string, []interface{}, error) {
+	return sq.Insert(table).Columns(
+		"member_id", "action", "source", "details", "created_at",
+	).Values(
+		entry.MemberID,
+		entry.Action,
+		entry.Source,
+		entry.Details,
+		sq.Expr(`NOW()`),
+	).ToSql()
+}
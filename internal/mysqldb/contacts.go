@@ -50,7 +50,7 @@ func (r *memberContactRepository) MemberContacts(ctx context.Context, memberID u
 			"source_page", "is_blocked", "is_watched",
 			"label_ids", "standing",
 			"created_at", "updated_at",
-		).From(r.contacts), operators...,
+		).From(r.contacts).Where(sq.Eq{"member_id": memberID}), operators...,
 	).ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("[Contact Repository] Failed to generate query: %w", err)
@@ -63,6 +63,22 @@ func (r *memberContactRepository) MemberContacts(ctx context.Context, memberID u
 
 }
 
+func (r *memberContactRepository) CountMemberContacts(ctx context.Context, memberID uint, operators ...*athena.Operator) (uint, error) {
+
+	query, args, err := BuildFilters(
+		sq.Select("COUNT(*)").From(r.contacts).Where(sq.Eq{"member_id": memberID}), operators...,
+	).ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("[Contact Repository] Failed to generate query: %w", err)
+	}
+
+	var count uint
+	err = r.db.GetContext(ctx, &count, query, args...)
+
+	return count, err
+
+}
+
 func (r *memberContactRepository) CreateMemberContacts(ctx context.Context, memberID uint, contacts []*athena.MemberContact) ([]*athena.MemberContact, error) {
 
 	i := sq.Insert(r.contacts).
@@ -50,7 +50,7 @@ func (r *memberContractRepository) MemberContracts(ctx context.Context, memberID
 		"end_location_id", "for_corporation", "issuer_corporation_id", "issuer_id",
 		"price", "reward", "start_location_id", "status", "title",
 		"type", "volume", "created_at", "updated_at",
-	).From(r.contracts), operators...).ToSql()
+	).From(r.contracts).Where(sq.Eq{"member_id": memberID}), operators...).ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("[Contract Repository] Failed to generate query: %w", err)
 	}
@@ -62,6 +62,20 @@ func (r *memberContractRepository) MemberContracts(ctx context.Context, memberID
 
 }
 
+func (r *memberContractRepository) CountMemberContracts(ctx context.Context, memberID uint, operators ...*athena.Operator) (uint, error) {
+
+	query, args, err := BuildFilters(sq.Select("COUNT(*)").From(r.contracts).Where(sq.Eq{"member_id": memberID}), operators...).ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("[Contract Repository] Failed to generate query: %w", err)
+	}
+
+	var count uint
+	err = r.db.GetContext(ctx, &count, query, args...)
+
+	return count, err
+
+}
+
 func (r *memberContractRepository) CreateContracts(ctx context.Context, memberID uint, contracts []*athena.MemberContract) ([]*athena.MemberContract, error) {
 
 	i := sq.Insert(r.contracts).Columns(
@@ -147,7 +161,26 @@ func (r *memberContractRepository) MemberContractItems(ctx context.Context, memb
 		"type_id", "quantity", "raw_quantity",
 		"is_included", "is_singleton",
 		"created_at", "updated_at",
-	).From(r.items), operators...).ToSql()
+	).From(r.items).Where(sq.Eq{"member_id": memberID, "contract_id": contractID}), operators...).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("[Contract Repository] Failed to generate query: %w", err)
+	}
+
+	var items = make([]*athena.MemberContractItem, 0)
+	err = r.db.SelectContext(ctx, &items, query, args...)
+
+	return items, err
+
+}
+
+func (r *memberContractRepository) MemberContractItemsByContractIDs(ctx context.Context, memberID uint, contractIDs []uint) ([]*athena.MemberContractItem, error) {
+
+	query, args, err := sq.Select(
+		"member_id", "contract_id", "record_id",
+		"type_id", "quantity", "raw_quantity",
+		"is_included", "is_singleton",
+		"created_at", "updated_at",
+	).From(r.items).Where(sq.Eq{"member_id": memberID, "contract_id": contractIDs}).ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("[Contract Repository] Failed to generate query: %w", err)
 	}
@@ -193,9 +226,25 @@ func (r *memberContractRepository) CreateMemberContractItems(ctx context.Context
 
 func (r *memberContractRepository) MemberContractBids(ctx context.Context, memberID, contractID uint, operators ...*athena.Operator) ([]*athena.MemberContractBid, error) {
 
+	query, args, err := BuildFilters(sq.Select(
+		"member_id", "contract_id", "bid_id", "bidder", "amount", "bid_date", "created_at", "updated_at",
+	).From(r.bids).Where(sq.Eq{"member_id": memberID, "contract_id": contractID}), operators...).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("[Contract Repository] Failed to generate query: %w", err)
+	}
+
+	var bids = make([]*athena.MemberContractBid, 0)
+	err = r.db.SelectContext(ctx, &bids, query, args...)
+
+	return bids, err
+
+}
+
+func (r *memberContractRepository) MemberContractBidsByContractIDs(ctx context.Context, memberID uint, contractIDs []uint) ([]*athena.MemberContractBid, error) {
+
 	query, args, err := sq.Select(
 		"member_id", "contract_id", "bid_id", "bidder", "amount", "bid_date", "created_at", "updated_at",
-	).From(r.bids).Where(sq.Eq{"member_id": memberID, "contract_id": contractID}).ToSql()
+	).From(r.bids).Where(sq.Eq{"member_id": memberID, "contract_id": contractIDs}).ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("[Contract Repository] Failed to generate query: %w", err)
 	}
@@ -27,8 +27,9 @@ func (r *memberFittingRepository) MemberFitting(ctx context.Context, memberID, f
 
 	query, args, err := sq.Select(
 		"member_id", "fitting_id", "ship_type_id",
-		"name", "description", "created_at", "updated_at",
-	).From(r.fittings).Where(sq.Eq{"member_id": memberID}).ToSql()
+		"name", "description", "item_hash",
+		"created_at", "updated_at",
+	).From(r.fittings).Where(sq.Eq{"member_id": memberID, "fitting_id": fittingID}).ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("[Fitting Repository] Failed to generate query: %w", err)
 	}
@@ -42,10 +43,11 @@ func (r *memberFittingRepository) MemberFitting(ctx context.Context, memberID, f
 
 func (r *memberFittingRepository) MemberFittings(ctx context.Context, memberID uint, operators ...*athena.Operator) ([]*athena.MemberFitting, error) {
 
-	query, args, err := sq.Select(
+	query, args, err := BuildFilters(sq.Select(
 		"member_id", "fitting_id", "ship_type_id",
-		"name", "description", "created_at", "updated_at",
-	).From(r.fittings).Where(sq.Eq{"member_id": memberID}).ToSql()
+		"name", "description", "item_hash",
+		"created_at", "updated_at",
+	).From(r.fittings).Where(sq.Eq{"member_id": memberID}), operators...).ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("[Fitting Repository] Failed to generate query: %w", err)
 	}
@@ -61,15 +63,18 @@ func (r *memberFittingRepository) CreateMemberFittings(ctx context.Context, memb
 
 	i := sq.Insert(r.fittings).Columns(
 		"member_id", "fitting_id", "ship_type_id",
-		"name", "description", "created_at", "updated_at",
+		"name", "description", "item_hash",
+		"created_at", "updated_at",
 	)
+	fittingIDs := make([]interface{}, 0, len(fittings))
 	for _, fitting := range fittings {
-		i.Values(
-			fitting.MemberID,
+		i = i.Values(
+			memberID,
 			fitting.FittingID, fitting.ShipTypeID,
-			fitting.Name, fitting.Description,
-			fitting.CreatedAt, fitting.UpdatedAt,
+			fitting.Name, fitting.Description, fitting.ItemsHash,
+			sq.Expr(`NOW()`), sq.Expr(`NOW()`),
 		)
+		fittingIDs = append(fittingIDs, fitting.FittingID)
 	}
 
 	query, args, err := i.ToSql()
@@ -82,15 +87,18 @@ func (r *memberFittingRepository) CreateMemberFittings(ctx context.Context, memb
 		return nil, fmt.Errorf("[Fitting Repository] Failed to insert records: %w", err)
 	}
 
-	return r.MemberFittings(ctx, memberID)
+	return r.MemberFittings(ctx, memberID, athena.NewInOperator("fitting_id", fittingIDs))
 
 }
 
 func (r *memberFittingRepository) UpdateMemberFitting(ctx context.Context, memberID, fittingID uint, fitting *athena.MemberFitting) (*athena.MemberFitting, error) {
 
 	query, args, err := sq.Update(r.fittings).
+		Set("ship_type_id", fitting.ShipTypeID).
 		Set("name", fitting.Name).
 		Set("description", fitting.Description).
+		Set("item_hash", fitting.ItemsHash).
+		Set("updated_at", sq.Expr(`NOW()`)).
 		Where(sq.Eq{"member_id": memberID, "fitting_id": fittingID}).ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("[Fitting Repository] Failed to generate query: %w", err)
@@ -98,10 +106,10 @@ func (r *memberFittingRepository) UpdateMemberFitting(ctx context.Context, membe
 
 	_, err = r.db.ExecContext(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("[Fitting Repository] Failed to insert records: %w", err)
+		return nil, fmt.Errorf("[Fitting Repository] Failed to update record: %w", err)
 	}
 
-	return r.MemberFitting(ctx, memberID, fitting.FittingID)
+	return r.MemberFitting(ctx, memberID, fittingID)
 
 }
 
@@ -114,7 +122,7 @@ func (r *memberFittingRepository) DeleteMemberFitting(ctx context.Context, membe
 
 	_, err = r.db.ExecContext(ctx, query, args...)
 	if err != nil {
-		return false, fmt.Errorf("[Fitting Repository] Failed to insert records: %w", err)
+		return false, fmt.Errorf("[Fitting Repository] Failed to delete records: %w", err)
 	}
 
 	return true, nil
@@ -141,13 +149,13 @@ func (r *memberFittingRepository) MemberFittingItems(ctx context.Context, member
 
 func (r *memberFittingRepository) CreateMemberFittingItems(ctx context.Context, memberID, fittingID uint, items []*athena.MemberFittingItem) ([]*athena.MemberFittingItem, error) {
 
-	i := sq.Insert(r.fittings).Columns(
+	i := sq.Insert(r.items).Options("IGNORE").Columns(
 		"member_id",
 		"fitting_id", "type_id", "quantity",
 		"flag", "created_at", "updated_at",
 	)
 	for _, item := range items {
-		i.Values(
+		i = i.Values(
 			memberID,
 			fittingID, item.TypeID,
 			item.Quantity, item.Flag,
@@ -178,7 +186,7 @@ func (r *memberFittingRepository) DeleteMemberFittingItems(ctx context.Context,
 
 	_, err = r.db.ExecContext(ctx, query, args...)
 	if err != nil {
-		return false, fmt.Errorf("[Fitting Repository] Failed to insert records: %w", err)
+		return false, fmt.Errorf("[Fitting Repository] Failed to delete records: %w", err)
 	}
 
 	return true, nil
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strconv"
 	"time"
 
 	sq "github.com/Masterminds/squirrel"
@@ -35,7 +36,7 @@ func NewMailRepository(db *sql.DB) athena.MailRepository {
 
 func (r *mailRepository) MailHeader(ctx context.Context, mailID uint) (*athena.MailHeader, error) {
 
-	mails, err := r.MailHeaders(ctx, athena.NewEqualOperator("mail_id", mailID))
+	mails, err := r.MailHeaders(ctx, athena.NewEqualOperator("id", mailID))
 	if err != nil {
 		return nil, err
 	}
@@ -109,6 +110,72 @@ func (r *mailRepository) MemberMailHeaders(ctx context.Context, operators ...*at
 
 }
 
+func (r *mailRepository) SearchMemberMailHeaders(ctx context.Context, memberID uint, filter *athena.MemberMailHeaderFilter, operators ...*athena.Operator) ([]*athena.MemberMailHeader, error) {
+
+	s := r.searchMemberMailHeaders(sq.Select(
+		"mmh.member_id", "mmh.mail_id", "mmh.labels", "mmh.is_read", "mmh.created_at", "mmh.updated_at",
+	), memberID, filter)
+
+	query, args, err := BuildFilters(s, operators...).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("[Mail Repository] Failed to generate query: %w", err)
+	}
+
+	var headers = make([]*athena.MemberMailHeader, 0)
+	err = r.db.SelectContext(ctx, &headers, query, args...)
+
+	return headers, err
+
+}
+
+func (r *mailRepository) CountMemberMailHeaders(ctx context.Context, memberID uint, filter *athena.MemberMailHeaderFilter) (uint, error) {
+
+	query, args, err := r.searchMemberMailHeaders(sq.Select("COUNT(*)"), memberID, filter).ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("[Mail Repository] Failed to generate query: %w", err)
+	}
+
+	var count uint
+	err = r.db.GetContext(ctx, &count, query, args...)
+
+	return count, err
+
+}
+
+// searchMemberMailHeaders applies the joins and the conditions described by filter to s
+func (r *mailRepository) searchMemberMailHeaders(s sq.SelectBuilder, memberID uint, filter *athena.MemberMailHeaderFilter) sq.SelectBuilder {
+
+	s = s.From(fmt.Sprintf("%s mmh", r.member)).
+		Join(fmt.Sprintf("%s mh ON mh.id = mmh.mail_id", r.mail)).
+		Where(sq.Eq{"mmh.member_id": memberID})
+
+	if filter == nil {
+		return s
+	}
+
+	if filter.LabelID.Valid {
+		s = s.Where(sq.Expr("JSON_CONTAINS(mmh.labels, ?)", strconv.FormatUint(uint64(filter.LabelID.Uint), 10)))
+	}
+	if filter.SenderID.Valid {
+		s = s.Where(sq.Eq{"mh.sender_id": filter.SenderID.Uint})
+	}
+	if filter.RecipientType.IsValid() {
+		s = s.Where(sq.Expr(
+			fmt.Sprintf("EXISTS (SELECT 1 FROM %s mhr WHERE mhr.mail_id = mmh.mail_id AND mhr.recipient_type = ?)", r.recipients),
+			filter.RecipientType,
+		))
+	}
+	if filter.From.Valid {
+		s = s.Where(sq.GtOrEq{"mh.sent": filter.From.Time})
+	}
+	if filter.To.Valid {
+		s = s.Where(sq.LtOrEq{"mh.sent": filter.To.Time})
+	}
+
+	return s
+
+}
+
 func (r *mailRepository) CreateMemberMailHeaders(ctx context.Context, memberID uint, headers []*athena.MemberMailHeader) ([]*athena.MemberMailHeader, error) {
 
 	i := sq.Insert(r.member).Columns(
@@ -218,13 +285,13 @@ func (r *mailRepository) MemberMailLabels(ctx context.Context, memberID uint) (*
 
 	query, args, err := sq.Select(
 		"member_id", "labels", "total_unread_count", "created_at", "updated_at",
-	).From(r.labels).Where("member_id", memberID).ToSql()
+	).From(r.labels).Where(sq.Eq{"member_id": memberID}).ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("[Mail Repository] Failed to generate query: %w", err)
 	}
 
 	var labels = new(athena.MemberMailLabels)
-	err = r.db.SelectContext(ctx, labels, query, args...)
+	err = r.db.GetContext(ctx, labels, query, args...)
 
 	return labels, err
 }
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strconv"
 
 	"github.com/Masterminds/squirrel"
 	sq "github.com/Masterminds/squirrel"
@@ -43,8 +44,8 @@ func (r *memberRepository) Members(ctx context.Context, operators ...*athena.Ope
 
 	query, args, err := BuildFilters(sq.Select(
 		"id", "main_id", "access_token", "refresh_token", "expires",
-		"owner_hash", "scopes", "disabled", "disabled_reason", "disabled_timestamp",
-		"last_login", "created_at", "updated_at",
+		"owner_hash", "ownership_epoch", "owner_changed_at", "scopes", "disabled", "disabled_reason",
+		"disabled_timestamp", "last_login", "created_at", "updated_at",
 	).From(r.table), operators...).ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("[Member Repository] Failed to generate sql: %w", err)
@@ -61,8 +62,8 @@ func (r *memberRepository) CreateMember(ctx context.Context, member *athena.Memb
 
 	query, args, err := squirrel.Insert(r.table).Columns(
 		"id", "main_id", "access_token", "refresh_token", "expires",
-		"owner_hash", "scopes", "disabled", "disabled_reason", "disabled_timestamp",
-		"last_login", "created_at", "updated_at",
+		"owner_hash", "ownership_epoch", "owner_changed_at", "scopes", "disabled", "disabled_reason",
+		"disabled_timestamp", "last_login", "created_at", "updated_at",
 	).Values(
 		member.ID,
 		member.MainID,
@@ -70,6 +71,8 @@ func (r *memberRepository) CreateMember(ctx context.Context, member *athena.Memb
 		member.RefreshToken,
 		member.Expires,
 		member.OwnerHash,
+		member.OwnershipEpoch,
+		member.OwnerChangedAt,
 		member.Scopes,
 		member.Disabled,
 		member.DisabledReason,
@@ -98,6 +101,8 @@ func (r *memberRepository) UpdateMember(ctx context.Context, id uint, member *at
 		Set("refresh_token", member.RefreshToken).
 		Set("expires", member.Expires).
 		Set("owner_hash", member.OwnerHash).
+		Set("ownership_epoch", member.OwnershipEpoch).
+		Set("owner_changed_at", member.OwnerChangedAt).
 		Set("scopes", member.Scopes).
 		Set("disabled", member.Disabled).
 		Set("disabled_reason", member.DisabledReason).
@@ -118,6 +123,48 @@ func (r *memberRepository) UpdateMember(ctx context.Context, id uint, member *at
 
 }
 
+// UpdateMemberToken only updates the token columns of the member, so that a token that is refreshed
+// while the member is being processed is not overwritten with the stale copy held by the processor
+func (r *memberRepository) UpdateMemberToken(ctx context.Context, id uint, member *athena.Member) (*athena.Member, error) {
+
+	query, args, err := sq.Update(r.table).
+		Set("access_token", member.AccessToken).
+		Set("refresh_token", member.RefreshToken).
+		Set("expires", member.Expires).
+		Set("updated_at", sq.Expr(`NOW()`)).
+		Where(sq.Eq{"id": id}).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("[Member Repository] Failed to generate sql query: %w", err)
+	}
+
+	_, err = r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("[Member Repository] Failed to update record: %w", err)
+	}
+
+	return r.Member(ctx, id)
+
+}
+
+func (r *memberRepository) UpdateMemberScopes(ctx context.Context, id uint, scopes athena.MemberScopes) (*athena.Member, error) {
+
+	query, args, err := sq.Update(r.table).
+		Set("scopes", scopes).
+		Set("updated_at", sq.Expr(`NOW()`)).
+		Where(sq.Eq{"id": id}).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("[Member Repository] Failed to generate sql query: %w", err)
+	}
+
+	_, err = r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("[Member Repository] Failed to update record: %w", err)
+	}
+
+	return r.Member(ctx, id)
+
+}
+
 func (r *memberRepository) DeleteMember(ctx context.Context, id uint) (bool, error) {
 
 	query, args, err := sq.Delete(r.table).Where(sq.Eq{"id": id}).ToSql()
@@ -129,3 +176,289 @@ func (r *memberRepository) DeleteMember(ctx context.Context, id uint) (bool, err
 	return err == nil, err
 
 }
+
+// memberTables are the tables that hold data which belongs to a single member and
+// is keyed by a member_id column. The order matters, child tables are listed
+// before the tables they reference.
+var memberTables = []string{
+	"member_assets",
+	"member_clone_meta",
+	"member_home_clone",
+	"member_jump_clones",
+	"member_implants",
+	"member_contacts",
+	"member_contact_labels",
+	"member_contract_bids",
+	"member_contract_items",
+	"member_contracts",
+	"member_fitting_items",
+	"member_fittings",
+	"member_location",
+	"member_online",
+	"member_ship",
+	"member_mail_labels",
+	"member_mailing_lists",
+	"member_skill_properties",
+	"member_skills",
+	"member_skillqueue",
+	"member_attributes",
+	"member_wallet_balance",
+	"member_wallet_journals",
+	"member_wallet_transactions",
+	"member_red_flags",
+}
+
+// PurgeMember removes the member and every record tied to the member inside of a single
+// transaction. The audit entry is written as part of the same transaction so a purge is
+// never recorded without having happened, and vice versa.
+func (r *memberRepository) PurgeMember(ctx context.Context, id uint, entry *athena.AuditEntry) ([]string, error) {
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("[Member Repository] Failed to start transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	err = r.purgeMemberData(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := r.purgeMemberAPIKeys(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	query, args, err := sq.Delete(r.table).Where(sq.Eq{"id": id}).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("[Member Repository] Failed to generate sql query: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("[Member Repository] Failed to delete member: %w", err)
+	}
+
+	err = r.recordAuditEntry(ctx, tx, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, fmt.Errorf("[Member Repository] Failed to commit transaction: %w", err)
+	}
+
+	return keys, nil
+
+}
+
+// ResetMember removes every record tied to the member, but leaves the member record itself
+// in place. This is used when a character changes hands and the data collected for the previous
+// owner must not be visible to the new owner.
+func (r *memberRepository) ResetMember(ctx context.Context, id uint, entry *athena.AuditEntry) ([]string, error) {
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("[Member Repository] Failed to start transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	err = r.purgeMemberData(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := r.purgeMemberAPIKeys(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.recordAuditEntry(ctx, tx, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, fmt.Errorf("[Member Repository] Failed to commit transaction: %w", err)
+	}
+
+	return keys, nil
+
+}
+
+func (r *memberRepository) purgeMemberData(ctx context.Context, tx *sqlx.Tx, id uint) error {
+
+	for _, table := range memberTables {
+		query, args, err := sq.Delete(table).Where(sq.Eq{"member_id": id}).ToSql()
+		if err != nil {
+			return fmt.Errorf("[Member Repository] Failed to generate sql query: %w", err)
+		}
+
+		_, err = tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			return fmt.Errorf("[Member Repository] Failed to purge records from %s: %w", table, err)
+		}
+	}
+
+	// Mail headers are shared between every member that received the mail. Only the headers
+	// that are no longer referenced by another member are removed, recipients cascade.
+	var mailIDs = make([]uint, 0)
+	query, args, err := sq.Select("mail_id").From("member_mail_headers").Where(sq.Eq{"member_id": id}).ToSql()
+	if err != nil {
+		return fmt.Errorf("[Member Repository] Failed to generate sql query: %w", err)
+	}
+
+	err = tx.SelectContext(ctx, &mailIDs, query, args...)
+	if err != nil {
+		return fmt.Errorf("[Member Repository] Failed to fetch mail ids for member: %w", err)
+	}
+
+	query, args, err = sq.Delete("member_mail_headers").Where(sq.Eq{"member_id": id}).ToSql()
+	if err != nil {
+		return fmt.Errorf("[Member Repository] Failed to generate sql query: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("[Member Repository] Failed to purge records from member_mail_headers: %w", err)
+	}
+
+	if len(mailIDs) > 0 {
+		query, args, err = sq.Delete("mail_headers").Where(sq.And{
+			sq.Eq{"id": mailIDs},
+			sq.Expr("id NOT IN (SELECT mail_id FROM member_mail_headers)"),
+		}).ToSql()
+		if err != nil {
+			return fmt.Errorf("[Member Repository] Failed to generate sql query: %w", err)
+		}
+
+		_, err = tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			return fmt.Errorf("[Member Repository] Failed to purge orphaned mail headers: %w", err)
+		}
+	}
+
+	// Etags for authenticated character endpoints are keyed by the endpoint and the character id
+	query, args, err = sq.Delete("etags").Where(sq.Or{
+		sq.Like{"etag_id": fmt.Sprintf("GetCharacter%%::%d", id)},
+		sq.Like{"etag_id": fmt.Sprintf("GetCharacter%%::%d::%%", id)},
+	}).ToSql()
+	if err != nil {
+		return fmt.Errorf("[Member Repository] Failed to generate sql query: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("[Member Repository] Failed to purge etags: %w", err)
+	}
+
+	// Alts that were linked to this member as their main are unlinked
+	query, args, err = sq.Update(r.table).
+		Set("main_id", nil).
+		Set("updated_at", sq.Expr(`NOW()`)).
+		Where(sq.Eq{"main_id": id}).ToSql()
+	if err != nil {
+		return fmt.Errorf("[Member Repository] Failed to generate sql query: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("[Member Repository] Failed to unlink alts: %w", err)
+	}
+
+	return nil
+
+}
+
+// purgeMemberAPIKeys revokes the API keys that were created by the member and removes the member from
+// the keys that were granted access to it. A key without any allowed members may access every member,
+// so a key that was only granted access to this member is revoked instead of being left empty. The
+// hashes of every key that was changed are returned
+func (r *memberRepository) purgeMemberAPIKeys(ctx context.Context, tx *sqlx.Tx, id uint) ([]string, error) {
+
+	query, args, err := sq.Select("id", "hash", "allowed_members", "created_by").
+		From("api_keys").
+		Where(sq.Eq{"revoked": false}).
+		Where(sq.Or{
+			sq.Eq{"created_by": id},
+			sq.Expr("JSON_CONTAINS(allowed_members, CAST(? AS JSON))", strconv.FormatUint(uint64(id), 10)),
+		}).
+		Suffix("FOR UPDATE").ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("[Member Repository] Failed to generate sql query: %w", err)
+	}
+
+	var keys = make([]*athena.APIKey, 0)
+	err = tx.SelectContext(ctx, &keys, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("[Member Repository] Failed to fetch api keys of member: %w", err)
+	}
+
+	hashes := make([]string, 0, len(keys))
+	revoke := make([]uint, 0, len(keys))
+	for _, key := range keys {
+		hashes = append(hashes, key.Hash)
+
+		allowed := make(athena.SliceUint, 0, len(key.AllowedMembers))
+		for _, memberID := range key.AllowedMembers {
+			if memberID != uint64(id) {
+				allowed = append(allowed, memberID)
+			}
+		}
+
+		if (key.CreatedBy.Valid && key.CreatedBy.Uint == id) || len(allowed) == 0 {
+			revoke = append(revoke, key.ID)
+			continue
+		}
+
+		query, args, err = sq.Update("api_keys").
+			Set("allowed_members", allowed).
+			Set("updated_at", sq.Expr(`NOW()`)).
+			Where(sq.Eq{"id": key.ID}).ToSql()
+		if err != nil {
+			return nil, fmt.Errorf("[Member Repository] Failed to generate sql query: %w", err)
+		}
+
+		_, err = tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			return nil, fmt.Errorf("[Member Repository] Failed to remove member from api key: %w", err)
+		}
+	}
+
+	if len(revoke) > 0 {
+		query, args, err = sq.Update("api_keys").
+			Set("revoked", true).
+			Set("revoked_at", sq.Expr(`NOW()`)).
+			Set("updated_at", sq.Expr(`NOW()`)).
+			Where(sq.Eq{"id": revoke}).ToSql()
+		if err != nil {
+			return nil, fmt.Errorf("[Member Repository] Failed to generate sql query: %w", err)
+		}
+
+		_, err = tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			return nil, fmt.Errorf("[Member Repository] Failed to revoke api keys of member: %w", err)
+		}
+	}
+
+	return hashes, nil
+
+}
+
+func (r *memberRepository) recordAuditEntry(ctx context.Context, tx *sqlx.Tx, entry *athena.AuditEntry) error {
+
+	query, args, err := insertAuditEntryQuery("audit_log", entry)
+	if err != nil {
+		return fmt.Errorf("[Member Repository] Failed to generate sql query: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("[Member Repository] Failed to record audit entry: %w", err)
+	}
+
+	return nil
+
+}
@@ -0,0 +1,230 @@
+package mysqldb
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/eveisesi/athena"
+)
+
+// recordingDriver is a database/sql driver that records every statement that it is handed and answers
+// queries from a fixed set of results, so that the statements a repository issues can be inspected
+// without a MySQL server
+type recordingDriver struct {
+	mu         sync.Mutex
+	statements []recordedStatement
+	results    map[string]recordedRows
+	committed  bool
+}
+
+type recordedStatement struct {
+	query string
+	args  []driver.Value
+	inTx  bool
+}
+
+type recordedRows struct {
+	columns []string
+	values  [][]driver.Value
+}
+
+var testDrivers sync.Map
+
+func init() {
+	sql.Register("recording", driverFunc(func(name string) (driver.Conn, error) {
+		d, ok := testDrivers.Load(name)
+		if !ok {
+			return nil, errors.New("unknown recording driver")
+		}
+		return &recordingConn{driver: d.(*recordingDriver)}, nil
+	}))
+}
+
+type driverFunc func(name string) (driver.Conn, error)
+
+func (f driverFunc) Open(name string) (driver.Conn, error) { return f(name) }
+
+func newRecordingDB(t *testing.T, results map[string]recordedRows) (*sql.DB, *recordingDriver) {
+	t.Helper()
+
+	d := &recordingDriver{results: results}
+	testDrivers.Store(t.Name(), d)
+	t.Cleanup(func() { testDrivers.Delete(t.Name()) })
+
+	db, err := sql.Open("recording", t.Name())
+	if err != nil {
+		t.Fatalf("failed to open recording db: %s", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	return db, d
+}
+
+func (d *recordingDriver) record(query string, args []driver.NamedValue, inTx bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	values := make([]driver.Value, 0, len(args))
+	for _, arg := range args {
+		values = append(values, arg.Value)
+	}
+
+	d.statements = append(d.statements, recordedStatement{query: query, args: values, inTx: inTx})
+}
+
+// statement returns the first recorded statement that starts with prefix
+func (d *recordingDriver) statement(prefix string) (recordedStatement, bool) {
+	for _, s := range d.statements {
+		if strings.HasPrefix(s.query, prefix) {
+			return s, true
+		}
+	}
+
+	return recordedStatement{}, false
+}
+
+type recordingConn struct {
+	driver *recordingDriver
+	inTx   bool
+}
+
+func (c *recordingConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("prepared statements are not supported")
+}
+
+func (c *recordingConn) Close() error { return nil }
+
+func (c *recordingConn) Begin() (driver.Tx, error) {
+	c.inTx = true
+	return c, nil
+}
+
+func (c *recordingConn) Commit() error {
+	c.inTx = false
+	c.driver.committed = true
+	return nil
+}
+
+func (c *recordingConn) Rollback() error {
+	c.inTx = false
+	return nil
+}
+
+func (c *recordingConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.driver.record(query, args, c.inTx)
+	return driver.RowsAffected(1), nil
+}
+
+func (c *recordingConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.driver.record(query, args, c.inTx)
+
+	for prefix, rows := range c.driver.results {
+		if strings.HasPrefix(query, prefix) {
+			return &recordingRows{rows: rows}, nil
+		}
+	}
+
+	return &recordingRows{}, nil
+}
+
+type recordingRows struct {
+	rows recordedRows
+	next int
+}
+
+func (r *recordingRows) Columns() []string { return r.rows.columns }
+
+func (r *recordingRows) Close() error { return nil }
+
+func (r *recordingRows) Next(dest []driver.Value) error {
+	if r.next >= len(r.rows.values) {
+		return io.EOF
+	}
+
+	copy(dest, r.rows.values[r.next])
+	r.next++
+
+	return nil
+}
+
+func TestPurgeMemberRevokesAPIKeys(t *testing.T) {
+
+	const memberID uint = 90000001
+
+	db, recorder := newRecordingDB(t, map[string]recordedRows{
+		"SELECT id, hash, allowed_members, created_by FROM api_keys": {
+			columns: []string{"id", "hash", "allowed_members", "created_by"},
+			values: [][]driver.Value{
+				// Created by the member
+				{int64(1), "hash-1", []byte(`[90000001, 90000002]`), int64(memberID)},
+				// Shared between the member and another member
+				{int64(2), "hash-2", []byte(`[90000001, 90000002]`), int64(90000003)},
+				// Only granted access to the member
+				{int64(3), "hash-3", []byte(`[90000001]`), int64(90000003)},
+			},
+		},
+	})
+
+	repository := NewMemberRepository(db)
+	hashes, err := repository.PurgeMember(context.Background(), memberID, &athena.AuditEntry{
+		MemberID: memberID,
+		Action:   athena.MemberPurgedAuditAction,
+		Source:   athena.CLIAuditSource,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if want := []string{"hash-1", "hash-2", "hash-3"}; !reflect.DeepEqual(hashes, want) {
+		t.Errorf("hashes = %v, want %v", hashes, want)
+	}
+
+	if !recorder.committed {
+		t.Fatalf("purge was not committed")
+	}
+
+	selectKeys, ok := recorder.statement("SELECT id, hash, allowed_members, created_by FROM api_keys")
+	if !ok {
+		t.Fatalf("api keys of the member were not selected")
+	}
+	if !strings.Contains(selectKeys.query, "JSON_CONTAINS(allowed_members") || !strings.HasSuffix(selectKeys.query, "FOR UPDATE") {
+		t.Errorf("unexpected api key query: %s", selectKeys.query)
+	}
+
+	narrowed, ok := recorder.statement("UPDATE api_keys SET allowed_members = ?")
+	if !ok {
+		t.Fatalf("member was not removed from the allowed members of a shared key")
+	}
+	if got := string(narrowed.args[0].([]byte)); got != "[90000002]" {
+		t.Errorf("allowed_members = %s, want [90000002]", got)
+	}
+	if got := narrowed.args[len(narrowed.args)-1]; got != int64(2) {
+		t.Errorf("narrowed key = %v, want 2", got)
+	}
+
+	revoked, ok := recorder.statement("UPDATE api_keys SET revoked = ?")
+	if !ok {
+		t.Fatalf("keys of the member were not revoked")
+	}
+	if got := revoked.args[1:]; !reflect.DeepEqual(got, []driver.Value{int64(1), int64(3)}) {
+		t.Errorf("revoked keys = %v, want [1 3]", got)
+	}
+
+	for _, s := range recorder.statements {
+		if !s.inTx {
+			t.Errorf("statement was issued outside of the purge transaction: %s", s.query)
+		}
+	}
+
+	if _, ok := recorder.statement("INSERT INTO audit_log"); !ok {
+		t.Errorf("purge was not audited")
+	}
+
+}
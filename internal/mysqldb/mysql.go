@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 	"time"
 
 	sq "github.com/Masterminds/squirrel"
@@ -24,6 +25,11 @@ func New(ctx context.Context, host string, port int, user, pass, dbname string)
 		ReadTimeout:  time.Second * 5,
 		WriteTimeout: time.Second * 5,
 		ParseTime:    true,
+		// Pin the session to the location that the driver reads and writes times in, otherwise
+		// TIMESTAMP columns are converted from the time zone of the server
+		Params: map[string]string{
+			"time_zone": "'+00:00'",
+		},
 	}
 
 	db, err := sql.Open("mysql", config.FormatDSN())
@@ -42,38 +48,85 @@ func New(ctx context.Context, host string, port int, user, pass, dbname string)
 
 func BuildFilters(s sq.SelectBuilder, operators ...*athena.Operator) sq.SelectBuilder {
 	for _, a := range operators {
-		if !a.Operation.IsValid() {
+		if a == nil || !a.Operation.IsValid() {
 			continue
 		}
 
 		switch a.Operation {
-		case athena.EqualOp:
-			s = s.Where(sq.Eq{a.Column: a.Value})
-		case athena.NotEqualOp:
-			s = s.Where(sq.NotEq{a.Column: a.Value})
-		case athena.GreaterThanEqualToOp:
-			s = s.Where(sq.GtOrEq{a.Column: a.Value})
-		case athena.GreaterThanOp:
-			s = s.Where(sq.Gt{a.Column: a.Value})
-		case athena.LessThanEqualToOp:
-			s = s.Where(sq.LtOrEq{a.Column: a.Value})
-		case athena.LessThanOp:
-			s = s.Where(sq.Lt{a.Column: a.Value})
-		case athena.InOp:
-			s = s.Where(sq.Eq{a.Column: a.Value.(interface{})})
-		case athena.NotInOp:
-			s = s.Where(sq.NotEq{a.Column: a.Value.([]interface{})})
-		case athena.LikeOp:
-			s = s.Where(sq.Like{a.Column: fmt.Sprintf("%%%v%%", a.Value)})
 		case athena.OrderOp:
-			s = s.OrderBy(fmt.Sprintf("%s %s", a.Column, a.Value))
+			direction := "ASC"
+			if a.Value == athena.SortDesc.Value() {
+				direction = "DESC"
+			}
+			s = s.OrderBy(fmt.Sprintf("%s %s", a.Column, direction))
 		case athena.LimitOp:
 			s = s.Limit(uint64(a.Value.(int64)))
 		case athena.SkipOp:
 			s = s.Offset(uint64(a.Value.(int64)))
+		default:
+			if c := buildCondition(a); c != nil {
+				s = s.Where(c)
+			}
 		}
 	}
 
 	return s
 
 }
+
+// likeEscaper escapes the wildcards of a LIKE pattern so that values are matched literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func buildCondition(a *athena.Operator) sq.Sqlizer {
+	if a == nil || !a.Operation.IsValid() {
+		return nil
+	}
+
+	switch a.Operation {
+	case athena.EqualOp:
+		return sq.Eq{a.Column: a.Value}
+	case athena.NotEqualOp:
+		return sq.NotEq{a.Column: a.Value}
+	case athena.GreaterThanEqualToOp:
+		return sq.GtOrEq{a.Column: a.Value}
+	case athena.GreaterThanOp:
+		return sq.Gt{a.Column: a.Value}
+	case athena.LessThanEqualToOp:
+		return sq.LtOrEq{a.Column: a.Value}
+	case athena.LessThanOp:
+		return sq.Lt{a.Column: a.Value}
+	case athena.InOp:
+		return sq.Eq{a.Column: a.Value.(interface{})}
+	case athena.NotInOp:
+		return sq.NotEq{a.Column: a.Value}
+	case athena.LikeOp:
+		return sq.Like{a.Column: fmt.Sprintf("%%%s%%", likeEscaper.Replace(fmt.Sprintf("%v", a.Value)))}
+	case athena.PrefixOp:
+		return sq.Like{a.Column: fmt.Sprintf("%s%%", likeEscaper.Replace(fmt.Sprintf("%v", a.Value)))}
+	case athena.OrOp, athena.AndOp:
+		nested, ok := a.Value.([]*athena.Operator)
+		if !ok {
+			return nil
+		}
+
+		conditions := make([]sq.Sqlizer, 0, len(nested))
+		for _, n := range nested {
+			if c := buildCondition(n); c != nil {
+				conditions = append(conditions, c)
+			}
+		}
+
+		if len(conditions) == 0 {
+			return nil
+		}
+
+		if a.Operation == athena.OrOp {
+			return sq.Or(conditions)
+		}
+
+		return sq.And(conditions)
+	}
+
+	return nil
+
+}
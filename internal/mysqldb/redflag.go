@@ -0,0 +1,103 @@
+package mysqldb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/eveisesi/athena"
+	"github.com/jmoiron/sqlx"
+)
+
+const redFlagInsertBatchSize = 1000
+
+type redFlagRepository struct {
+	db    *sqlx.DB
+	flags string
+}
+
+func NewRedFlagRepository(db *sql.DB) athena.RedFlagRepository {
+	return &redFlagRepository{
+		db:    sqlx.NewDb(db, "mysql"),
+		flags: "member_red_flags",
+	}
+}
+
+func (r *redFlagRepository) MemberRedFlags(ctx context.Context, memberID uint, operators ...*athena.Operator) ([]*athena.MemberRedFlag, error) {
+
+	query, args, err := BuildFilters(sq.Select(
+		"member_id", "rule_id", "entity", "entity_id",
+		"severity", "description", "value",
+		"created_at", "updated_at",
+	).From(r.flags).Where(sq.Eq{"member_id": memberID}), operators...).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("[Red Flag Repository] Failed to generate query: %w", err)
+	}
+
+	var flags = make([]*athena.MemberRedFlag, 0)
+	err = r.db.SelectContext(ctx, &flags, query, args...)
+
+	return flags, err
+
+}
+
+// ReplaceMemberRedFlags swaps the red flags of the member for the provided flags inside of a single
+// transaction, so that readers never observe a partially evaluated set of flags
+func (r *redFlagRepository) ReplaceMemberRedFlags(ctx context.Context, memberID uint, flags []*athena.MemberRedFlag) ([]*athena.MemberRedFlag, error) {
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("[Red Flag Repository] Failed to start transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	query, args, err := sq.Delete(r.flags).Where(sq.Eq{"member_id": memberID}).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("[Red Flag Repository] Failed to generate query: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("[Red Flag Repository] Failed to delete records: %w", err)
+	}
+
+	// Flags are inserted in batches to stay clear of the placeholder limit of a single statement
+	for start := 0; start < len(flags); start += redFlagInsertBatchSize {
+		end := start + redFlagInsertBatchSize
+		if end > len(flags) {
+			end = len(flags)
+		}
+
+		i := sq.Insert(r.flags).Columns(
+			"member_id", "rule_id", "entity", "entity_id",
+			"severity", "description", "value",
+			"created_at", "updated_at",
+		)
+		for _, flag := range flags[start:end] {
+			i = i.Values(
+				memberID, flag.RuleID, flag.Entity, flag.EntityID,
+				flag.Severity, flag.Description, flag.Value,
+				flag.CreatedAt, sq.Expr(`NOW()`),
+			)
+		}
+
+		query, args, err = i.ToSql()
+		if err != nil {
+			return nil, fmt.Errorf("[Red Flag Repository] Failed to generate query: %w", err)
+		}
+
+		_, err = tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			return nil, fmt.Errorf("[Red Flag Repository] Failed to insert records: %w", err)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, fmt.Errorf("[Red Flag Repository] Failed to commit transaction: %w", err)
+	}
+
+	return r.MemberRedFlags(ctx, memberID)
+
+}
@@ -344,7 +344,7 @@ func (r *skillRepository) MemberAttributes(ctx context.Context, id uint) (*athen
 	}
 
 	var attributes = new(athena.MemberAttributes)
-	err = r.db.GetContext(ctx, &attributes, query, args...)
+	err = r.db.GetContext(ctx, attributes, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("[Skill Repository] Failed to fetch member attributes: %w", err)
 	}
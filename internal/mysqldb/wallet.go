@@ -105,6 +105,20 @@ func (r *memberWalletRepository) MemberWalletTransactions(ctx context.Context, o
 
 }
 
+func (r *memberWalletRepository) CountMemberWalletTransactions(ctx context.Context, operators ...*athena.Operator) (uint, error) {
+
+	query, args, err := BuildFilters(sq.Select("COUNT(*)").From(r.transactions), operators...).ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("[Wallet Repository] Failed to generate query: %w", err)
+	}
+
+	var count uint
+	err = r.db.GetContext(ctx, &count, query, args...)
+
+	return count, err
+
+}
+
 func (r *memberWalletRepository) CreateMemberWalletTransactions(ctx context.Context, memberID uint, transactions []*athena.MemberWalletTransaction) ([]*athena.MemberWalletTransaction, error) {
 
 	i := sq.Insert(r.transactions).Options("IGNORE").Columns(
@@ -163,6 +177,20 @@ func (r *memberWalletRepository) MemberWalletJournals(ctx context.Context, opera
 
 }
 
+func (r *memberWalletRepository) CountMemberWalletJournals(ctx context.Context, operators ...*athena.Operator) (uint, error) {
+
+	query, args, err := BuildFilters(sq.Select("COUNT(*)").From(r.journals), operators...).ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("[Wallet Repository] Failed to generate query: %w", err)
+	}
+
+	var count uint
+	err = r.db.GetContext(ctx, &count, query, args...)
+
+	return count, err
+
+}
+
 func (r *memberWalletRepository) CreateMemberWalletJournals(ctx context.Context, memberID uint, journals []*athena.MemberWalletJournal) ([]*athena.MemberWalletJournal, error) {
 
 	i := sq.Insert(r.journals).Options("IGNORE").Columns(
@@ -2,15 +2,23 @@ package processor
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/eveisesi/athena"
 	"github.com/eveisesi/athena/internal/cache"
+	"github.com/eveisesi/athena/internal/esi"
 	"github.com/eveisesi/athena/internal/member"
+	"github.com/eveisesi/athena/internal/redflag"
 	"github.com/korovkin/limiter"
 	"github.com/sirupsen/logrus"
 )
 
+// scopeRevocationThreshold is the number of consecutive 403s ESI may return for a scope
+// before the scope is marked as revoked and its resolvers are no longer scheduled
+const scopeRevocationThreshold = 3
+
 type Service interface {
 	Run()
 	SetScopeMap(athena.ScopeMap)
@@ -19,19 +27,23 @@ type Service interface {
 type service struct {
 	logger *logrus.Logger
 
-	cache  cache.Service
-	member member.Service
+	cache   cache.Service
+	esi     esi.Service
+	member  member.Service
+	redflag redflag.Service
 
 	scopes athena.ScopeMap
 }
 
-func NewService(logger *logrus.Logger, cache cache.Service, member member.Service) Service {
+func NewService(logger *logrus.Logger, cache cache.Service, esi esi.Service, member member.Service, redflag redflag.Service) Service {
 
 	s := &service{
 		logger: logger,
 
-		cache:  cache,
-		member: member,
+		cache:   cache,
+		esi:     esi,
+		member:  member,
+		redflag: redflag,
 	}
 
 	return s
@@ -53,6 +65,20 @@ func (s *service) Run() {
 	for {
 		ctx := context.Background()
 
+		// Refresh jobs have been requested on demand by somebody that is waiting
+		// on them, so they are always processed before the regular queue
+		jobs, err := s.cache.PopRefreshJobs(ctx, 10)
+		if err != nil {
+			s.logger.WithError(err).Errorln("[processor.Run]")
+		}
+
+		for _, job := range jobs {
+			job := job
+			limit.Execute(func() {
+				s.processRefreshJob(ctx, job)
+			})
+		}
+
 		count, err := s.cache.ProcessorQueueCount(ctx)
 		if err != nil {
 			s.logger.WithError(err).Errorln("[processor.Run]")
@@ -61,8 +87,10 @@ func (s *service) Run() {
 		}
 
 		if count == 0 {
-			s.logger.Debug("record count is 0, sleep 5 seconds")
-			time.Sleep(time.Second * 5)
+			if len(jobs) == 0 {
+				s.logger.Debug("record count is 0, sleep 1 second")
+				time.Sleep(time.Second)
+			}
 			continue
 		}
 
@@ -74,6 +102,7 @@ func (s *service) Run() {
 		}
 
 		for _, result := range results {
+			result := result
 			limit.Execute(func() {
 				s.processMember(ctx, result)
 			})
@@ -101,10 +130,16 @@ func (s *service) processMember(ctx context.Context, memberID uint) {
 	})
 
 	// Member Retrieve successfully. Loop over the scopes array calling the functions in the scope map
+	synced := false
 	for i, scope := range member.Scopes {
 
 		entry := entry.WithField("scope", scope.Scope)
 
+		if scope.IsRevoked() {
+			entry.Debug("skipping revoked scope")
+			continue
+		}
+
 		// If the scope expiry is valid, that means it has previously been called,
 		// and if the expiry is after the current time, that means that the cache timer
 		// has not expired yet, so attempting to update the data now will not yield any fresh results
@@ -120,34 +155,216 @@ func (s *service) processMember(ctx context.Context, memberID uint) {
 			continue
 		}
 
-		for _, resolver := range s.scopes[scope.Scope] {
-			entry := entry.WithField("name", resolver.Name)
-			entry.Info()
+		scope, _ = s.processScope(ctx, entry, member, scope)
+
+		member.Scopes[i] = scope
+		synced = true
+
+	}
+
+	_, err = s.member.UpdateMemberScopes(ctx, member.ID, member.Scopes)
+	if err != nil {
+		entry.WithError(err).Error("failed to update member")
+	}
+
+	if synced {
+		s.evaluateRedFlags(ctx, entry, member.ID)
+	}
+
+	entry.Info("member processed successfully")
+
+}
+
+// processScope calls the resolvers of the scope for the member and returns the scope with its expiry
+// and failures updated. The error of the last resolver that failed is returned along with the scope
+func (s *service) processScope(ctx context.Context, entry *logrus.Entry, member *athena.Member, scope athena.MemberScope) (athena.MemberScope, error) {
+
+	var failure error
+	forbidden := false
+	for _, resolver := range s.scopes[scope.Scope] {
+		entry := entry.WithField("name", resolver.Name)
+		entry.Info()
+
+		etag, err := resolver.Func(ctx, member)
+		if err != nil {
+			entry.WithError(err).Errorln()
+			if errors.As(err, &esi.ForbiddenError{}) {
+				forbidden = true
+			}
+			failure = err
+			continue
+		}
+
+		entry.Info("scope resolved successfully")
+
+		if etag != nil {
+			scope.Expiry.SetValid(etag.CachedUntil)
+		}
+	}
+
+	if forbidden {
+		scope.Failures++
+		if scope.Failures >= scopeRevocationThreshold {
+			scope.Revoked.SetValid(time.Now())
+			entry.WithField("failures", scope.Failures).Warn("scope has been revoked by member")
+		}
+	} else {
+		scope.Failures = 0
+	}
+
+	return scope, failure
+
+}
+
+// processRefreshJob syncs the scopes of a refresh job one at a time, publishing the progress of the
+// job every time the status of one of its scopes changes
+func (s *service) processRefreshJob(ctx context.Context, job *athena.RefreshJob) {
+
+	entry := s.logger.WithFields(logrus.Fields{
+		"member": job.MemberID,
+		"job":    job.ID,
+	})
+
+	job.Status = athena.RefreshJobProcessing
+	s.publishRefreshJob(ctx, entry, job)
+
+	member, err := s.member.Member(ctx, job.MemberID)
+	if err == nil && member == nil {
+		err = fmt.Errorf("member %d does not exist", job.MemberID)
+	}
+	if err == nil {
+		member, err = s.member.ValidateToken(ctx, member)
+	}
+	if err != nil {
+		entry.WithError(err).Errorln("failed to prepare member for refresh")
+		s.failRefreshJob(ctx, entry, job, err)
+		return
+	}
 
-			etag, err := resolver.Func(ctx, member)
+	scopes := make(map[athena.Scope]int, len(member.Scopes))
+	for i, scope := range member.Scopes {
+		scopes[scope.Scope] = i
+	}
+
+	for _, progress := range job.Scopes {
+
+		entry := entry.WithField("scope", progress.Scope)
+
+		i, ok := scopes[progress.Scope]
+		switch {
+		case !ok:
+			progress.SetStatus(athena.RefreshScopeSkipped, "scope has not been granted by member")
+		case member.Scopes[i].IsRevoked():
+			progress.SetStatus(athena.RefreshScopeSkipped, "scope has been revoked by member")
+		case len(s.scopes[progress.Scope]) == 0:
+			progress.SetStatus(athena.RefreshScopeSkipped, "scope is not supported")
+		case !job.Force && member.Scopes[i].Expiry.Valid && member.Scopes[i].Expiry.Time.After(time.Now()):
+			progress.SetStatus(athena.RefreshScopeSkipped, fmt.Sprintf("scope is cached until %s", member.Scopes[i].Expiry.Time.Format(time.RFC3339)))
+		}
+
+		if progress.Status == athena.RefreshScopeSkipped {
+			s.publishRefreshJob(ctx, entry, job)
+			continue
+		}
+
+		progress.SetStatus(athena.RefreshScopeProcessing, "")
+		s.publishRefreshJob(ctx, entry, job)
+
+		if job.Force {
+			err = s.resetScopeEtags(ctx, member, progress.Scope)
 			if err != nil {
-				entry.WithError(err).Errorln()
+				entry.WithError(err).Error("failed to reset etags of scope")
+				progress.SetStatus(athena.RefreshScopeFailed, "failed to reset etags of scope")
+				s.publishRefreshJob(ctx, entry, job)
 				continue
 			}
+		}
 
-			entry.Info("scope resolved successfully")
+		member.Scopes[i], err = s.processScope(ctx, entry, member, member.Scopes[i])
+		if err != nil {
+			progress.SetStatus(athena.RefreshScopeFailed, err.Error())
+		} else {
+			progress.SetStatus(athena.RefreshScopeCompleted, "")
+		}
 
-			if etag != nil {
-				scope.Expiry.SetValid(etag.CachedUntil)
-			}
+		s.publishRefreshJob(ctx, entry, job)
 
-			member.Scopes[i] = scope
+	}
 
-			// time.Sleep(time.Second)
+	_, err = s.member.UpdateMemberScopes(ctx, member.ID, member.Scopes)
+	if err != nil {
+		entry.WithError(err).Error("failed to update member")
+	}
+
+	s.evaluateRedFlags(ctx, entry, member.ID)
+
+	job.Status = athena.RefreshJobCompleted
+	job.CompletedAt.SetValid(time.Now())
+	s.publishRefreshJob(ctx, entry, job)
+
+	entry.Info("refresh job processed successfully")
+
+}
+
+// evaluateRedFlags re-evaluates the red flag rules against the data of the member that has just been
+// synced. Failures are logged, the data itself has been synced successfully regardless
+func (s *service) evaluateRedFlags(ctx context.Context, entry *logrus.Entry, memberID uint) {
+
+	flags, err := s.redflag.EvaluateMember(ctx, memberID)
+	if err != nil {
+		entry.WithError(err).Error("failed to evaluate red flags")
+		return
+	}
+
+	entry.WithField("red_flags", len(flags)).Debug("red flags evaluated")
+
+}
+
+func (s *service) resetScopeEtags(ctx context.Context, member *athena.Member, scope athena.Scope) error {
+
+	etags, err := s.esi.ScopeEtags(ctx, scope, member.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, etag := range etags {
+		err = s.esi.ResetEtag(ctx, etag)
+		if err != nil {
+			return err
 		}
+	}
 
+	return nil
+
+}
+
+// failRefreshJob marks every scope of the job that has not been processed yet as failed along with
+// the job itself
+func (s *service) failRefreshJob(ctx context.Context, entry *logrus.Entry, job *athena.RefreshJob, err error) {
+
+	for _, progress := range job.Scopes {
+		if progress.Status == athena.RefreshScopePending || progress.Status == athena.RefreshScopeProcessing {
+			progress.SetStatus(athena.RefreshScopeFailed, err.Error())
+		}
 	}
 
-	_, err = s.member.UpdateMember(ctx, member)
+	job.Status = athena.RefreshJobFailed
+	job.Error.SetValid(err.Error())
+	job.CompletedAt.SetValid(time.Now())
+	s.publishRefreshJob(ctx, entry, job)
+
+}
+
+func (s *service) publishRefreshJob(ctx context.Context, entry *logrus.Entry, job *athena.RefreshJob) {
+
+	err := s.cache.SetRefreshJob(ctx, job)
 	if err != nil {
-		entry.WithError(err).Error("failed to update member")
+		entry.WithError(err).Error("failed to cache refresh job")
 	}
 
-	entry.Info("member processed successfully")
+	err = s.cache.PublishRefreshJob(ctx, job)
+	if err != nil {
+		entry.WithError(err).Error("failed to publish refresh job")
+	}
 
 }
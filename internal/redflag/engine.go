@@ -0,0 +1,444 @@
+package redflag
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eveisesi/athena"
+	"github.com/volatiletech/null"
+)
+
+type fieldKind int
+
+const (
+	kindUnknown fieldKind = iota
+	kindNumber
+	kindString
+	kindBool
+)
+
+// entityFields are the fields that rules may reference on every entity along with the kind of
+// value that the field holds
+var entityFields = map[athena.RedFlagEntity]map[string]fieldKind{
+	athena.RedFlagEntityWalletJournal: {
+		"ref_type":          kindString,
+		"amount":            kindNumber,
+		"balance":           kindNumber,
+		"description":       kindString,
+		"reason":            kindString,
+		"first_party_id":    kindNumber,
+		"second_party_id":   kindNumber,
+		"counterparty_id":   kindNumber,
+		"counterparty_type": kindString,
+		"age_days":          kindNumber,
+	},
+	athena.RedFlagEntityContact: {
+		"contact_id":   kindNumber,
+		"contact_type": kindString,
+		"standing":     kindNumber,
+		"is_blocked":   kindBool,
+		"is_watched":   kindBool,
+	},
+	athena.RedFlagEntityContract: {
+		"type":            kindString,
+		"status":          kindString,
+		"title":           kindString,
+		"price":           kindNumber,
+		"reward":          kindNumber,
+		"collateral":      kindNumber,
+		"issuer_id":       kindNumber,
+		"assignee_id":     kindNumber,
+		"acceptor_id":     kindNumber,
+		"for_corporation": kindBool,
+		"age_days":        kindNumber,
+	},
+	athena.RedFlagEntityMail: {
+		"subject":     kindString,
+		"body":        kindString,
+		"sender_id":   kindNumber,
+		"sender_type": kindString,
+		"age_days":    kindNumber,
+	},
+	athena.RedFlagEntityCorporationHistory: {
+		"corporation_id": kindNumber,
+		"tenure_days":    kindNumber,
+		"age_days":       kindNumber,
+		"is_deleted":     kindBool,
+		"is_current":     kindBool,
+	},
+}
+
+// record is a single row of an entity flattened into the fields that rules are evaluated against.
+// Fields without a value are nil and never match a condition
+type record struct {
+	id     string
+	fields map[string]interface{}
+}
+
+func kindOf(value interface{}) fieldKind {
+	switch value.(type) {
+	case float64:
+		return kindNumber
+	case string:
+		return kindString
+	case bool:
+		return kindBool
+	}
+
+	return kindUnknown
+}
+
+// entityRules returns the rules that are evaluated against the records of entity
+func entityRules(rules []*athena.RedFlagRule, entity athena.RedFlagEntity) []*athena.RedFlagRule {
+
+	matches := make([]*athena.RedFlagRule, 0, len(rules))
+	for _, rule := range rules {
+		if rule.Entity == entity {
+			matches = append(matches, rule)
+		}
+	}
+
+	return matches
+
+}
+
+// recordCutoff returns the time before which no record can match any of rules, which must all be of
+// the same entity. The cutoff is only valid when every rule bounds the age_days of a record
+func recordCutoff(rules []*athena.RedFlagRule, now time.Time) null.Time {
+
+	if len(rules) == 0 {
+		return null.Time{}
+	}
+
+	var oldest float64
+	for i, rule := range rules {
+		days, ok := maxAgeDays(rule)
+		if !ok {
+			return null.Time{}
+		}
+
+		if i == 0 || days > oldest {
+			oldest = days
+		}
+	}
+
+	// age_days counts whole days, so a record is up to a day older than the age that it reports
+	return null.TimeFrom(now.Add(-time.Duration(math.Floor(oldest)+1) * time.Hour * 24))
+
+}
+
+// maxAgeDays returns the largest age_days that a record may have and still match the rule. False is
+// returned when the rule matches records of any age
+func maxAgeDays(rule *athena.RedFlagRule) (float64, bool) {
+
+	conditions := append([]*athena.RedFlagCondition{{
+		Field:     rule.Field,
+		Operator:  rule.Operator,
+		Threshold: rule.Threshold,
+	}}, rule.Where...)
+
+	var max float64
+	bounded := false
+	for _, condition := range conditions {
+		if condition.Field != "age_days" {
+			continue
+		}
+
+		var thresholds []interface{}
+		switch condition.Operator {
+		case athena.RedFlagOperatorEqual, athena.RedFlagOperatorLessThan, athena.RedFlagOperatorLessThanEqualTo:
+			thresholds = []interface{}{condition.Threshold}
+		case athena.RedFlagOperatorIn:
+			thresholds, _ = condition.Threshold.([]interface{})
+		default:
+			continue
+		}
+
+		limit, ok := maxNumber(thresholds)
+		if !ok {
+			continue
+		}
+
+		// Every condition has to hold, so the tightest bound applies
+		if !bounded || limit < max {
+			max, bounded = limit, true
+		}
+	}
+
+	return max, bounded
+
+}
+
+func maxNumber(values []interface{}) (float64, bool) {
+
+	var max float64
+	found := false
+	for _, value := range values {
+		v, ok := value.(float64)
+		if !ok {
+			return 0, false
+		}
+
+		if !found || v > max {
+			max, found = v, true
+		}
+	}
+
+	return max, found
+
+}
+
+// matchRule reports whether the record satisfies the condition of the rule and each of its where
+// conditions
+func matchRule(rule *athena.RedFlagRule, r *record) bool {
+
+	if !match(r.fields[rule.Field], rule.Operator, rule.Threshold) {
+		return false
+	}
+
+	for _, condition := range rule.Where {
+		if !match(r.fields[condition.Field], condition.Operator, condition.Threshold) {
+			return false
+		}
+	}
+
+	return true
+
+}
+
+func match(value interface{}, operator athena.RedFlagOperator, threshold interface{}) bool {
+
+	if value == nil {
+		return false
+	}
+
+	switch operator {
+	case athena.RedFlagOperatorEqual:
+		return equal(value, threshold)
+	case athena.RedFlagOperatorNotEqual:
+		return !equal(value, threshold)
+	case athena.RedFlagOperatorGreaterThan, athena.RedFlagOperatorGreaterThanEqualTo,
+		athena.RedFlagOperatorLessThan, athena.RedFlagOperatorLessThanEqualTo:
+		v, ok := value.(float64)
+		t, tok := threshold.(float64)
+		if !ok || !tok {
+			return false
+		}
+
+		switch operator {
+		case athena.RedFlagOperatorGreaterThan:
+			return v > t
+		case athena.RedFlagOperatorGreaterThanEqualTo:
+			return v >= t
+		case athena.RedFlagOperatorLessThan:
+			return v < t
+		default:
+			return v <= t
+		}
+	case athena.RedFlagOperatorContains, athena.RedFlagOperatorNotContains:
+		v, ok := value.(string)
+		t, tok := threshold.(string)
+		if !ok || !tok {
+			return false
+		}
+
+		contains := strings.Contains(strings.ToLower(v), strings.ToLower(t))
+
+		return contains == (operator == athena.RedFlagOperatorContains)
+	case athena.RedFlagOperatorIn, athena.RedFlagOperatorNotIn:
+		values, _ := threshold.([]interface{})
+
+		found := false
+		for _, t := range values {
+			if equal(value, t) {
+				found = true
+				break
+			}
+		}
+
+		return found == (operator == athena.RedFlagOperatorIn)
+	}
+
+	return false
+
+}
+
+// equal compares the value of a field with a threshold. Text is compared case insensitively
+func equal(value, threshold interface{}) bool {
+
+	if v, ok := value.(string); ok {
+		t, ok := threshold.(string)
+		return ok && strings.EqualFold(v, t)
+	}
+
+	return value == threshold
+
+}
+
+// formatValue renders the value of a field for storage alongside a red flag
+func formatValue(value interface{}) string {
+
+	switch v := value.(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case string:
+		v = strings.Join(strings.Fields(v), " ")
+		if runes := []rune(v); len(runes) > maxRuleDescriptionLength {
+			v = string(runes[:maxRuleDescriptionLength-3]) + "..."
+		}
+		return v
+	}
+
+	return ""
+
+}
+
+func ageDays(t, now time.Time) float64 {
+	return float64(int(now.Sub(t).Hours() / 24))
+}
+
+func nullUint(v null.Uint) interface{} {
+	if !v.Valid {
+		return nil
+	}
+
+	return float64(v.Uint)
+}
+
+func nullFloat(v null.Float64) interface{} {
+	if !v.Valid {
+		return nil
+	}
+
+	return v.Float64
+}
+
+func nullString(v null.String) interface{} {
+	if !v.Valid {
+		return nil
+	}
+
+	return v.String
+}
+
+func walletJournalRecords(memberID uint, entries []*athena.MemberWalletJournal, now time.Time) []*record {
+
+	records := make([]*record, 0, len(entries))
+	for _, entry := range entries {
+
+		// The counterparty is whichever party of the entry is not the member
+		counterpartyID, counterpartyType := entry.FirstPartyID, entry.FirstPartyType
+		if entry.FirstPartyID.Valid && entry.FirstPartyID.Uint == memberID {
+			counterpartyID, counterpartyType = entry.SecondPartyID, entry.SecondPartyType
+		}
+
+		records = append(records, &record{
+			id: strconv.FormatUint(entry.JournalID, 10),
+			fields: map[string]interface{}{
+				"ref_type":          string(entry.RefType),
+				"amount":            nullFloat(entry.Amount),
+				"balance":           nullFloat(entry.Balance),
+				"description":       entry.Description,
+				"reason":            nullString(entry.Reason),
+				"first_party_id":    nullUint(entry.FirstPartyID),
+				"second_party_id":   nullUint(entry.SecondPartyID),
+				"counterparty_id":   nullUint(counterpartyID),
+				"counterparty_type": nullString(counterpartyType),
+				"age_days":          ageDays(entry.Date, now),
+			},
+		})
+	}
+
+	return records
+
+}
+
+func contactRecords(contacts []*athena.MemberContact) []*record {
+
+	records := make([]*record, 0, len(contacts))
+	for _, contact := range contacts {
+		records = append(records, &record{
+			id: strconv.FormatUint(uint64(contact.ContactID), 10),
+			fields: map[string]interface{}{
+				"contact_id":   float64(contact.ContactID),
+				"contact_type": contact.ContactType,
+				"standing":     contact.Standing,
+				"is_blocked":   contact.IsBlocked,
+				"is_watched":   contact.IsWatched,
+			},
+		})
+	}
+
+	return records
+
+}
+
+func contractRecords(contracts []*athena.MemberContract, now time.Time) []*record {
+
+	records := make([]*record, 0, len(contracts))
+	for _, contract := range contracts {
+		records = append(records, &record{
+			id: strconv.FormatUint(uint64(contract.ContractID), 10),
+			fields: map[string]interface{}{
+				"type":            string(contract.Type),
+				"status":          string(contract.Status),
+				"title":           nullString(contract.Title),
+				"price":           nullFloat(contract.Price),
+				"reward":          nullFloat(contract.Reward),
+				"collateral":      nullFloat(contract.Collateral),
+				"issuer_id":       float64(contract.IssuerID),
+				"assignee_id":     nullUint(contract.AssigneeID),
+				"acceptor_id":     nullUint(contract.AcceptorID),
+				"for_corporation": contract.ForCorporation,
+				"age_days":        ageDays(contract.DateIssued, now),
+			},
+		})
+	}
+
+	return records
+
+}
+
+func mailRecords(headers []*athena.MailHeader, now time.Time) []*record {
+
+	records := make([]*record, 0, len(headers))
+	for _, header := range headers {
+		records = append(records, &record{
+			id: strconv.FormatUint(uint64(header.MailID), 10),
+			fields: map[string]interface{}{
+				"subject":     nullString(header.Subject),
+				"body":        nullString(header.Body),
+				"sender_id":   nullUint(header.Sender),
+				"sender_type": nullString(header.SenderType),
+				"age_days":    ageDays(header.Timestamp, now),
+			},
+		})
+	}
+
+	return records
+
+}
+
+func corporationHistoryRecords(history []*athena.CharacterCorporationHistory, now time.Time) []*record {
+
+	records := make([]*record, 0, len(history))
+	for _, h := range history {
+		records = append(records, &record{
+			id: strconv.FormatUint(h.RecordID, 10),
+			fields: map[string]interface{}{
+				"corporation_id": float64(h.CorporationID),
+				"tenure_days":    float64(int(h.Tenure().Hours() / 24)),
+				"age_days":       ageDays(h.StartDate, now),
+				"is_deleted":     h.IsDeleted,
+				"is_current":     !h.EndDate.Valid,
+			},
+		})
+	}
+
+	return records
+
+}
@@ -0,0 +1,215 @@
+package redflag
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eveisesi/athena"
+)
+
+func TestMatchRule(t *testing.T) {
+
+	fields := map[string]interface{}{
+		"ref_type":          "player_donation",
+		"amount":            float64(-1500000000),
+		"reason":            nil,
+		"counterparty_id":   float64(90000002),
+		"counterparty_type": "Character",
+		"age_days":          float64(12),
+		"is_blocked":        true,
+	}
+
+	tests := []struct {
+		name string
+		rule *athena.RedFlagRule
+		want bool
+	}{
+		{
+			name: "less than or equal to",
+			rule: &athena.RedFlagRule{Field: "amount", Operator: athena.RedFlagOperatorLessThanEqualTo, Threshold: float64(-1000000000)},
+			want: true,
+		},
+		{
+			name: "greater than",
+			rule: &athena.RedFlagRule{Field: "amount", Operator: athena.RedFlagOperatorGreaterThan, Threshold: float64(0)},
+			want: false,
+		},
+		{
+			name: "text is compared case insensitively",
+			rule: &athena.RedFlagRule{Field: "counterparty_type", Operator: athena.RedFlagOperatorEqual, Threshold: "character"},
+			want: true,
+		},
+		{
+			name: "not equal",
+			rule: &athena.RedFlagRule{Field: "counterparty_type", Operator: athena.RedFlagOperatorNotEqual, Threshold: "character"},
+			want: false,
+		},
+		{
+			name: "contains",
+			rule: &athena.RedFlagRule{Field: "ref_type", Operator: athena.RedFlagOperatorContains, Threshold: "DONATION"},
+			want: true,
+		},
+		{
+			name: "not contains",
+			rule: &athena.RedFlagRule{Field: "ref_type", Operator: athena.RedFlagOperatorNotContains, Threshold: "donation"},
+			want: false,
+		},
+		{
+			name: "in",
+			rule: &athena.RedFlagRule{Field: "counterparty_id", Operator: athena.RedFlagOperatorIn, Threshold: []interface{}{float64(90000001), float64(90000002)}},
+			want: true,
+		},
+		{
+			name: "in without the value",
+			rule: &athena.RedFlagRule{Field: "counterparty_id", Operator: athena.RedFlagOperatorIn, Threshold: []interface{}{float64(90000001)}},
+			want: false,
+		},
+		{
+			name: "in with text",
+			rule: &athena.RedFlagRule{Field: "counterparty_type", Operator: athena.RedFlagOperatorIn, Threshold: []interface{}{"corporation", "CHARACTER"}},
+			want: true,
+		},
+		{
+			name: "not in",
+			rule: &athena.RedFlagRule{Field: "counterparty_id", Operator: athena.RedFlagOperatorNotIn, Threshold: []interface{}{float64(90000001)}},
+			want: true,
+		},
+		{
+			name: "not in with the value",
+			rule: &athena.RedFlagRule{Field: "counterparty_id", Operator: athena.RedFlagOperatorNotIn, Threshold: []interface{}{float64(90000002)}},
+			want: false,
+		},
+		{
+			name: "bool",
+			rule: &athena.RedFlagRule{Field: "is_blocked", Operator: athena.RedFlagOperatorEqual, Threshold: true},
+			want: true,
+		},
+		{
+			name: "nil field never matches",
+			rule: &athena.RedFlagRule{Field: "reason", Operator: athena.RedFlagOperatorNotEqual, Threshold: "ransom"},
+			want: false,
+		},
+		{
+			name: "nil field never matches not in",
+			rule: &athena.RedFlagRule{Field: "reason", Operator: athena.RedFlagOperatorNotIn, Threshold: []interface{}{"ransom"}},
+			want: false,
+		},
+		{
+			name: "missing field never matches",
+			rule: &athena.RedFlagRule{Field: "balance", Operator: athena.RedFlagOperatorLessThan, Threshold: float64(0)},
+			want: false,
+		},
+		{
+			name: "every where condition must hold",
+			rule: &athena.RedFlagRule{
+				Field: "amount", Operator: athena.RedFlagOperatorLessThan, Threshold: float64(0),
+				Where: []*athena.RedFlagCondition{
+					{Field: "ref_type", Operator: athena.RedFlagOperatorEqual, Threshold: "player_donation"},
+					{Field: "age_days", Operator: athena.RedFlagOperatorLessThanEqualTo, Threshold: float64(7)},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "where conditions hold",
+			rule: &athena.RedFlagRule{
+				Field: "amount", Operator: athena.RedFlagOperatorLessThan, Threshold: float64(0),
+				Where: []*athena.RedFlagCondition{
+					{Field: "ref_type", Operator: athena.RedFlagOperatorEqual, Threshold: "player_donation"},
+					{Field: "age_days", Operator: athena.RedFlagOperatorLessThanEqualTo, Threshold: float64(30)},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "nil where field never matches",
+			rule: &athena.RedFlagRule{
+				Field: "amount", Operator: athena.RedFlagOperatorLessThan, Threshold: float64(0),
+				Where: []*athena.RedFlagCondition{
+					{Field: "reason", Operator: athena.RedFlagOperatorNotContains, Threshold: "ransom"},
+				},
+			},
+			want: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := matchRule(test.rule, &record{id: "1", fields: fields}); got != test.want {
+				t.Errorf("matchRule() = %t, want %t", got, test.want)
+			}
+		})
+	}
+
+}
+
+func TestRecordCutoff(t *testing.T) {
+
+	now := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+	day := time.Hour * 24
+
+	bounded := func(operator athena.RedFlagOperator, threshold interface{}) *athena.RedFlagRule {
+		return &athena.RedFlagRule{
+			Field: "amount", Operator: athena.RedFlagOperatorLessThan, Threshold: float64(0),
+			Where: []*athena.RedFlagCondition{{Field: "age_days", Operator: operator, Threshold: threshold}},
+		}
+	}
+
+	tests := []struct {
+		name  string
+		rules []*athena.RedFlagRule
+		want  time.Time
+	}{
+		{
+			name:  "less than or equal to",
+			rules: []*athena.RedFlagRule{bounded(athena.RedFlagOperatorLessThanEqualTo, float64(30))},
+			want:  now.Add(-31 * day),
+		},
+		{
+			name:  "in",
+			rules: []*athena.RedFlagRule{bounded(athena.RedFlagOperatorIn, []interface{}{float64(1), float64(7)})},
+			want:  now.Add(-8 * day),
+		},
+		{
+			name:  "the oldest bound of the rules applies",
+			rules: []*athena.RedFlagRule{bounded(athena.RedFlagOperatorLessThan, float64(7)), bounded(athena.RedFlagOperatorLessThan, float64(90))},
+			want:  now.Add(-91 * day),
+		},
+		{
+			name: "the tightest bound of a rule applies",
+			rules: []*athena.RedFlagRule{{
+				Field: "age_days", Operator: athena.RedFlagOperatorLessThan, Threshold: float64(90),
+				Where: []*athena.RedFlagCondition{{Field: "age_days", Operator: athena.RedFlagOperatorLessThan, Threshold: float64(10)}},
+			}},
+			want: now.Add(-11 * day),
+		},
+		{
+			name:  "lower bounds do not limit the age",
+			rules: []*athena.RedFlagRule{bounded(athena.RedFlagOperatorGreaterThan, float64(30))},
+		},
+		{
+			name:  "a rule without a bound loads every record",
+			rules: []*athena.RedFlagRule{bounded(athena.RedFlagOperatorLessThan, float64(7)), {Field: "amount", Operator: athena.RedFlagOperatorLessThan, Threshold: float64(0)}},
+		},
+		{
+			name: "no rules",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := recordCutoff(test.rules, now)
+			if test.want.IsZero() {
+				if got.Valid {
+					t.Errorf("cutoff = %s, want none", got.Time)
+				}
+				return
+			}
+
+			if !got.Valid || !got.Time.Equal(test.want) {
+				t.Errorf("cutoff = %v, want %s", got, test.want)
+			}
+		})
+	}
+
+}
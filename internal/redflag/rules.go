@@ -0,0 +1,204 @@
+package redflag
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/eveisesi/athena"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	maxRuleIDLength          = 64
+	maxRuleDescriptionLength = 255
+)
+
+type ruleFile struct {
+	Rules []*athena.RedFlagRule `json:"rules" yaml:"rules"`
+}
+
+// LoadRules reads and validates the rules in the file at path. Files with a .json extension are
+// decoded as JSON, every other file is decoded as YAML
+func LoadRules(path string) ([]*athena.RedFlagRule, error) {
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read red flag rules: %w", err)
+	}
+
+	rules, err := ParseRules(data, strings.EqualFold(filepath.Ext(path), ".json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse red flag rules in %s: %w", path, err)
+	}
+
+	return rules, nil
+
+}
+
+// ParseRules decodes a document with a top level list of rules and validates every rule in it.
+// Thresholds are normalized so that every number is a float64
+func ParseRules(data []byte, isJSON bool) ([]*athena.RedFlagRule, error) {
+
+	var file ruleFile
+	var err error
+	if isJSON {
+		err = json.Unmarshal(data, &file)
+	} else {
+		err = yaml.UnmarshalStrict(data, &file)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[string]bool, len(file.Rules))
+	for i, rule := range file.Rules {
+		if rule == nil {
+			return nil, fmt.Errorf("rule %d is empty", i)
+		}
+
+		err = validateRule(rule)
+		if err != nil {
+			return nil, fmt.Errorf("rule %d (%s): %w", i, rule.ID, err)
+		}
+
+		if ids[rule.ID] {
+			return nil, fmt.Errorf("rule %d: id %s is not unique", i, rule.ID)
+		}
+		ids[rule.ID] = true
+	}
+
+	return file.Rules, nil
+
+}
+
+func validateRule(rule *athena.RedFlagRule) error {
+
+	if rule.ID == "" || len(rule.ID) > maxRuleIDLength {
+		return fmt.Errorf("id must be between 1 and %d characters", maxRuleIDLength)
+	}
+
+	if !rule.Entity.Valid() {
+		return fmt.Errorf("entity %q must be one of %v", rule.Entity, athena.AllRedFlagEntities)
+	}
+
+	if !rule.Severity.Valid() {
+		return fmt.Errorf("severity %q must be one of %v", rule.Severity, athena.AllRedFlagSeverities)
+	}
+
+	threshold, err := validateCondition(rule.Entity, rule.Field, rule.Operator, rule.Threshold)
+	if err != nil {
+		return err
+	}
+	rule.Threshold = threshold
+
+	for _, condition := range rule.Where {
+		if condition == nil {
+			return fmt.Errorf("where conditions may not be empty")
+		}
+
+		threshold, err = validateCondition(rule.Entity, condition.Field, condition.Operator, condition.Threshold)
+		if err != nil {
+			return fmt.Errorf("where: %w", err)
+		}
+		condition.Threshold = threshold
+	}
+
+	if rule.Description == "" {
+		rule.Description = fmt.Sprintf("%s %s %s %v", rule.Entity, rule.Field, rule.Operator, rule.Threshold)
+	}
+
+	if len(rule.Description) > maxRuleDescriptionLength {
+		return fmt.Errorf("description may not be longer than %d characters", maxRuleDescriptionLength)
+	}
+
+	return nil
+
+}
+
+// validateCondition verifies that field exists on the entity and that operator and threshold can be
+// applied to it. The normalized threshold is returned
+func validateCondition(entity athena.RedFlagEntity, field string, operator athena.RedFlagOperator, threshold interface{}) (interface{}, error) {
+
+	kind, ok := entityFields[entity][field]
+	if !ok {
+		return nil, fmt.Errorf("field %q does not exist on entity %s", field, entity)
+	}
+
+	if !operator.Valid() {
+		return nil, fmt.Errorf("operator %q must be one of %v", operator, athena.AllRedFlagOperators)
+	}
+
+	threshold, err := normalizeThreshold(threshold)
+	if err != nil {
+		return nil, err
+	}
+
+	switch operator {
+	case athena.RedFlagOperatorGreaterThan, athena.RedFlagOperatorGreaterThanEqualTo,
+		athena.RedFlagOperatorLessThan, athena.RedFlagOperatorLessThanEqualTo:
+		if kind != kindNumber || kindOf(threshold) != kindNumber {
+			return nil, fmt.Errorf("operator %s requires a numeric field and threshold", operator)
+		}
+	case athena.RedFlagOperatorContains, athena.RedFlagOperatorNotContains:
+		if kind != kindString || kindOf(threshold) != kindString {
+			return nil, fmt.Errorf("operator %s requires a text field and threshold", operator)
+		}
+	case athena.RedFlagOperatorIn, athena.RedFlagOperatorNotIn:
+		values, ok := threshold.([]interface{})
+		if !ok || len(values) == 0 {
+			return nil, fmt.Errorf("operator %s requires a list of thresholds", operator)
+		}
+
+		for _, value := range values {
+			if kindOf(value) != kind {
+				return nil, fmt.Errorf("threshold %v of operator %s does not match the type of field %s", value, operator, field)
+			}
+		}
+	default:
+		if kindOf(threshold) != kind {
+			return nil, fmt.Errorf("threshold %v does not match the type of field %s", threshold, field)
+		}
+	}
+
+	return threshold, nil
+
+}
+
+// normalizeThreshold converts the numbers that the JSON and YAML decoders produce into float64
+func normalizeThreshold(threshold interface{}) (interface{}, error) {
+
+	switch v := threshold.(type) {
+	case int:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case uint64:
+		return float64(v), nil
+	case float64, string, bool:
+		return v, nil
+	case []interface{}:
+		values := make([]interface{}, 0, len(v))
+		for _, value := range v {
+			if _, ok := value.([]interface{}); ok {
+				return nil, fmt.Errorf("threshold lists may not be nested")
+			}
+
+			value, err := normalizeThreshold(value)
+			if err != nil {
+				return nil, err
+			}
+
+			values = append(values, value)
+		}
+
+		return values, nil
+	case nil:
+		return nil, fmt.Errorf("threshold is required")
+	}
+
+	return nil, fmt.Errorf("threshold %v of type %T is not supported", threshold, threshold)
+
+}
@@ -0,0 +1,188 @@
+package redflag
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseRules(t *testing.T) {
+
+	tests := []struct {
+		name      string
+		data      string
+		isJSON    bool
+		threshold interface{}
+		where     []interface{}
+		err       string
+	}{
+		{
+			name:      "json numbers",
+			data:      `{"rules": [{"id": "a", "entity": "wallet_journal", "field": "amount", "operator": "lte", "threshold": -1000000000, "severity": "high"}]}`,
+			isJSON:    true,
+			threshold: float64(-1000000000),
+		},
+		{
+			name: "yaml integers",
+			data: `
+rules:
+  - id: a
+    entity: wallet_journal
+    field: amount
+    operator: lte
+    threshold: -1000000000
+    severity: high
+`,
+			threshold: float64(-1000000000),
+		},
+		{
+			name: "yaml integer list",
+			data: `
+rules:
+  - id: a
+    entity: contact
+    field: contact_id
+    operator: in
+    threshold: [99000001, 99000002]
+    severity: critical
+    where:
+      - field: standing
+        operator: gte
+        threshold: 5
+`,
+			threshold: []interface{}{float64(99000001), float64(99000002)},
+			where:     []interface{}{float64(5)},
+		},
+		{
+			name: "yaml integer beyond int64",
+			data: `
+rules:
+  - id: a
+    entity: wallet_journal
+    field: first_party_id
+    operator: eq
+    threshold: 18446744073709551615
+    severity: low
+`,
+			threshold: float64(18446744073709551615),
+		},
+		{
+			name:      "json string list",
+			data:      `{"rules": [{"id": "a", "entity": "contract", "field": "type", "operator": "not_in", "threshold": ["courier", "auction"], "severity": "low"}]}`,
+			isJSON:    true,
+			threshold: []interface{}{"courier", "auction"},
+		},
+		{
+			name: "unknown key",
+			data: `
+rules:
+  - id: a
+    entity: contact
+    field: standing
+    operator: gte
+    threshold: 5
+    severity: low
+    serverity: high
+`,
+			err: "serverity",
+		},
+		{
+			name:   "unknown field",
+			data:   `{"rules": [{"id": "a", "entity": "contact", "field": "amount", "operator": "gt", "threshold": 1, "severity": "low"}]}`,
+			isJSON: true,
+			err:    `field "amount" does not exist`,
+		},
+		{
+			name:   "numeric operator on text",
+			data:   `{"rules": [{"id": "a", "entity": "mail", "field": "subject", "operator": "gt", "threshold": 1, "severity": "low"}]}`,
+			isJSON: true,
+			err:    "requires a numeric field",
+		},
+		{
+			name:   "threshold of the wrong type",
+			data:   `{"rules": [{"id": "a", "entity": "contact", "field": "is_blocked", "operator": "eq", "threshold": "yes", "severity": "low"}]}`,
+			isJSON: true,
+			err:    "does not match the type",
+		},
+		{
+			name:   "list element of the wrong type",
+			data:   `{"rules": [{"id": "a", "entity": "contact", "field": "contact_id", "operator": "in", "threshold": [1, "2"], "severity": "low"}]}`,
+			isJSON: true,
+			err:    "does not match the type",
+		},
+		{
+			name:   "nested list",
+			data:   `{"rules": [{"id": "a", "entity": "contact", "field": "contact_id", "operator": "in", "threshold": [[1]], "severity": "low"}]}`,
+			isJSON: true,
+			err:    "may not be nested",
+		},
+		{
+			name:   "empty list",
+			data:   `{"rules": [{"id": "a", "entity": "contact", "field": "contact_id", "operator": "in", "threshold": [], "severity": "low"}]}`,
+			isJSON: true,
+			err:    "requires a list",
+		},
+		{
+			name:   "missing threshold",
+			data:   `{"rules": [{"id": "a", "entity": "contact", "field": "standing", "operator": "gt", "severity": "low"}]}`,
+			isJSON: true,
+			err:    "threshold is required",
+		},
+		{
+			name:   "duplicate id",
+			data:   `{"rules": [{"id": "a", "entity": "contact", "field": "standing", "operator": "gt", "threshold": 1, "severity": "low"}, {"id": "a", "entity": "contact", "field": "standing", "operator": "lt", "threshold": 1, "severity": "low"}]}`,
+			isJSON: true,
+			err:    "is not unique",
+		},
+		{
+			name:   "unknown severity",
+			data:   `{"rules": [{"id": "a", "entity": "contact", "field": "standing", "operator": "gt", "threshold": 1, "severity": "urgent"}]}`,
+			isJSON: true,
+			err:    "severity",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rules, err := ParseRules([]byte(test.data), test.isJSON)
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Fatalf("err = %v, want it to contain %q", err, test.err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if len(rules) != 1 {
+				t.Fatalf("parsed %d rules, want 1", len(rules))
+			}
+
+			if !reflect.DeepEqual(rules[0].Threshold, test.threshold) {
+				t.Errorf("threshold = %#v, want %#v", rules[0].Threshold, test.threshold)
+			}
+
+			for i, want := range test.where {
+				if got := rules[0].Where[i].Threshold; !reflect.DeepEqual(got, want) {
+					t.Errorf("where %d threshold = %#v, want %#v", i, got, want)
+				}
+			}
+
+			if rules[0].Description == "" {
+				t.Errorf("expected a default description")
+			}
+		})
+	}
+
+}
+
+func TestExampleRulesParse(t *testing.T) {
+
+	_, err := LoadRules("../../.config/redflag.rules.example.yaml")
+	if err != nil {
+		t.Fatalf("failed to load example rules: %s", err)
+	}
+
+}
@@ -0,0 +1,294 @@
+package redflag
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/eveisesi/athena"
+	"github.com/eveisesi/athena/internal/character"
+	"github.com/eveisesi/athena/internal/contact"
+	"github.com/eveisesi/athena/internal/contract"
+	"github.com/eveisesi/athena/internal/mail"
+	"github.com/eveisesi/athena/internal/wallet"
+	"github.com/sirupsen/logrus"
+	"github.com/volatiletech/null"
+)
+
+type Service interface {
+	Rules() []*athena.RedFlagRule
+	EvaluateMember(ctx context.Context, memberID uint) ([]*athena.MemberRedFlag, error)
+	MemberRedFlags(ctx context.Context, memberID uint, operators ...*athena.Operator) ([]*athena.MemberRedFlag, error)
+}
+
+type service struct {
+	logger *logrus.Logger
+
+	rules []*athena.RedFlagRule
+
+	character character.Service
+	contact   contact.Service
+	contract  contract.Service
+	mail      mail.Service
+	wallet    wallet.Service
+
+	flags athena.RedFlagRepository
+}
+
+const (
+	serviceIdentifier = "Red Flag Service"
+
+	// recordBatchSize is the number of rows of an entity that are loaded and evaluated at a time
+	recordBatchSize uint = 500
+)
+
+func NewService(logger *logrus.Logger, rules []*athena.RedFlagRule, character character.Service, contact contact.Service, contract contract.Service, mail mail.Service, wallet wallet.Service, flags athena.RedFlagRepository) Service {
+	return &service{
+		logger: logger,
+
+		rules: rules,
+
+		character: character,
+		contact:   contact,
+		contract:  contract,
+		mail:      mail,
+		wallet:    wallet,
+
+		flags: flags,
+	}
+}
+
+func (s *service) Rules() []*athena.RedFlagRule {
+	return s.rules
+}
+
+// EvaluateMember evaluates every rule against the stored data of the member and replaces the red
+// flags of the member with the result. Flags that were already raised by a previous evaluation keep
+// the time that they were first raised at
+func (s *service) EvaluateMember(ctx context.Context, memberID uint) ([]*athena.MemberRedFlag, error) {
+
+	entry := s.logger.WithContext(ctx).WithFields(logrus.Fields{
+		"member_id": memberID,
+		"service":   serviceIdentifier,
+		"method":    "EvaluateMember",
+	})
+
+	existing, err := s.flags.MemberRedFlags(ctx, memberID)
+	if err != nil {
+		entry.WithError(err).Error("failed to fetch member red flags from DB")
+		return nil, fmt.Errorf("failed to fetch member red flags from DB")
+	}
+
+	raised := make(map[string]time.Time, len(existing))
+	for _, flag := range existing {
+		raised[flagKey(flag.RuleID, flag.EntityID)] = flag.CreatedAt
+	}
+
+	now := time.Now()
+	flags := make([]*athena.MemberRedFlag, 0)
+	for _, entity := range athena.AllRedFlagEntities {
+
+		rules := entityRules(s.rules, entity)
+		if len(rules) == 0 {
+			continue
+		}
+
+		err = s.eachRecords(ctx, memberID, entity, recordCutoff(rules, now), now, func(records []*record) {
+			for _, rule := range rules {
+				for _, r := range records {
+					if !matchRule(rule, r) {
+						continue
+					}
+
+					createdAt, ok := raised[flagKey(rule.ID, r.id)]
+					if !ok {
+						createdAt = now
+					}
+
+					flags = append(flags, &athena.MemberRedFlag{
+						MemberID:    memberID,
+						RuleID:      rule.ID,
+						Entity:      rule.Entity,
+						EntityID:    r.id,
+						Severity:    rule.Severity,
+						Description: rule.Description,
+						Value:       formatValue(r.fields[rule.Field]),
+						CreatedAt:   createdAt,
+					})
+				}
+			}
+		})
+		if err != nil {
+			entry.WithError(err).WithField("entity", entity).Error("failed to load records of entity")
+			return nil, fmt.Errorf("failed to load %s records of member", entity)
+		}
+	}
+
+	flags, err = s.flags.ReplaceMemberRedFlags(ctx, memberID, flags)
+	if err != nil {
+		entry.WithError(err).Error("failed to replace member red flags in DB")
+		return nil, fmt.Errorf("failed to replace member red flags in DB")
+	}
+
+	sortRedFlags(flags)
+
+	return flags, nil
+
+}
+
+// MemberRedFlags returns the red flags of the member, the most severe first
+func (s *service) MemberRedFlags(ctx context.Context, memberID uint, operators ...*athena.Operator) ([]*athena.MemberRedFlag, error) {
+
+	flags, err := s.flags.MemberRedFlags(ctx, memberID, operators...)
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithFields(logrus.Fields{
+			"member_id": memberID,
+			"service":   serviceIdentifier,
+			"method":    "MemberRedFlags",
+		}).Error("failed to fetch member red flags from DB")
+		return nil, fmt.Errorf("failed to fetch member red flags from DB")
+	}
+
+	sortRedFlags(flags)
+
+	return flags, nil
+
+}
+
+// eachRecords loads the stored rows of the entity for the member and hands them to fn a batch at a
+// time, so that a long wallet journal or mailbox is never held in memory at once. Rows that are
+// older than a valid since are skipped, since no rule of the entity can match them. Contacts and the
+// corporation history of a member are short and are handed over in a single batch
+func (s *service) eachRecords(ctx context.Context, memberID uint, entity athena.RedFlagEntity, since null.Time, now time.Time, fn func(records []*record)) error {
+
+	page := &athena.Page{First: recordBatchSize}
+
+	switch entity {
+	case athena.RedFlagEntityWalletJournal:
+		operators := make([]*athena.Operator, 0, 1)
+		if since.Valid {
+			operators = append(operators, athena.NewGreaterThanEqualToOperator("date", since.Time))
+		}
+
+		for {
+			entries, err := s.wallet.MemberWalletJournals(ctx, memberID, page, operators...)
+			if err != nil {
+				return err
+			}
+
+			more := uint(len(entries)) > page.First
+			if more {
+				entries = entries[:page.First]
+			}
+
+			fn(walletJournalRecords(memberID, entries, now))
+			if !more {
+				return nil
+			}
+
+			page.After = &entries[len(entries)-1].JournalID
+		}
+	case athena.RedFlagEntityContact:
+		contacts, err := s.contact.MemberContacts(ctx, memberID, nil)
+		if err != nil {
+			return err
+		}
+
+		fn(contactRecords(contacts))
+
+		return nil
+	case athena.RedFlagEntityContract:
+		operators := make([]*athena.Operator, 0, 1)
+		if since.Valid {
+			operators = append(operators, athena.NewGreaterThanEqualToOperator("date_issued", since.Time))
+		}
+
+		for {
+			contracts, err := s.contract.MemberContracts(ctx, memberID, page, operators...)
+			if err != nil {
+				return err
+			}
+
+			more := uint(len(contracts)) > page.First
+			if more {
+				contracts = contracts[:page.First]
+			}
+
+			fn(contractRecords(contracts, now))
+			if !more {
+				return nil
+			}
+
+			after := uint64(contracts[len(contracts)-1].ContractID)
+			page.After = &after
+		}
+	case athena.RedFlagEntityMail:
+		filter := &athena.MemberMailHeaderFilter{From: since}
+		for {
+			memberHeaders, err := s.mail.MemberMailHeaders(ctx, memberID, page, filter)
+			if err != nil {
+				return err
+			}
+
+			more := uint(len(memberHeaders)) > page.First
+			if more {
+				memberHeaders = memberHeaders[:page.First]
+			}
+
+			if len(memberHeaders) == 0 {
+				return nil
+			}
+
+			mailIDs := make([]uint, 0, len(memberHeaders))
+			for _, header := range memberHeaders {
+				mailIDs = append(mailIDs, header.MailID)
+			}
+
+			headers, err := s.mail.MailHeadersByIDs(ctx, mailIDs)
+			if err != nil {
+				return err
+			}
+
+			fn(mailRecords(headers, now))
+			if !more {
+				return nil
+			}
+
+			after := uint64(mailIDs[len(mailIDs)-1])
+			page.After = &after
+		}
+	case athena.RedFlagEntityCorporationHistory:
+		history, err := s.character.CharacterCorporationHistory(ctx, athena.NewEqualOperator("character_id", memberID))
+		if err != nil {
+			return err
+		}
+
+		fn(corporationHistoryRecords(athena.ResolveCharacterCorporationHistory(history), now))
+
+		return nil
+	}
+
+	return fmt.Errorf("entity %s is not supported", entity)
+
+}
+
+func flagKey(ruleID, entityID string) string {
+	return fmt.Sprintf("%s::%s", ruleID, entityID)
+}
+
+// sortRedFlags orders flags from the most to the least severe, and the most recently raised first
+// within a severity
+func sortRedFlags(flags []*athena.MemberRedFlag) {
+	sort.SliceStable(flags, func(i, j int) bool {
+		if a, b := flags[i].Severity.Rank(), flags[j].Severity.Rank(); a != b {
+			return a > b
+		}
+
+		if !flags[i].CreatedAt.Equal(flags[j].CreatedAt) {
+			return flags[i].CreatedAt.After(flags[j].CreatedAt)
+		}
+
+		return flagKey(flags[i].RuleID, flags[i].EntityID) < flagKey(flags[j].RuleID, flags[j].EntityID)
+	})
+}
@@ -0,0 +1,359 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	htmltemplate "html/template"
+	"strconv"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/eveisesi/athena"
+	"github.com/volatiletech/null"
+)
+
+// Render encodes the report in the requested format. HTML reports are standalone documents that
+// embed their own styles, so they can be saved and shared as a single file
+func Render(report *athena.MemberReport, format athena.ReportFormat) ([]byte, error) {
+
+	var buf bytes.Buffer
+	var err error
+	switch format {
+	case athena.ReportFormatJSON:
+		enc := json.NewEncoder(&buf)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(report)
+	case athena.ReportFormatMarkdown:
+		err = markdownTemplate.Execute(&buf, report)
+	case athena.ReportFormatHTML:
+		err = htmlTemplate.Execute(&buf, report)
+	default:
+		return nil, fmt.Errorf("unsupported report format %q", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+
+}
+
+var templateFuncs = map[string]interface{}{
+	"date":     formatDate,
+	"nullDate": formatNullDate,
+	"isk":      formatISK,
+	"nullISK":  formatNullISK,
+	"number":   formatNumber,
+	"entity":   formatEntity,
+	"location": formatLocation,
+	"items":    formatItems,
+	"md":       escapeMarkdown,
+}
+
+var (
+	markdownTemplate = texttemplate.Must(texttemplate.New("markdown").Funcs(templateFuncs).Parse(markdownReport))
+	htmlTemplate     = htmltemplate.Must(htmltemplate.New("html").Funcs(templateFuncs).Parse(htmlReport))
+)
+
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+
+	return t.UTC().Format("2006-01-02")
+}
+
+func formatNullDate(t null.Time) string {
+	if !t.Valid {
+		return "-"
+	}
+
+	return formatDate(t.Time)
+}
+
+func formatISK(v float64) string {
+
+	sign := ""
+	if v < 0 {
+		sign, v = "-", -v
+	}
+
+	parts := strings.SplitN(strconv.FormatFloat(v, 'f', 2, 64), ".", 2)
+
+	return sign + formatDigits(parts[0]) + "." + parts[1] + " ISK"
+
+}
+
+func formatNullISK(v null.Float64) string {
+	if !v.Valid {
+		return "-"
+	}
+
+	return formatISK(v.Float64)
+}
+
+func formatNumber(v uint) string {
+	return formatDigits(strconv.FormatUint(uint64(v), 10))
+}
+
+func formatDigits(digits string) string {
+
+	var b strings.Builder
+	for i, r := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+
+}
+
+func formatEntity(entity *athena.ReportEntity) string {
+
+	if entity == nil {
+		return "-"
+	}
+
+	name := entity.Name
+	if name == "" {
+		name = fmt.Sprintf("Unknown %s %d", entity.Type, entity.ID)
+	}
+
+	if entity.Ticker != "" {
+		name = fmt.Sprintf("%s [%s]", name, entity.Ticker)
+	}
+
+	return name
+
+}
+
+func formatLocation(location *athena.ReportLocation) string {
+
+	if location == nil {
+		return "-"
+	}
+
+	name := location.Name
+	if name == "" {
+		name = fmt.Sprintf("Unknown %s %d", location.LocationType, location.LocationID)
+	}
+
+	if location.SolarSystem != "" && location.SolarSystem != name {
+		name = fmt.Sprintf("%s (%s)", name, location.SolarSystem)
+	}
+
+	return name
+
+}
+
+func formatItems(items []*athena.ReportItem) string {
+
+	if len(items) == 0 {
+		return "-"
+	}
+
+	names := make([]string, 0, len(items))
+	for _, item := range items {
+		name := item.Name
+		if name == "" {
+			name = fmt.Sprintf("Unknown type %d", item.TypeID)
+		}
+		names = append(names, name)
+	}
+
+	return strings.Join(names, ", ")
+
+}
+
+var markdownEscaper = strings.NewReplacer(`\`, `\\`, "|", `\|`, "*", `\*`, "_", `\_`, "`", "\\`", "[", `\[`, "]", `\]`, "<", "&lt;", ">", "&gt;")
+
+// escapeMarkdown escapes user controlled text, such as contract titles, so that it can not break
+// out of the table cell that it is rendered in
+func escapeMarkdown(s string) string {
+	return markdownEscaper.Replace(strings.Join(strings.Fields(s), " "))
+}
+
+const markdownReport = `# Vetting Report
+
+- **Member:** {{ .MemberID }}
+- **Main:** {{ .MainID }}
+- **Characters:** {{ len .Characters }}
+- **Total SP:** {{ number .TotalSP }}
+- **Generated:** {{ .GeneratedAt.UTC.Format "2006-01-02 15:04:05 MST" }}
+{{ range .Characters }}
+## {{ md .Name }}{{ if .IsMain }} (Main){{ end }}
+
+- **Character ID:** {{ .CharacterID }}
+- **Birthday:** {{ date .Birthday }}
+- **Security Status:** {{ if .SecurityStatus.Valid }}{{ printf "%.2f" .SecurityStatus.Float64 }}{{ else }}-{{ end }}
+- **Corporation:** {{ md (entity .Corporation) }}
+- **Alliance:** {{ md (entity .Alliance) }}
+
+### Corporation History
+{{ if .CorporationHistory }}
+| Corporation | Joined | Left | Days |
+| --- | --- | --- | ---: |
+{{ range .CorporationHistory }}| {{ md (entity .Corporation) }} | {{ date .StartDate }} | {{ nullDate .EndDate }} | {{ number .TenureDays }} |
+{{ end }}{{ else }}
+No corporation history.
+{{ end }}
+### Skills
+{{ with .Skills }}
+- **Total SP:** {{ number .TotalSP }}
+- **Unallocated SP:** {{ if .UnallocatedSP.Valid }}{{ .UnallocatedSP.Int }}{{ else }}-{{ end }}
+
+| Skill Group | Skills | SP |
+| --- | ---: | ---: |
+{{ range .Groups }}| {{ md .Name }} | {{ .Skills }} | {{ number .Skillpoints }} |
+{{ end }}{{ else }}
+Skills have not been synced.
+{{ end }}
+### Wallet
+{{ with .Wallet }}
+- **Balance:** {{ nullISK .Balance }}
+{{ if .RefTypes }}
+| Ref Type | Entries | Income | Expense | Net |
+| --- | ---: | ---: | ---: | ---: |
+{{ range .RefTypes }}| {{ .RefType }} | {{ .Entries }} | {{ isk .Income }} | {{ isk .Expense }} | {{ isk .Net }} |
+{{ end }}{{ else }}
+No wallet journal entries.
+{{ end }}{{ else }}
+Wallet has not been synced.
+{{ end }}
+### Negative Contacts
+{{ if .NegativeContacts }}
+| Contact | Type | Standing | Blocked | Watched |
+| --- | --- | ---: | --- | --- |
+{{ range .NegativeContacts }}| {{ md (entity .Contact) }} | {{ .Contact.Type }} | {{ printf "%.1f" .Standing }} | {{ if .IsBlocked }}Yes{{ else }}No{{ end }} | {{ if .IsWatched }}Yes{{ else }}No{{ end }} |
+{{ end }}{{ else }}
+No contacts with negative standings.
+{{ end }}
+### Recent Contracts
+{{ if .RecentContracts }}
+| Contract | Type | Status | Title | Price | Reward | Issued |
+| --- | --- | --- | --- | ---: | ---: | --- |
+{{ range .RecentContracts }}| {{ .ContractID }} | {{ .Type }} | {{ .Status }} | {{ md .Title.String }} | {{ nullISK .Price }} | {{ nullISK .Reward }} | {{ date .DateIssued }} |
+{{ end }}{{ else }}
+No contracts.
+{{ end }}
+### Clones
+{{ with .Clones }}
+- **Home Location:** {{ md (location .HomeLocation) }}
+- **Active Implants:** {{ md (items .ActiveImplants) }}
+- **Last Clone Jump:** {{ nullDate .LastCloneJumpDate }}
+{{ if .JumpClones }}
+| Jump Clone | Location | Implants |
+| --- | --- | --- |
+{{ range .JumpClones }}| {{ .JumpCloneID }} | {{ md (location .Location) }} | {{ md (items .Implants) }} |
+{{ end }}{{ end }}{{ else }}
+Clones have not been synced.
+{{ end }}
+### Last Seen
+{{ with .LastSeen }}
+- **Location:** {{ md (location .Location) }}
+- **Online:** {{ if .Online }}Yes{{ else }}No{{ end }}
+- **Last Login:** {{ nullDate .LastLogin }}
+- **Last Logout:** {{ nullDate .LastLogout }}
+{{ else }}
+Location has not been synced.
+{{ end }}{{ end }}`
+
+const htmlReport = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Vetting Report - {{ .MainID }}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2em auto; max-width: 1100px; padding: 0 1em; color: #1f2328; }
+h1, h2 { border-bottom: 1px solid #d0d7de; padding-bottom: .3em; }
+table { border-collapse: collapse; margin: .5em 0 1.5em; width: 100%; }
+th, td { border: 1px solid #d0d7de; padding: .35em .6em; text-align: left; }
+th { background: #f6f8fa; }
+td.num { text-align: right; white-space: nowrap; }
+.main { color: #1a7f37; font-size: .7em; vertical-align: middle; }
+.empty { color: #656d76; font-style: italic; }
+.negative { color: #cf222e; }
+</style>
+</head>
+<body>
+<h1>Vetting Report</h1>
+<table>
+<tr><th>Member</th><td>{{ .MemberID }}</td></tr>
+<tr><th>Main</th><td>{{ .MainID }}</td></tr>
+<tr><th>Characters</th><td>{{ len .Characters }}</td></tr>
+<tr><th>Total SP</th><td>{{ number .TotalSP }}</td></tr>
+<tr><th>Generated</th><td>{{ .GeneratedAt.UTC.Format "2006-01-02 15:04:05 MST" }}</td></tr>
+</table>
+{{ range .Characters }}
+<h2>{{ .Name }}{{ if .IsMain }} <span class="main">MAIN</span>{{ end }}</h2>
+<table>
+<tr><th>Character ID</th><td>{{ .CharacterID }}</td></tr>
+<tr><th>Birthday</th><td>{{ date .Birthday }}</td></tr>
+<tr><th>Security Status</th><td>{{ if .SecurityStatus.Valid }}{{ printf "%.2f" .SecurityStatus.Float64 }}{{ else }}-{{ end }}</td></tr>
+<tr><th>Corporation</th><td>{{ entity .Corporation }}</td></tr>
+<tr><th>Alliance</th><td>{{ entity .Alliance }}</td></tr>
+</table>
+
+<h3>Corporation History</h3>
+{{ if .CorporationHistory }}<table>
+<tr><th>Corporation</th><th>Joined</th><th>Left</th><th>Days</th></tr>
+{{ range .CorporationHistory }}<tr><td>{{ entity .Corporation }}</td><td>{{ date .StartDate }}</td><td>{{ nullDate .EndDate }}</td><td class="num">{{ number .TenureDays }}</td></tr>
+{{ end }}</table>{{ else }}<p class="empty">No corporation history.</p>{{ end }}
+
+<h3>Skills</h3>
+{{ with .Skills }}<table>
+<tr><th>Total SP</th><td>{{ number .TotalSP }}</td></tr>
+<tr><th>Unallocated SP</th><td>{{ if .UnallocatedSP.Valid }}{{ .UnallocatedSP.Int }}{{ else }}-{{ end }}</td></tr>
+</table>
+<table>
+<tr><th>Skill Group</th><th>Skills</th><th>SP</th></tr>
+{{ range .Groups }}<tr><td>{{ .Name }}</td><td class="num">{{ .Skills }}</td><td class="num">{{ number .Skillpoints }}</td></tr>
+{{ end }}</table>{{ else }}<p class="empty">Skills have not been synced.</p>{{ end }}
+
+<h3>Wallet</h3>
+{{ with .Wallet }}<table>
+<tr><th>Balance</th><td>{{ nullISK .Balance }}</td></tr>
+</table>
+{{ if .RefTypes }}<table>
+<tr><th>Ref Type</th><th>Entries</th><th>Income</th><th>Expense</th><th>Net</th></tr>
+{{ range .RefTypes }}<tr><td>{{ .RefType }}</td><td class="num">{{ .Entries }}</td><td class="num">{{ isk .Income }}</td><td class="num">{{ isk .Expense }}</td><td class="num{{ if lt .Net 0.0 }} negative{{ end }}">{{ isk .Net }}</td></tr>
+{{ end }}</table>{{ else }}<p class="empty">No wallet journal entries.</p>{{ end }}{{ else }}<p class="empty">Wallet has not been synced.</p>{{ end }}
+
+<h3>Negative Contacts</h3>
+{{ if .NegativeContacts }}<table>
+<tr><th>Contact</th><th>Type</th><th>Standing</th><th>Blocked</th><th>Watched</th></tr>
+{{ range .NegativeContacts }}<tr><td>{{ entity .Contact }}</td><td>{{ .Contact.Type }}</td><td class="num negative">{{ printf "%.1f" .Standing }}</td><td>{{ if .IsBlocked }}Yes{{ else }}No{{ end }}</td><td>{{ if .IsWatched }}Yes{{ else }}No{{ end }}</td></tr>
+{{ end }}</table>{{ else }}<p class="empty">No contacts with negative standings.</p>{{ end }}
+
+<h3>Recent Contracts</h3>
+{{ if .RecentContracts }}<table>
+<tr><th>Contract</th><th>Type</th><th>Status</th><th>Title</th><th>Price</th><th>Reward</th><th>Issued</th></tr>
+{{ range .RecentContracts }}<tr><td>{{ .ContractID }}</td><td>{{ .Type }}</td><td>{{ .Status }}</td><td>{{ .Title.String }}</td><td class="num">{{ nullISK .Price }}</td><td class="num">{{ nullISK .Reward }}</td><td>{{ date .DateIssued }}</td></tr>
+{{ end }}</table>{{ else }}<p class="empty">No contracts.</p>{{ end }}
+
+<h3>Clones</h3>
+{{ with .Clones }}<table>
+<tr><th>Home Location</th><td>{{ location .HomeLocation }}</td></tr>
+<tr><th>Active Implants</th><td>{{ items .ActiveImplants }}</td></tr>
+<tr><th>Last Clone Jump</th><td>{{ nullDate .LastCloneJumpDate }}</td></tr>
+</table>
+{{ if .JumpClones }}<table>
+<tr><th>Jump Clone</th><th>Location</th><th>Implants</th></tr>
+{{ range .JumpClones }}<tr><td>{{ .JumpCloneID }}</td><td>{{ location .Location }}</td><td>{{ items .Implants }}</td></tr>
+{{ end }}</table>{{ end }}{{ else }}<p class="empty">Clones have not been synced.</p>{{ end }}
+
+<h3>Last Seen</h3>
+{{ with .LastSeen }}<table>
+<tr><th>Location</th><td>{{ location .Location }}</td></tr>
+<tr><th>Online</th><td>{{ if .Online }}Yes{{ else }}No{{ end }}</td></tr>
+<tr><th>Last Login</th><td>{{ nullDate .LastLogin }}</td></tr>
+<tr><th>Last Logout</th><td>{{ nullDate .LastLogout }}</td></tr>
+</table>{{ else }}<p class="empty">Location has not been synced.</p>{{ end }}
+{{ end }}
+</body>
+</html>
+`
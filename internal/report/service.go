@@ -0,0 +1,648 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/eveisesi/athena"
+	"github.com/eveisesi/athena/internal/alliance"
+	"github.com/eveisesi/athena/internal/character"
+	"github.com/eveisesi/athena/internal/clone"
+	"github.com/eveisesi/athena/internal/contact"
+	"github.com/eveisesi/athena/internal/contract"
+	"github.com/eveisesi/athena/internal/corporation"
+	"github.com/eveisesi/athena/internal/location"
+	"github.com/eveisesi/athena/internal/member"
+	"github.com/eveisesi/athena/internal/skill"
+	"github.com/eveisesi/athena/internal/universe"
+	"github.com/eveisesi/athena/internal/wallet"
+	"github.com/sirupsen/logrus"
+)
+
+type Service interface {
+	MemberReport(ctx context.Context, memberID uint, include func(memberID uint) bool) (*athena.MemberReport, error)
+	RenderMemberReport(ctx context.Context, memberID uint, format athena.ReportFormat, include func(memberID uint) bool) ([]byte, error)
+}
+
+type service struct {
+	logger *logrus.Logger
+
+	member      member.Service
+	character   character.Service
+	corporation corporation.Service
+	alliance    alliance.Service
+	universe    universe.Service
+	location    location.Service
+	clone       clone.Service
+	contact     contact.Service
+	contract    contract.Service
+	skill       skill.Service
+	wallet      wallet.Service
+}
+
+const (
+	serviceIdentifier = "Report Service"
+
+	// reportSkillGroups is the number of skill groups, ordered by the skillpoints trained in them,
+	// that are included for each character
+	reportSkillGroups = 10
+	// reportRecentContracts is the number of the most recently issued contracts that are included
+	// for each character
+	reportRecentContracts = 10
+)
+
+func NewService(
+	logger *logrus.Logger,
+	member member.Service,
+	character character.Service,
+	corporation corporation.Service,
+	alliance alliance.Service,
+	universe universe.Service,
+	location location.Service,
+	clone clone.Service,
+	contact contact.Service,
+	contract contract.Service,
+	skill skill.Service,
+	wallet wallet.Service,
+) Service {
+	return &service{
+		logger: logger,
+
+		member:      member,
+		character:   character,
+		corporation: corporation,
+		alliance:    alliance,
+		universe:    universe,
+		location:    location,
+		clone:       clone,
+		contact:     contact,
+		contract:    contract,
+		skill:       skill,
+		wallet:      wallet,
+	}
+}
+
+// MemberReport assembles a vetting report of the member and every alt that is linked to the main
+// of the member. The main is always the first character of the report. Linked members for which
+// include returns false are left out of the report, a nil include leaves every member in
+func (s *service) MemberReport(ctx context.Context, memberID uint, include func(memberID uint) bool) (*athena.MemberReport, error) {
+
+	entry := s.logger.WithContext(ctx).WithFields(logrus.Fields{
+		"member_id": memberID,
+		"service":   serviceIdentifier,
+		"method":    "MemberReport",
+	})
+
+	members, err := s.member.LinkedMembers(ctx, memberID)
+	if err != nil {
+		entry.WithError(err).Error("failed to fetch linked members")
+		return nil, fmt.Errorf("failed to fetch linked members")
+	}
+
+	report := &athena.MemberReport{
+		MemberID:    memberID,
+		MainID:      members[0].ID,
+		Characters:  make([]*athena.CharacterReport, 0, len(members)),
+		GeneratedAt: time.Now(),
+	}
+
+	for _, member := range members {
+		if member.ID != memberID && include != nil && !include(member.ID) {
+			continue
+		}
+
+		character, err := s.characterReport(ctx, member)
+		if err != nil {
+			entry.WithError(err).WithField("character_id", member.ID).Error("failed to build character report")
+			return nil, fmt.Errorf("failed to build report of character %d", member.ID)
+		}
+
+		character.IsMain = member.ID == report.MainID
+		if character.Skills != nil {
+			report.TotalSP += character.Skills.TotalSP
+		}
+
+		report.Characters = append(report.Characters, character)
+	}
+
+	return report, nil
+
+}
+
+// RenderMemberReport assembles the report of the member and renders it in the requested format
+func (s *service) RenderMemberReport(ctx context.Context, memberID uint, format athena.ReportFormat, include func(memberID uint) bool) ([]byte, error) {
+
+	report, err := s.MemberReport(ctx, memberID, include)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := Render(report, format)
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithFields(logrus.Fields{
+			"member_id": memberID,
+			"format":    format,
+			"service":   serviceIdentifier,
+			"method":    "RenderMemberReport",
+		}).Error("failed to render member report")
+		return nil, fmt.Errorf("failed to render member report")
+	}
+
+	return data, nil
+
+}
+
+func (s *service) characterReport(ctx context.Context, member *athena.Member) (*athena.CharacterReport, error) {
+
+	character, err := s.character.Character(ctx, member.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch character: %w", err)
+	}
+
+	if character == nil {
+		return nil, fmt.Errorf("character %d does not exist", member.ID)
+	}
+
+	report := &athena.CharacterReport{
+		CharacterID:    character.ID,
+		Name:           character.Name,
+		Birthday:       character.Birthday,
+		SecurityStatus: character.SecurityStatus,
+		Corporation:    s.entity(ctx, character.CorporationID, "corporation"),
+	}
+
+	if character.AllianceID.Valid {
+		report.Alliance = s.entity(ctx, character.AllianceID.Uint, "alliance")
+	}
+
+	report.CorporationHistory, err = s.corporationHistory(ctx, character.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	report.Skills, err = s.skills(ctx, member.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	report.Wallet, err = s.walletSummary(ctx, member.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	report.NegativeContacts, err = s.negativeContacts(ctx, member.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	report.RecentContracts, err = s.recentContracts(ctx, member.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	report.Clones, err = s.clones(ctx, member)
+	if err != nil {
+		return nil, err
+	}
+
+	report.LastSeen, err = s.lastSeen(ctx, member)
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+
+}
+
+func (s *service) corporationHistory(ctx context.Context, characterID uint) ([]*athena.ReportCorporationStint, error) {
+
+	history, err := s.character.CharacterCorporationHistory(ctx, athena.NewEqualOperator("character_id", characterID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch corporation history: %w", err)
+	}
+
+	if len(history) == 0 {
+		_, err = s.character.FetchCharacterCorporationHistory(ctx, characterID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch corporation history from ESI: %w", err)
+		}
+
+		history, err = s.character.CharacterCorporationHistory(ctx, athena.NewEqualOperator("character_id", characterID))
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch corporation history: %w", err)
+		}
+	}
+
+	history = athena.ResolveCharacterCorporationHistory(history)
+
+	stints := make([]*athena.ReportCorporationStint, 0, len(history))
+	for _, record := range history {
+		stints = append(stints, &athena.ReportCorporationStint{
+			Corporation: s.entity(ctx, record.CorporationID, "corporation"),
+			StartDate:   record.StartDate,
+			EndDate:     record.EndDate,
+			TenureDays:  uint(record.Tenure().Hours() / 24),
+		})
+	}
+
+	return stints, nil
+
+}
+
+func (s *service) skills(ctx context.Context, memberID uint) (*athena.ReportSkills, error) {
+
+	properties, err := s.skill.MemberSkillProperties(ctx, memberID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch skill properties: %w", err)
+	}
+
+	if properties == nil {
+		return nil, nil
+	}
+
+	skills, err := s.skill.MemberSkills(ctx, memberID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch skills: %w", err)
+	}
+
+	groups := make(map[uint]*athena.ReportSkillGroup)
+	for _, skill := range skills {
+		info, err := s.universe.Type(ctx, skill.SkillID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch skill type %d: %w", skill.SkillID, err)
+		}
+
+		// Skills whose type is unknown cannot be placed in a group, but still count towards TotalSP
+		if info == nil {
+			continue
+		}
+
+		if _, ok := groups[info.GroupID]; !ok {
+			groups[info.GroupID] = &athena.ReportSkillGroup{GroupID: info.GroupID}
+		}
+
+		groups[info.GroupID].Skills++
+		groups[info.GroupID].Skillpoints += skill.SkillpointsInSkill
+	}
+
+	report := &athena.ReportSkills{
+		TotalSP:       properties.TotalSP,
+		UnallocatedSP: properties.UnallocatedSP,
+		Groups:        make([]*athena.ReportSkillGroup, 0, len(groups)),
+	}
+
+	for _, group := range groups {
+		report.Groups = append(report.Groups, group)
+	}
+
+	sort.Slice(report.Groups, func(i, j int) bool {
+		if report.Groups[i].Skillpoints != report.Groups[j].Skillpoints {
+			return report.Groups[i].Skillpoints > report.Groups[j].Skillpoints
+		}
+
+		return report.Groups[i].GroupID < report.Groups[j].GroupID
+	})
+
+	if len(report.Groups) > reportSkillGroups {
+		report.Groups = report.Groups[:reportSkillGroups]
+	}
+
+	for _, group := range report.Groups {
+		info, err := s.universe.Group(ctx, group.GroupID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch skill group %d: %w", group.GroupID, err)
+		}
+
+		if info != nil {
+			group.Name = info.Name
+		}
+	}
+
+	return report, nil
+
+}
+
+func (s *service) walletSummary(ctx context.Context, memberID uint) (*athena.ReportWallet, error) {
+
+	report := &athena.ReportWallet{
+		RefTypes: make([]*athena.ReportRefType, 0),
+	}
+
+	balance, err := s.wallet.MemberBalance(ctx, memberID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch wallet balance: %w", err)
+	}
+
+	if balance != nil {
+		report.Balance.SetValid(balance.Balance)
+	}
+
+	// A nil page selects the entire journal
+	entries, err := s.wallet.MemberWalletJournals(ctx, memberID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch wallet journal: %w", err)
+	}
+
+	refTypes := make(map[athena.RefType]*athena.ReportRefType)
+	for _, entry := range entries {
+		if _, ok := refTypes[entry.RefType]; !ok {
+			refTypes[entry.RefType] = &athena.ReportRefType{RefType: entry.RefType}
+			report.RefTypes = append(report.RefTypes, refTypes[entry.RefType])
+		}
+
+		summary := refTypes[entry.RefType]
+		summary.Entries++
+		if !entry.Amount.Valid {
+			continue
+		}
+
+		if entry.Amount.Float64 >= 0 {
+			summary.Income += entry.Amount.Float64
+		} else {
+			summary.Expense -= entry.Amount.Float64
+		}
+
+		summary.Net += entry.Amount.Float64
+	}
+
+	sort.Slice(report.RefTypes, func(i, j int) bool {
+		a := report.RefTypes[i].Income + report.RefTypes[i].Expense
+		b := report.RefTypes[j].Income + report.RefTypes[j].Expense
+		if a != b {
+			return a > b
+		}
+
+		return report.RefTypes[i].RefType < report.RefTypes[j].RefType
+	})
+
+	return report, nil
+
+}
+
+func (s *service) negativeContacts(ctx context.Context, memberID uint) ([]*athena.ReportContact, error) {
+
+	contacts, err := s.contact.MemberContacts(ctx, memberID, nil, athena.NewLessThanOperator("standing", 0))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch contacts: %w", err)
+	}
+
+	sort.SliceStable(contacts, func(i, j int) bool {
+		return contacts[i].Standing < contacts[j].Standing
+	})
+
+	report := make([]*athena.ReportContact, 0, len(contacts))
+	for _, contact := range contacts {
+		report = append(report, &athena.ReportContact{
+			Contact:   s.entity(ctx, contact.ContactID, contact.ContactType),
+			Standing:  contact.Standing,
+			IsBlocked: contact.IsBlocked,
+			IsWatched: contact.IsWatched,
+		})
+	}
+
+	return report, nil
+
+}
+
+func (s *service) recentContracts(ctx context.Context, memberID uint) ([]*athena.ReportContract, error) {
+
+	// Contracts are ordered newest first when no page is provided
+	contracts, err := s.contract.MemberContracts(ctx, memberID, nil, athena.NewLimitOperator(reportRecentContracts))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch contracts: %w", err)
+	}
+
+	report := make([]*athena.ReportContract, 0, len(contracts))
+	for _, contract := range contracts {
+		report = append(report, &athena.ReportContract{
+			ContractID: contract.ContractID,
+			Type:       contract.Type,
+			Status:     contract.Status,
+			Title:      contract.Title,
+			IssuerID:   contract.IssuerID,
+			AssigneeID: contract.AssigneeID,
+			AcceptorID: contract.AcceptorID,
+			Price:      contract.Price,
+			Reward:     contract.Reward,
+			DateIssued: contract.DateIssued,
+		})
+	}
+
+	return report, nil
+
+}
+
+func (s *service) clones(ctx context.Context, member *athena.Member) (*athena.ReportClones, error) {
+
+	clones, err := s.clone.MemberClones(ctx, member.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch clones: %w", err)
+	}
+
+	implants, err := s.clone.MemberImplants(ctx, member.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch implants: %w", err)
+	}
+
+	if clones == nil && len(implants) == 0 {
+		return nil, nil
+	}
+
+	report := &athena.ReportClones{
+		ActiveImplants: make([]*athena.ReportItem, 0, len(implants)),
+		JumpClones:     make([]*athena.ReportJumpClone, 0),
+	}
+
+	for _, implant := range implants {
+		report.ActiveImplants = append(report.ActiveImplants, s.item(ctx, implant.ImplantID))
+	}
+
+	if clones == nil {
+		return report, nil
+	}
+
+	report.LastCloneJumpDate = clones.LastCloneJumpDate
+	if clones.HomeLocation != nil {
+		report.HomeLocation = s.reportLocation(ctx, member, clones.HomeLocation.LocationID, clones.HomeLocation.LocationType)
+	}
+
+	for _, jumpClone := range clones.JumpClones {
+		clone := &athena.ReportJumpClone{
+			JumpCloneID: jumpClone.JumpCloneID,
+			Location:    s.reportLocation(ctx, member, jumpClone.LocationID, jumpClone.LocationType),
+			Implants:    make([]*athena.ReportItem, 0, len(jumpClone.Implants)),
+		}
+
+		for _, implantID := range jumpClone.Implants {
+			clone.Implants = append(clone.Implants, s.item(ctx, uint(implantID)))
+		}
+
+		report.JumpClones = append(report.JumpClones, clone)
+	}
+
+	return report, nil
+
+}
+
+func (s *service) lastSeen(ctx context.Context, member *athena.Member) (*athena.ReportLastSeen, error) {
+
+	location, err := s.location.MemberLocation(ctx, member.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch location: %w", err)
+	}
+
+	online, err := s.location.MemberOnline(ctx, member.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch online status: %w", err)
+	}
+
+	if location == nil && online == nil {
+		return nil, nil
+	}
+
+	report := new(athena.ReportLastSeen)
+	if location != nil {
+		report.UpdatedAt = location.UpdatedAt
+		switch {
+		case location.StructureID.Valid:
+			report.Location = s.reportLocation(ctx, member, location.StructureID.Uint64, "structure")
+		case location.StationID.Valid:
+			report.Location = s.reportLocation(ctx, member, uint64(location.StationID.Uint), "station")
+		default:
+			report.Location = s.reportLocation(ctx, member, uint64(location.SolarSystemID), "solar_system")
+		}
+	}
+
+	if online != nil {
+		report.Online = online.Online
+		report.LastLogin = online.LastLogin
+		report.LastLogout = online.LastLogout
+		if online.UpdatedAt.After(report.UpdatedAt) {
+			report.UpdatedAt = online.UpdatedAt
+		}
+	}
+
+	return report, nil
+
+}
+
+// entity resolves the name of a character, corporation, alliance or faction. A failure to resolve
+// the name is logged and leaves the name empty rather than failing the entire report, since names
+// of entities that have not been seen before have to be requested from ESI
+func (s *service) entity(ctx context.Context, id uint, entityType string) *athena.ReportEntity {
+
+	entity := &athena.ReportEntity{ID: id, Type: entityType}
+
+	var err error
+	switch entityType {
+	case "character":
+		var character *athena.Character
+		character, err = s.character.Character(ctx, id)
+		if character != nil {
+			entity.Name = character.Name
+		}
+	case "corporation":
+		var corporation *athena.Corporation
+		corporation, err = s.corporation.Corporation(ctx, id)
+		if corporation != nil {
+			entity.Name, entity.Ticker = corporation.Name, corporation.Ticker
+		}
+	case "alliance":
+		var alliance *athena.Alliance
+		alliance, err = s.alliance.Alliance(ctx, id)
+		if alliance != nil {
+			entity.Name, entity.Ticker = alliance.Name, alliance.Ticker
+		}
+	case "faction":
+		var faction *athena.Faction
+		faction, err = s.universe.Faction(ctx, id)
+		if faction != nil {
+			entity.Name = faction.Name
+		}
+	}
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithFields(logrus.Fields{
+			"entity_id":   id,
+			"entity_type": entityType,
+			"service":     serviceIdentifier,
+			"method":      "entity",
+		}).Warn("failed to resolve entity name")
+	}
+
+	return entity
+
+}
+
+func (s *service) item(ctx context.Context, typeID uint) *athena.ReportItem {
+
+	item := &athena.ReportItem{TypeID: typeID}
+
+	info, err := s.universe.Type(ctx, typeID)
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithFields(logrus.Fields{
+			"type_id": typeID,
+			"service": serviceIdentifier,
+			"method":  "item",
+		}).Warn("failed to resolve type name")
+		return item
+	}
+
+	if info != nil {
+		item.Name = info.Name
+	}
+
+	return item
+
+}
+
+// reportLocation resolves the name of a station, structure or solar system along with the solar system
+// that it is in. Structures are requested with the token of the member, which fails when the member
+// is not allowed to dock at the structure, so failures are logged and leave the names empty
+func (s *service) reportLocation(ctx context.Context, member *athena.Member, locationID uint64, locationType string) *athena.ReportLocation {
+
+	location := &athena.ReportLocation{LocationID: locationID, LocationType: locationType}
+
+	var systemID uint
+	var err error
+	switch locationType {
+	case "station":
+		var station *athena.Station
+		station, err = s.universe.Station(ctx, uint(locationID))
+		if station != nil {
+			location.Name, systemID = station.Name, station.SystemID
+		}
+	case "structure":
+		var structure *athena.Structure
+		structure, err = s.universe.Structure(ctx, member, locationID)
+		if structure != nil {
+			location.Name, systemID = structure.Name, structure.SolarSystemID
+		}
+	case "solar_system":
+		systemID = uint(locationID)
+	}
+
+	if err == nil && systemID > 0 {
+		var system *athena.SolarSystem
+		system, err = s.universe.SolarSystem(ctx, systemID)
+		if system != nil {
+			location.SolarSystem = system.Name
+			if locationType == "solar_system" {
+				location.Name = system.Name
+			}
+		}
+	}
+
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithFields(logrus.Fields{
+			"location_id":   locationID,
+			"location_type": locationType,
+			"service":       serviceIdentifier,
+			"method":        "reportLocation",
+		}).Warn("failed to resolve location name")
+	}
+
+	return location
+
+}
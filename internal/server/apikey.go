@@ -0,0 +1,46 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/99designs/gqlgen/graphql"
+)
+
+// authorizeAPIKey restricts requests that were authenticated with an API Key to the root fields
+// and members that the key has been scoped to. Requests without a key are passed through untouched.
+func (s *server) authorizeAPIKey(ctx context.Context, next graphql.Resolver) (interface{}, error) {
+
+	key := s.apikey.APIKeyFromContext(ctx)
+	if key == nil {
+		return next(ctx)
+	}
+
+	fc := graphql.GetFieldContext(ctx)
+	if fc == nil {
+		return next(ctx)
+	}
+
+	switch fc.Object {
+	case "Query", "Mutation", "Subscription":
+	default:
+		return next(ctx)
+	}
+
+	name := fc.Field.Name
+	if strings.HasPrefix(name, "__") {
+		return next(ctx)
+	}
+
+	if !key.AllowsQuery(name) {
+		return nil, fmt.Errorf("api key is not permitted to execute %s", name)
+	}
+
+	if memberID, ok := fc.Args["memberID"].(uint); ok && !key.AllowsMember(memberID) {
+		return nil, fmt.Errorf("api key is not permitted to access member %d", memberID)
+	}
+
+	return next(ctx)
+
+}
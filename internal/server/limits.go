@@ -0,0 +1,345 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	gqlgen "github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/errcode"
+	"github.com/eveisesi/athena"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// Limits caps the cost of the operations that a single client may execute against /query
+type Limits struct {
+	// MaxComplexity is the highest complexity score, as calculated by complexitySchema, that an operation may have
+	MaxComplexity int
+	// MaxDepth is the deepest that the selection sets of an operation may be nested
+	MaxDepth int
+	// RateLimit is the number of requests that a member or anonymous client may make per RateWindow
+	RateLimit  uint
+	RateWindow time.Duration
+	// TrustedProxies are the networks of the reverse proxies that sit in front of the server. The
+	// X-Forwarded-For header is only read from requests that one of these proxies passed on, so
+	// that anonymous clients are rate limited by their own address rather than by the proxy's
+	TrustedProxies []*net.IPNet
+}
+
+// ParseTrustedProxies parses the CIDRs or plain addresses of the trusted proxies of Limits
+func ParseTrustedProxies(proxies []string) ([]*net.IPNet, error) {
+
+	networks := make([]*net.IPNet, 0, len(proxies))
+	for _, proxy := range proxies {
+		proxy = strings.TrimSpace(proxy)
+		if proxy == "" {
+			continue
+		}
+
+		if !strings.Contains(proxy, "/") {
+			ip := net.ParseIP(proxy)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid trusted proxy %q", proxy)
+			}
+
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+
+			networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		_, network, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", proxy, err)
+		}
+
+		networks = append(networks, network)
+	}
+
+	return networks, nil
+
+}
+
+const (
+	errDepthLimit = "DEPTH_LIMIT_EXCEEDED"
+	errRateLimit  = "RATE_LIMITED"
+
+	// entityFieldCost is the cost of a field that resolves a character, corporation, alliance or
+	// structure. These are refreshed from ESI whenever their cached copy expires
+	entityFieldCost = 5
+	// universeFieldCost is the cost of a field that resolves static universe data, which is only
+	// requested from ESI the first time that it is seen
+	universeFieldCost = 2
+	// reportFieldCost is the cost of a field that assembles a vetting report. Reports read the data
+	// of every section for the member and each linked alt, and resolve the entities that they reference
+	reportFieldCost = 100
+	// analysisFieldCost is the cost of a field that analyses a whole set of records of a member or
+	// character, such as every wallet journal entry that falls within the requested range of dates
+	analysisFieldCost = 50
+	// listFieldMultiplier is the number of records a list without a page size is assumed to hold
+	listFieldMultiplier = 10
+)
+
+var fieldCosts = map[string]int{
+	"Character":                  entityFieldCost,
+	"Corporation":                entityFieldCost,
+	"Alliance":                   entityFieldCost,
+	"Structure":                  entityFieldCost,
+	"Station":                    universeFieldCost,
+	"SolarSystem":                universeFieldCost,
+	"Constellation":              universeFieldCost,
+	"Region":                     universeFieldCost,
+	"Type":                       universeFieldCost,
+	"Group":                      universeFieldCost,
+	"Category":                   universeFieldCost,
+	"Race":                       universeFieldCost,
+	"Ancestry":                   universeFieldCost,
+	"Bloodline":                  universeFieldCost,
+	"Faction":                    universeFieldCost,
+	"MemberReport":               reportFieldCost,
+	"WalletJournalAnalysis":      analysisFieldCost,
+	"CorporationHistoryAnalysis": analysisFieldCost,
+}
+
+// complexitySchema scores the fields of an operation for the ComplexityLimit extension. A field
+// costs more when it resolves data that may have to be fetched from ESI, and the cost of a list
+// along with everything selected beneath it is multiplied by the number of records it may return
+type complexitySchema struct {
+	gqlgen.ExecutableSchema
+}
+
+func (s complexitySchema) Complexity(typeName, field string, childComplexity int, args map[string]interface{}) (int, bool) {
+
+	def := s.Schema().Types[typeName]
+	if def == nil {
+		return 0, false
+	}
+
+	fieldDef := def.Fields.ForName(field)
+	if fieldDef == nil {
+		return 0, false
+	}
+
+	name := fieldDef.Type.Name()
+
+	cost, ok := fieldCosts[name]
+	if !ok {
+		cost = 1
+	}
+
+	multiplier := 1
+	switch {
+	case strings.HasSuffix(name, "Connection"):
+		multiplier = int(athena.DefaultPageSize)
+		if first, ok := intArg(args["first"]); ok && first > 0 {
+			multiplier = first
+		}
+	case strings.HasSuffix(name, "Edge"):
+		// The edges of a connection are already accounted for by the page size of the connection
+	case fieldDef.Type.Elem != nil:
+		multiplier = listFieldMultiplier
+	}
+
+	return safeMultiply(cost+childComplexity, multiplier), true
+
+}
+
+func intArg(v interface{}) (int, bool) {
+	switch n := v.(type) {
+	case int:
+		return n, true
+	case int64:
+		return int(n), true
+	case uint:
+		return int(n), true
+	case float64:
+		return int(n), true
+	case json.Number:
+		i, err := n.Int64()
+		return int(i), err == nil
+	}
+
+	return 0, false
+}
+
+func safeMultiply(a, b int) int {
+	const maxInt = int(^uint(0) >> 1)
+	if a != 0 && b > maxInt/a {
+		return maxInt
+	}
+
+	return a * b
+}
+
+// depthLimit rejects operations whose selection sets are nested deeper than limit. Introspection
+// fields are not counted so that the playground and code generators keep working
+type depthLimit struct {
+	limit int
+}
+
+var _ interface {
+	gqlgen.OperationContextMutator
+	gqlgen.HandlerExtension
+} = depthLimit{}
+
+func (d depthLimit) ExtensionName() string {
+	return "DepthLimit"
+}
+
+func (d depthLimit) Validate(schema gqlgen.ExecutableSchema) error {
+	if d.limit <= 0 {
+		return fmt.Errorf("depth limit must be greater than zero")
+	}
+
+	return nil
+}
+
+func (d depthLimit) MutateOperationContext(ctx context.Context, rc *gqlgen.OperationContext) *gqlerror.Error {
+
+	op := rc.Doc.Operations.ForName(rc.OperationName)
+	if op == nil {
+		return nil
+	}
+
+	depth := selectionSetDepth(op.SelectionSet)
+	if depth > d.limit {
+		err := gqlerror.Errorf("operation has depth %d, which exceeds the limit of %d", depth, d.limit)
+		errcode.Set(err, errDepthLimit)
+		return err
+	}
+
+	return nil
+
+}
+
+func selectionSetDepth(set ast.SelectionSet) int {
+
+	var depth int
+	for _, selection := range set {
+		var d int
+		switch s := selection.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(s.Name, "__") {
+				continue
+			}
+			d = selectionSetDepth(s.SelectionSet) + 1
+		case *ast.FragmentSpread:
+			d = selectionSetDepth(s.Definition.SelectionSet)
+		case *ast.InlineFragment:
+			d = selectionSetDepth(s.SelectionSet)
+		}
+
+		if d > depth {
+			depth = d
+		}
+	}
+
+	return depth
+
+}
+
+// rateLimit counts requests against the limit of the member that made them, or of the address
+// that they came from when no member is logged in. API Keys carry their own limit, which is
+// enforced when the key is authenticated, so requests made with a key are not counted again
+func (s *server) rateLimit(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		var ctx = r.Context()
+
+		if s.apikey.APIKeyFromContext(ctx) != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		identity := fmt.Sprintf("ip::%s", clientAddr(r, s.limits.TrustedProxies))
+		if member := s.member.MemberFromContext(ctx); member != nil {
+			identity = fmt.Sprintf("member::%d", member.ID)
+		}
+
+		count, err := s.cache.IncrementRequestCount(ctx, identity, s.limits.RateWindow)
+		if err != nil {
+			// Failing open keeps the API available while redis is unreachable
+			s.logger.WithError(err).WithField("identity", identity).Error("failed to increment request count")
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if count > int64(s.limits.RateLimit) {
+			w.Header().Set("Retry-After", fmt.Sprintf("%d", int(s.limits.RateWindow.Seconds())))
+			err := gqlerror.Errorf("rate limit of %d requests per %s exceeded", s.limits.RateLimit, s.limits.RateWindow)
+			errcode.Set(err, errRateLimit)
+			s.writeResponse(ctx, w, http.StatusTooManyRequests, &gqlgen.Response{Errors: gqlerror.List{err}})
+			return
+		}
+
+		next.ServeHTTP(w, r)
+
+	})
+}
+
+// clientAddr returns the address of the client that made a request. When the request was passed on
+// by a trusted proxy, the X-Forwarded-For header is walked from the nearest hop outwards and the
+// first address that is not a trusted proxy is returned. Headers are ignored on any other request,
+// since a client that connects directly could set them to anything
+func clientAddr(r *http.Request, trusted []*net.IPNet) string {
+
+	addr := remoteAddr(r)
+	if !isTrustedProxy(addr, trusted) {
+		return addr
+	}
+
+	hops := strings.Split(r.Header.Get("X-Forwarded-For"), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(hops[i])
+		if hop == "" {
+			continue
+		}
+
+		if net.ParseIP(hop) == nil {
+			// The header has been tampered with beyond this hop, so nothing further out can be trusted
+			return addr
+		}
+
+		addr = hop
+		if !isTrustedProxy(hop, trusted) {
+			return hop
+		}
+	}
+
+	return addr
+
+}
+
+func isTrustedProxy(addr string, trusted []*net.IPNet) bool {
+
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+
+	for _, network := range trusted {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+
+}
+
+func remoteAddr(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
@@ -0,0 +1,225 @@
+package server
+
+import (
+	"net/http"
+	"testing"
+
+	gqlgen "github.com/99designs/gqlgen/graphql"
+	"github.com/eveisesi/athena"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+var limitsTestSchema = gqlparser.MustLoadSchema(&ast.Source{Name: "limits.graphqls", Input: `
+type Query {
+	character: Character
+	characters: [Character]
+	contacts(first: Int): ContactConnection
+	report: MemberReport
+	count: Int
+}
+
+type Character {
+	id: Int
+	name: String
+	corporation: Corporation
+}
+
+type Corporation {
+	id: Int
+	name: String
+}
+
+type ContactConnection {
+	edges: [ContactEdge]
+}
+
+type ContactEdge {
+	node: Character
+}
+
+type MemberReport {
+	id: Int
+}
+`})
+
+type limitsTestExecutableSchema struct {
+	gqlgen.ExecutableSchema
+}
+
+func (limitsTestExecutableSchema) Schema() *ast.Schema {
+	return limitsTestSchema
+}
+
+func TestComplexitySchema(t *testing.T) {
+
+	const maxInt = int(^uint(0) >> 1)
+
+	var tests = []struct {
+		name            string
+		typeName, field string
+		childComplexity int
+		args            map[string]interface{}
+		want            int
+		wantOK          bool
+	}{
+		{name: "scalar", typeName: "Query", field: "count", want: 1, wantOK: true},
+		{name: "entity", typeName: "Query", field: "character", childComplexity: 3, want: entityFieldCost + 3, wantOK: true},
+		{name: "nested entity", typeName: "Character", field: "corporation", want: entityFieldCost, wantOK: true},
+		{name: "report", typeName: "Query", field: "report", want: reportFieldCost, wantOK: true},
+		{name: "list", typeName: "Query", field: "characters", childComplexity: 2, want: (entityFieldCost + 2) * listFieldMultiplier, wantOK: true},
+		{name: "connection default page size", typeName: "Query", field: "contacts", childComplexity: 4, want: 5 * int(athena.DefaultPageSize), wantOK: true},
+		{name: "connection first", typeName: "Query", field: "contacts", childComplexity: 4, args: map[string]interface{}{"first": 7}, want: 35, wantOK: true},
+		{name: "connection first int64", typeName: "Query", field: "contacts", childComplexity: 4, args: map[string]interface{}{"first": int64(3)}, want: 15, wantOK: true},
+		{name: "connection first zero", typeName: "Query", field: "contacts", childComplexity: 4, args: map[string]interface{}{"first": 0}, want: 5 * int(athena.DefaultPageSize), wantOK: true},
+		{name: "edge is not multiplied", typeName: "ContactConnection", field: "edges", childComplexity: 4, want: 5, wantOK: true},
+		{name: "overflow", typeName: "Query", field: "contacts", childComplexity: maxInt / 2, args: map[string]interface{}{"first": 10}, want: maxInt, wantOK: true},
+		{name: "unknown type", typeName: "Mutation", field: "count", want: 0, wantOK: false},
+		{name: "unknown field", typeName: "Query", field: "missing", want: 0, wantOK: false},
+	}
+
+	schema := complexitySchema{limitsTestExecutableSchema{}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, ok := schema.Complexity(test.typeName, test.field, test.childComplexity, test.args)
+			if got != test.want || ok != test.wantOK {
+				t.Errorf("Complexity() = %d, %t, want %d, %t", got, ok, test.want, test.wantOK)
+			}
+		})
+	}
+
+}
+
+func TestSafeMultiply(t *testing.T) {
+
+	const maxInt = int(^uint(0) >> 1)
+
+	var tests = []struct {
+		name string
+		a, b int
+		want int
+	}{
+		{name: "small", a: 6, b: 7, want: 42},
+		{name: "zero", a: 0, b: maxInt, want: 0},
+		{name: "exact", a: maxInt, b: 1, want: maxInt},
+		{name: "overflow", a: maxInt/2 + 1, b: 2, want: maxInt},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := safeMultiply(test.a, test.b); got != test.want {
+				t.Errorf("safeMultiply(%d, %d) = %d, want %d", test.a, test.b, got, test.want)
+			}
+		})
+	}
+
+}
+
+func TestSelectionSetDepth(t *testing.T) {
+
+	var tests = []struct {
+		name  string
+		query string
+		want  int
+	}{
+		{name: "single field", query: `{ count }`, want: 1},
+		{name: "nested", query: `{ character { corporation { name } } }`, want: 3},
+		{name: "deepest branch", query: `{ count character { id corporation { name } } }`, want: 3},
+		{name: "connection", query: `{ contacts { edges { node { corporation { id } } } } }`, want: 5},
+		{name: "fragment spread", query: `{ character { ...corp } } fragment corp on Character { corporation { name } }`, want: 3},
+		{name: "inline fragment", query: `{ character { ... on Character { corporation { name } } } }`, want: 3},
+		{name: "introspection is skipped", query: `{ __schema { types { fields { type { name } } } } }`, want: 0},
+		{name: "typename is skipped", query: `{ character { __typename } }`, want: 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			doc, err := gqlparser.LoadQuery(limitsTestSchema, test.query)
+			if err != nil {
+				t.Fatalf("LoadQuery() error = %v", err)
+			}
+
+			if got := selectionSetDepth(doc.Operations[0].SelectionSet); got != test.want {
+				t.Errorf("selectionSetDepth() = %d, want %d", got, test.want)
+			}
+		})
+	}
+
+}
+
+func TestClientAddr(t *testing.T) {
+
+	trusted, err := ParseTrustedProxies([]string{"10.0.0.0/8", "192.168.1.1"})
+	if err != nil {
+		t.Fatalf("ParseTrustedProxies() error = %v", err)
+	}
+
+	var tests = []struct {
+		name         string
+		remoteAddr   string
+		forwardedFor string
+		want         string
+	}{
+		{name: "direct", remoteAddr: "203.0.113.5:4000", want: "203.0.113.5"},
+		{name: "direct ignores header", remoteAddr: "203.0.113.5:4000", forwardedFor: "198.51.100.1", want: "203.0.113.5"},
+		{name: "trusted proxy", remoteAddr: "10.1.2.3:4000", forwardedFor: "198.51.100.1", want: "198.51.100.1"},
+		{name: "spoofed hop", remoteAddr: "10.1.2.3:4000", forwardedFor: "1.1.1.1, 198.51.100.1", want: "198.51.100.1"},
+		{name: "chained proxies", remoteAddr: "10.1.2.3:4000", forwardedFor: "198.51.100.1, 192.168.1.1", want: "198.51.100.1"},
+		{name: "trusted proxy without header", remoteAddr: "10.1.2.3:4000", want: "10.1.2.3"},
+		{name: "only proxies", remoteAddr: "10.1.2.3:4000", forwardedFor: "10.9.9.9", want: "10.9.9.9"},
+		{name: "malformed hop", remoteAddr: "10.1.2.3:4000", forwardedFor: "not-an-ip", want: "10.1.2.3"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := &http.Request{RemoteAddr: test.remoteAddr, Header: http.Header{}}
+			if test.forwardedFor != "" {
+				r.Header.Set("X-Forwarded-For", test.forwardedFor)
+			}
+
+			if got := clientAddr(r, trusted); got != test.want {
+				t.Errorf("clientAddr() = %s, want %s", got, test.want)
+			}
+		})
+	}
+
+}
+
+func TestParseTrustedProxies(t *testing.T) {
+
+	var tests = []struct {
+		name    string
+		proxies []string
+		want    []string
+		wantErr bool
+	}{
+		{name: "cidr", proxies: []string{"10.0.0.0/8"}, want: []string{"10.0.0.0/8"}},
+		{name: "address", proxies: []string{"192.168.1.1", "::1"}, want: []string{"192.168.1.1/32", "::1/128"}},
+		{name: "blank", proxies: []string{" "}, want: []string{}},
+		{name: "invalid address", proxies: []string{"proxy"}, wantErr: true},
+		{name: "invalid cidr", proxies: []string{"10.0.0.0/33"}, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := ParseTrustedProxies(test.proxies)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("ParseTrustedProxies() error = %v, wantErr %t", err, test.wantErr)
+			}
+			if test.wantErr {
+				return
+			}
+
+			if len(got) != len(test.want) {
+				t.Fatalf("ParseTrustedProxies() = %v, want %v", got, test.want)
+			}
+			for i, network := range got {
+				if network.String() != test.want[i] {
+					t.Errorf("ParseTrustedProxies()[%d] = %s, want %s", i, network, test.want[i])
+				}
+			}
+		})
+	}
+
+}
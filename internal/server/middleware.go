@@ -12,7 +12,7 @@ func (s *server) cors(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Origin, Content-Type, Accept, Authorization")
+		w.Header().Set("Access-Control-Allow-Headers", "Origin, Content-Type, Accept, Authorization, X-Api-Key")
 		w.Header().Set("Access-Control-Allow-Credentials", "true")
 		w.Header().Set("Access-Control-Max-Age", "600")
 
@@ -34,10 +34,13 @@ func (s *server) dataloaders(next http.Handler) http.Handler {
 			dataloaders.CtxKey,
 			dataloaders.New(
 				ctx,
+				s.member,
 				s.alliance,
 				s.character,
 				s.corporation,
 				s.universe,
+				s.contract,
+				s.mail,
 			),
 		)
 
@@ -0,0 +1,266 @@
+package server
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var (
+	timeType      = reflect.TypeOf(time.Time{})
+	marshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// buildOpenAPIDocument describes the REST routes in an OpenAPI 3 document. The schemas of the
+// responses are generated from the json tags of the models that the routes respond with, so the
+// document can not drift away from what is actually served
+func buildOpenAPIDocument(prefix string, routes []restRoute) map[string]interface{} {
+
+	schemas := make(map[string]interface{})
+	schemas["Error"] = map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"message": map[string]interface{}{"type": "string"},
+		},
+		"required": []string{"message"},
+	}
+
+	errorResponse := func(description string) map[string]interface{} {
+		return map[string]interface{}{
+			"description": description,
+			"content": map[string]interface{}{
+				"application/json": map[string]interface{}{
+					"schema": map[string]interface{}{"$ref": "#/components/schemas/Error"},
+				},
+			},
+		}
+	}
+
+	paths := make(map[string]interface{})
+	for _, route := range routes {
+
+		parameters := []interface{}{
+			map[string]interface{}{
+				"name":     "memberID",
+				"in":       "path",
+				"required": true,
+				"schema":   map[string]interface{}{"type": "integer", "minimum": 0},
+			},
+		}
+
+		schema := openAPISchema(reflect.TypeOf(route.model), schemas)
+		if route.paginated {
+			page := openAPISchema(reflect.TypeOf(restPage{}), schemas).(map[string]interface{})
+			schema = map[string]interface{}{
+				"allOf": []interface{}{
+					page,
+					map[string]interface{}{
+						"type": "object",
+						"properties": map[string]interface{}{
+							"data": map[string]interface{}{"type": "array", "items": schema},
+						},
+					},
+				},
+			}
+
+			parameters = append(parameters,
+				map[string]interface{}{
+					"name":   "first",
+					"in":     "query",
+					"schema": map[string]interface{}{"type": "integer", "minimum": 1},
+				},
+				map[string]interface{}{
+					"name":   "after",
+					"in":     "query",
+					"schema": map[string]interface{}{"type": "string"},
+				},
+			)
+		}
+
+		paths[prefix+route.path] = map[string]interface{}{
+			"get": map[string]interface{}{
+				"operationId": route.query,
+				"summary":     route.summary,
+				"parameters":  parameters,
+				"responses": map[string]interface{}{
+					"200": map[string]interface{}{
+						"description": route.summary,
+						"headers": map[string]interface{}{
+							"ETag":          map[string]interface{}{"schema": map[string]interface{}{"type": "string"}},
+							"Last-Modified": map[string]interface{}{"schema": map[string]interface{}{"type": "string"}},
+						},
+						"content": map[string]interface{}{
+							"application/json": map[string]interface{}{"schema": schema},
+						},
+					},
+					"304": map[string]interface{}{"description": "Not Modified"},
+					"400": errorResponse("Bad Request"),
+					"401": errorResponse("Unauthorized"),
+					"403": errorResponse("Forbidden"),
+					"404": errorResponse("Not Found"),
+				},
+			},
+		}
+	}
+
+	return map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":   "Athena",
+			"version": "v1",
+		},
+		"components": map[string]interface{}{
+			"schemas": schemas,
+			"securitySchemes": map[string]interface{}{
+				"apiKey": map[string]interface{}{"type": "apiKey", "in": "header", "name": "X-Api-Key"},
+				"bearer": map[string]interface{}{"type": "http", "scheme": "bearer"},
+			},
+		},
+		"security": []interface{}{
+			map[string]interface{}{"apiKey": []string{}},
+			map[string]interface{}{"bearer": []string{}},
+		},
+		"paths": paths,
+	}
+
+}
+
+// openAPISchema returns the schema of the JSON encoding of t. Named structs are added to schemas
+// and referenced, every other type is described inline
+func openAPISchema(t reflect.Type, schemas map[string]interface{}) interface{} {
+
+	switch {
+	case t == timeType:
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	case t.Kind() == reflect.Ptr:
+		return openAPINullable(openAPISchema(t.Elem(), schemas))
+	case t.Kind() == reflect.Struct && t.Implements(marshalerType):
+		// Nullable wrappers such as the types of the null package marshal to the value that they
+		// wrap, or null when they are not valid
+		if value, ok := nullableValue(t); ok {
+			return openAPINullable(openAPISchema(value, schemas))
+		}
+
+		return map[string]interface{}{}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer", "minimum": 0}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{"type": "array", "items": openAPISchema(t.Elem(), schemas)}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": openAPISchema(t.Elem(), schemas)}
+	case reflect.Struct:
+	default:
+		return map[string]interface{}{}
+	}
+
+	// Schemas are named after the exported name of the type, restPage becomes Page
+	name := strings.TrimPrefix(t.Name(), "rest")
+	ref := map[string]interface{}{"$ref": "#/components/schemas/" + name}
+	if _, ok := schemas[name]; ok {
+		return ref
+	}
+
+	// Reserve the name before the fields are described so that recursive types terminate
+	schemas[name] = nil
+
+	properties := make(map[string]interface{})
+	required := make([]string, 0)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		tag := strings.Split(field.Tag.Get("json"), ",")
+		if tag[0] == "-" {
+			continue
+		}
+
+		key := field.Name
+		if tag[0] != "" {
+			key = tag[0]
+		}
+
+		// The items of a page are described by the route that serves it
+		if field.Type.Kind() == reflect.Interface {
+			continue
+		}
+
+		schema := openAPISchema(field.Type, schemas)
+		properties[key] = schema
+
+		omitempty := len(tag) > 1 && tag[1] == "omitempty"
+		if _, nullable := schema.(map[string]interface{})["nullable"]; !omitempty && !nullable {
+			required = append(required, key)
+		}
+	}
+
+	schema := map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+
+	schemas[name] = schema
+
+	return ref
+
+}
+
+func openAPINullable(schema interface{}) interface{} {
+
+	s, ok := schema.(map[string]interface{})
+	if !ok {
+		return schema
+	}
+
+	if _, ok := s["$ref"]; ok {
+		return map[string]interface{}{"allOf": []interface{}{s}, "nullable": true}
+	}
+
+	nullable := make(map[string]interface{}, len(s)+1)
+	for k, v := range s {
+		nullable[k] = v
+	}
+	nullable["nullable"] = true
+
+	return nullable
+
+}
+
+// nullableValue returns the type of the value that a nullable wrapper holds. Wrappers are structs
+// of a Valid flag and the value itself
+func nullableValue(t reflect.Type) (reflect.Type, bool) {
+
+	if t.NumField() != 2 {
+		return nil, false
+	}
+
+	valid, ok := t.FieldByName("Valid")
+	if !ok || valid.Type.Kind() != reflect.Bool {
+		return nil, false
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		if field := t.Field(i); field.Name != "Valid" {
+			return field.Type, true
+		}
+	}
+
+	return nil, false
+
+}
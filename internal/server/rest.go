@@ -0,0 +1,410 @@
+package server
+
+import (
+	"context"
+	"crypto/sha1"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/eveisesi/athena"
+	"github.com/eveisesi/athena/internal/apikey"
+	"github.com/go-chi/chi"
+	"github.com/sirupsen/logrus"
+)
+
+// restRoute is a read only endpoint of the REST API. Every route serves the data of a single member
+// and is guarded by the same permissions as the GraphQL query that serves the same data, so API Keys
+// that have not been granted that query may not use the route either
+type restRoute struct {
+	path    string
+	summary string
+	query   string
+	scope   athena.Scope
+	// model is a value of the type that the route responds with, or of the items of a page when
+	// the route is paginated. It is only used to describe the route in the OpenAPI document
+	model     interface{}
+	paginated bool
+	handler   restHandler
+}
+
+// restHandler returns the data of a member. page is nil unless the route is paginated. A nil
+// pointer is served as a 404
+type restHandler func(ctx context.Context, memberID uint, page *athena.Page) (interface{}, error)
+
+// restPage is the envelope that paginated routes respond with. The cursor of the last record is
+// passed as the after query parameter to fetch the page that follows it
+type restPage struct {
+	Data       interface{}  `json:"data"`
+	PageInfo   restPageInfo `json:"page_info"`
+	TotalCount uint         `json:"total_count"`
+}
+
+type restPageInfo struct {
+	HasNextPage bool    `json:"has_next_page"`
+	EndCursor   *string `json:"end_cursor"`
+}
+
+func (s *server) restRoutes() []restRoute {
+	return []restRoute{
+		{
+			path:      "/members/{memberID}/assets",
+			summary:   "Assets of a member",
+			query:     "memberAssets",
+			scope:     athena.ReadAssetsV1,
+			model:     athena.MemberAsset{},
+			paginated: true,
+			handler:   s.restMemberAssets,
+		},
+		{
+			path:    "/members/{memberID}/clones",
+			summary: "Home location and jump clones of a member",
+			query:   "memberClones",
+			scope:   athena.ReadClonesV1,
+			model:   athena.MemberClones{},
+			handler: s.restMemberClones,
+		},
+		{
+			path:    "/members/{memberID}/location",
+			summary: "Current location of a member",
+			query:   "memberLocation",
+			scope:   athena.ReadLocationV1,
+			model:   athena.MemberLocation{},
+			handler: s.restMemberLocation,
+		},
+		{
+			path:    "/members/{memberID}/skills",
+			summary: "Trained skills of a member",
+			query:   "memberSkills",
+			scope:   athena.ReadSkillsV1,
+			model:   athena.MemberSkills{},
+			handler: s.restMemberSkills,
+		},
+		{
+			path:    "/members/{memberID}/skills/queue",
+			summary: "Skill queue of a member",
+			query:   "memberSkillQueue",
+			scope:   athena.ReadSkillQueueV1,
+			model:   []*athena.MemberSkillQueue{},
+			handler: s.restMemberSkillQueue,
+		},
+		{
+			path:    "/members/{memberID}/wallet/balance",
+			summary: "Wallet balance of a member",
+			query:   "memberWalletBalance",
+			scope:   athena.ReadWalletV1,
+			model:   athena.MemberWalletBalance{},
+			handler: s.restMemberWalletBalance,
+		},
+		{
+			path:      "/members/{memberID}/wallet/journal",
+			summary:   "Wallet journal of a member",
+			query:     "memberWalletJournal",
+			scope:     athena.ReadWalletV1,
+			model:     athena.MemberWalletJournal{},
+			paginated: true,
+			handler:   s.restMemberWalletJournal,
+		},
+		{
+			path:      "/members/{memberID}/wallet/transactions",
+			summary:   "Wallet transactions of a member",
+			query:     "memberWalletTransactions",
+			scope:     athena.ReadWalletV1,
+			model:     athena.MemberWalletTransaction{},
+			paginated: true,
+			handler:   s.restMemberWalletTransactions,
+		},
+	}
+}
+
+func (s *server) buildRestRouter(r chi.Router) {
+
+	routes := s.restRoutes()
+
+	document := buildOpenAPIDocument("/api/v1", routes)
+	r.Get("/openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		s.writeResponse(r.Context(), w, http.StatusOK, document)
+	})
+
+	for _, route := range routes {
+		r.Get(route.path, s.handleRestRoute(route))
+	}
+
+	r.NotFound(func(w http.ResponseWriter, r *http.Request) {
+		s.writeError(r.Context(), w, http.StatusNotFound, fmt.Errorf("route %s does not exist", r.URL.Path))
+	})
+
+}
+
+func (s *server) handleRestRoute(route restRoute) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		var ctx = r.Context()
+
+		id, err := strconv.ParseUint(chi.URLParam(r, "memberID"), 10, 32)
+		if err != nil {
+			s.writeError(ctx, w, http.StatusBadRequest, fmt.Errorf("member id must be a positive integer"))
+			return
+		}
+		memberID := uint(id)
+
+		status, err := s.authorizeRestRequest(ctx, route.query, memberID)
+		if err != nil {
+			s.writeError(ctx, w, status, err)
+			return
+		}
+
+		var page *athena.Page
+		if route.paginated {
+			page, err = restPageFromRequest(r)
+			if err != nil {
+				s.writeError(ctx, w, http.StatusBadRequest, err)
+				return
+			}
+		}
+
+		entry := s.logger.WithContext(ctx).WithFields(logrus.Fields{
+			"member_id": memberID,
+			"route":     route.path,
+		})
+
+		etags, err := s.esi.ScopeEtags(ctx, route.scope, memberID)
+		if err != nil {
+			entry.WithError(err).Error("failed to fetch etags of scope")
+			s.writeError(ctx, w, http.StatusInternalServerError, fmt.Errorf("failed to fetch etags of scope"))
+			return
+		}
+
+		if writeRestValidators(w, r, etags) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		data, err := route.handler(ctx, memberID, page)
+		if err != nil {
+			entry.WithError(err).Error("failed to fetch data for route")
+			s.writeError(ctx, w, http.StatusInternalServerError, fmt.Errorf("failed to fetch data for route"))
+			return
+		}
+
+		value := reflect.ValueOf(data)
+		switch {
+		case data == nil, value.Kind() == reflect.Ptr && value.IsNil():
+			s.writeError(ctx, w, http.StatusNotFound, fmt.Errorf("no data has been synced for member %d yet", memberID))
+			return
+		case value.Kind() == reflect.Slice && value.IsNil():
+			data = []struct{}{}
+		}
+
+		s.writeResponse(ctx, w, http.StatusOK, data)
+
+	}
+}
+
+// authorizeRestRequest applies the permissions of the GraphQL API to a REST request. API Keys must
+// have been granted query, and the member is authorized by apikey.Service.AuthorizeMember just as
+// it is for a GraphQL query. The returned status code describes why a request was refused
+func (s *server) authorizeRestRequest(ctx context.Context, query string, memberID uint) (int, error) {
+
+	if key := s.apikey.APIKeyFromContext(ctx); key != nil && !key.AllowsQuery(query) {
+		return http.StatusForbidden, fmt.Errorf("api key is not permitted to execute %s", query)
+	}
+
+	err := s.apikey.AuthorizeMember(ctx, s.member.MemberFromContext(ctx), memberID)
+	if errors.Is(err, apikey.ErrUnauthenticated) {
+		return http.StatusUnauthorized, err
+	}
+	if err != nil {
+		return http.StatusForbidden, err
+	}
+
+	return http.StatusOK, nil
+
+}
+
+// restPageFromRequest builds a Page from the first and after query parameters of a request
+func restPageFromRequest(r *http.Request) (*athena.Page, error) {
+
+	query := r.URL.Query()
+
+	var first *uint
+	if v := query.Get("first"); v != "" {
+		parsed, err := strconv.ParseUint(v, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("first must be a positive integer")
+		}
+
+		f := uint(parsed)
+		first = &f
+	}
+
+	var after *string
+	if v := query.Get("after"); v != "" {
+		after = &v
+	}
+
+	return athena.NewPage(first, after, nil)
+
+}
+
+// newRestPage wraps the n fetched records of data in a restPage. athena.Page fetches one record past
+// the end of the page to tell whether another page follows it, data must already have been cut down
+// to the records that belong to the page. cursor returns the cursor of the record at index i
+func newRestPage(data interface{}, fetched, n int, total uint, cursor func(i int) athena.Cursor) *restPage {
+
+	info := restPageInfo{
+		HasNextPage: fetched > n,
+	}
+
+	if n > 0 {
+		end := cursor(n - 1).String()
+		info.EndCursor = &end
+	}
+
+	return &restPage{Data: data, PageInfo: info, TotalCount: total}
+
+}
+
+func restPageLength(page *athena.Page, fetched int) int {
+	if fetched > int(page.First) {
+		return int(page.First)
+	}
+
+	return fetched
+}
+
+// writeRestValidators sets the ETag and Last-Modified headers of a response from the etags of the
+// ESI responses that the data was built from and reports whether the request can be answered with a
+// 304. The data only changes when ESI is asked for it again, which also moves CachedUntil forward,
+// so CachedUntil identifies the version of the data that is being served
+func writeRestValidators(w http.ResponseWriter, r *http.Request, etags []*athena.Etag) bool {
+
+	if len(etags) == 0 {
+		return false
+	}
+
+	sort.Slice(etags, func(i, j int) bool {
+		return etags[i].EtagID < etags[j].EtagID
+	})
+
+	var modified time.Time
+	hash := sha1.New()
+	_, _ = fmt.Fprintf(hash, "%s\n", r.URL.RequestURI())
+	for _, etag := range etags {
+		_, _ = fmt.Fprintf(hash, "%s:%s:%d\n", etag.EtagID, etag.Etag, etag.CachedUntil.Unix())
+		if etag.UpdatedAt.After(modified) {
+			modified = etag.UpdatedAt
+		}
+	}
+
+	tag := fmt.Sprintf(`W/"%x"`, hash.Sum(nil))
+	modified = modified.UTC().Truncate(time.Second)
+
+	w.Header().Set("ETag", tag)
+	w.Header().Set("Last-Modified", modified.Format(http.TimeFormat))
+
+	if match := r.Header.Get("If-None-Match"); match != "" {
+		return match == tag || match == "*"
+	}
+
+	if since := r.Header.Get("If-Modified-Since"); since != "" {
+		t, err := http.ParseTime(since)
+		return err == nil && !modified.After(t)
+	}
+
+	return false
+
+}
+
+func (s *server) restMemberAssets(ctx context.Context, memberID uint, page *athena.Page) (interface{}, error) {
+
+	assets, err := s.asset.MemberAssets(ctx, memberID, page)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.asset.MemberAssetCount(ctx, memberID)
+	if err != nil {
+		return nil, err
+	}
+
+	n := restPageLength(page, len(assets))
+	return newRestPage(assets[:n], len(assets), n, count, func(i int) athena.Cursor {
+		return athena.NewCursor(assets[i].ItemID)
+	}), nil
+
+}
+
+func (s *server) restMemberClones(ctx context.Context, memberID uint, page *athena.Page) (interface{}, error) {
+	return s.clone.MemberClones(ctx, memberID)
+}
+
+func (s *server) restMemberLocation(ctx context.Context, memberID uint, page *athena.Page) (interface{}, error) {
+	return s.location.MemberLocation(ctx, memberID)
+}
+
+func (s *server) restMemberSkills(ctx context.Context, memberID uint, page *athena.Page) (interface{}, error) {
+
+	properties, err := s.skill.MemberSkillProperties(ctx, memberID)
+	if err != nil || properties == nil {
+		return properties, err
+	}
+
+	properties.Skills, err = s.skill.MemberSkills(ctx, memberID)
+	if err != nil {
+		return nil, err
+	}
+
+	return properties, nil
+
+}
+
+func (s *server) restMemberSkillQueue(ctx context.Context, memberID uint, page *athena.Page) (interface{}, error) {
+	return s.skill.MemberSkillQueue(ctx, memberID)
+}
+
+func (s *server) restMemberWalletBalance(ctx context.Context, memberID uint, page *athena.Page) (interface{}, error) {
+	return s.wallet.MemberBalance(ctx, memberID)
+}
+
+func (s *server) restMemberWalletJournal(ctx context.Context, memberID uint, page *athena.Page) (interface{}, error) {
+
+	entries, err := s.wallet.MemberWalletJournals(ctx, memberID, page)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.wallet.MemberWalletJournalCount(ctx, memberID)
+	if err != nil {
+		return nil, err
+	}
+
+	n := restPageLength(page, len(entries))
+	return newRestPage(entries[:n], len(entries), n, count, func(i int) athena.Cursor {
+		return athena.NewCursor(entries[i].JournalID)
+	}), nil
+
+}
+
+func (s *server) restMemberWalletTransactions(ctx context.Context, memberID uint, page *athena.Page) (interface{}, error) {
+
+	transactions, err := s.wallet.MemberWalletTransactions(ctx, memberID, page)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.wallet.MemberWalletTransactionCount(ctx, memberID)
+	if err != nil {
+		return nil, err
+	}
+
+	n := restPageLength(page, len(transactions))
+	return newRestPage(transactions[:n], len(transactions), n, count, func(i int) athena.Cursor {
+		return athena.NewCursor(transactions[i].TransactionID)
+	}), nil
+
+}
@@ -18,6 +18,8 @@ import (
 	"github.com/99designs/gqlgen/graphql/handler/lru"
 	"github.com/99designs/gqlgen/graphql/handler/transport"
 	"github.com/eveisesi/athena/internal/alliance"
+	"github.com/eveisesi/athena/internal/analysis"
+	"github.com/eveisesi/athena/internal/apikey"
 	"github.com/eveisesi/athena/internal/asset"
 	"github.com/eveisesi/athena/internal/auth"
 	"github.com/eveisesi/athena/internal/cache"
@@ -26,11 +28,18 @@ import (
 	"github.com/eveisesi/athena/internal/contact"
 	"github.com/eveisesi/athena/internal/contract"
 	"github.com/eveisesi/athena/internal/corporation"
+	"github.com/eveisesi/athena/internal/esi"
+	"github.com/eveisesi/athena/internal/fittings"
 	"github.com/eveisesi/athena/internal/graphql/resolvers"
 	graphql "github.com/eveisesi/athena/internal/graphql/service"
 	"github.com/eveisesi/athena/internal/location"
+	"github.com/eveisesi/athena/internal/mail"
 	"github.com/eveisesi/athena/internal/member"
+	"github.com/eveisesi/athena/internal/redflag"
+	"github.com/eveisesi/athena/internal/report"
+	"github.com/eveisesi/athena/internal/skill"
 	"github.com/eveisesi/athena/internal/universe"
+	"github.com/eveisesi/athena/internal/wallet"
 	"github.com/go-chi/chi"
 	"github.com/go-chi/chi/middleware"
 	"github.com/newrelic/go-agent/v3/newrelic"
@@ -40,11 +49,14 @@ import (
 type server struct {
 	port     uint
 	env      athena.Environment
+	limits   Limits
 	logger   *logrus.Logger
 	newrelic *newrelic.Application
 
+	apikey      apikey.Service
 	auth        auth.Service
 	cache       cache.Service
+	esi         esi.Service
 	member      member.Service
 	character   character.Service
 	corporation corporation.Service
@@ -55,6 +67,13 @@ type server struct {
 	contact     contact.Service
 	contract    contract.Service
 	asset       asset.Service
+	skill       skill.Service
+	wallet      wallet.Service
+	mail        mail.Service
+	fittings    fittings.Service
+	report      report.Service
+	redflag     redflag.Service
+	analysis    analysis.Service
 
 	server *http.Server
 }
@@ -62,9 +81,12 @@ type server struct {
 func NewServer(
 	port uint,
 	env athena.Environment,
+	limits Limits,
 	logger *logrus.Logger,
 	cache cache.Service,
 	newrelic *newrelic.Application,
+	esi esi.Service,
+	apikey apikey.Service,
 	auth auth.Service,
 	member member.Service,
 	character character.Service,
@@ -76,14 +98,24 @@ func NewServer(
 	contact contact.Service,
 	contract contract.Service,
 	asset asset.Service,
+	skill skill.Service,
+	wallet wallet.Service,
+	mail mail.Service,
+	fittings fittings.Service,
+	report report.Service,
+	redflag redflag.Service,
+	analysis analysis.Service,
 ) *server {
 
 	s := &server{
 		port:        port,
 		env:         env,
+		limits:      limits,
 		logger:      logger,
 		cache:       cache,
 		newrelic:    newrelic,
+		esi:         esi,
+		apikey:      apikey,
 		auth:        auth,
 		member:      member,
 		character:   character,
@@ -95,6 +127,13 @@ func NewServer(
 		contact:     contact,
 		contract:    contract,
 		asset:       asset,
+		skill:       skill,
+		wallet:      wallet,
+		mail:        mail,
+		fittings:    fittings,
+		report:      report,
+		redflag:     redflag,
+		analysis:    analysis,
 	}
 
 	s.server = &http.Server{
@@ -131,23 +170,30 @@ func (s *server) buildRouter() *chi.Mux {
 	r.Group(func(r chi.Router) {
 		r.Use(
 			middleware.SetHeader("Content-Type", "application/json"),
+			s.apikey.Middleware,
 			s.member.Middleware,
+			s.rateLimit,
 		)
 
+		r.Route("/api/v1", s.buildRestRouter)
+
 		r.Group(func(r chi.Router) {
 			r.Use(s.dataloaders)
 
 			// directives := graphql.NewDirectives()
 			es := graphql.NewExecutableSchema(graphql.Config{
 				Resolvers: resolvers.New(
-					s.logger, s.auth, s.member,
+					s.logger, s.apikey, s.auth, s.member,
 					s.character, s.corporation, s.alliance,
 					s.universe, s.location, s.clone,
 					s.contact, s.contract, s.asset,
+					s.skill, s.wallet, s.mail,
+					s.fittings, s.report, s.redflag,
+					s.analysis,
 				),
 				// Directives: generated.DirectiveRoot{HasGrant: directives.HasGrant},
 			})
-			queryHandler := handler.New(es)
+			queryHandler := handler.New(complexitySchema{es})
 
 			queryHandler.AddTransport(transport.Websocket{
 				Upgrader: websocket.Upgrader{
@@ -156,11 +202,17 @@ func (s *server) buildRouter() *chi.Mux {
 					},
 				},
 				KeepAlivePingInterval: 2 * time.Second,
+				InitFunc:              s.initWebsocket,
 			})
 			queryHandler.AddTransport(transport.POST{})
 
 			queryHandler.SetQueryCache(lru.New(1000))
 
+			queryHandler.AroundFields(s.authorizeAPIKey)
+
+			queryHandler.Use(extension.FixedComplexityLimit(s.limits.MaxComplexity))
+			queryHandler.Use(depthLimit{limit: s.limits.MaxDepth})
+
 			queryHandler.Use(extension.AutomaticPersistedQuery{
 				Cache: lru.New(100),
 			})
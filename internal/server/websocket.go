@@ -0,0 +1,48 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/eveisesi/athena/internal/apikey"
+)
+
+// initWebsocket authenticates a websocket connection from the payload of its connection_init
+// message. Browsers cannot set headers on a websocket upgrade, so the payload carries the same
+// credentials that the apikey and member middleware read from the headers of a request.
+// Connections that were already authenticated by the middleware are left as they are
+func (s *server) initWebsocket(ctx context.Context, payload transport.InitPayload) (context.Context, error) {
+
+	if s.apikey.APIKeyFromContext(ctx) != nil || s.member.MemberFromContext(ctx) != nil {
+		return ctx, nil
+	}
+
+	plain := payload.GetString(apikey.HeaderName)
+	if plain == "" {
+		plain = payload.GetString(strings.ToLower(apikey.HeaderName))
+	}
+	if plain != "" {
+		ctx, err := s.apikey.ContextWithAPIKey(ctx, plain)
+		if err != nil {
+			return ctx, fmt.Errorf("failed to authenticate api key: %w", err)
+		}
+
+		return ctx, nil
+	}
+
+	token := payload.Authorization()
+	if !strings.HasPrefix(strings.ToLower(token), "bearer ") {
+		return ctx, nil
+	}
+
+	ctx, err := s.member.ContextWithSession(ctx, token[7:])
+	if err != nil {
+		s.logger.WithError(err).Error("failed to authenticate websocket session")
+		return ctx, fmt.Errorf("failed to authenticate session")
+	}
+
+	return ctx, nil
+
+}
@@ -22,6 +22,8 @@ type Service interface {
 	MemberSkills(ctx context.Context, memberID uint) ([]*athena.Skill, error)
 	FetchMemberSkillQueue(ctx context.Context, member *athena.Member) (*athena.Etag, error)
 	MemberSkillQueue(ctx context.Context, memberID uint) ([]*athena.MemberSkillQueue, error)
+	FetchMemberAttributes(ctx context.Context, member *athena.Member) (*athena.Etag, error)
+	MemberAttributes(ctx context.Context, memberID uint) (*athena.MemberAttributes, error)
 }
 
 type service struct {
@@ -78,7 +80,7 @@ func (s *service) FetchMemberSkills(ctx context.Context, member *athena.Member)
 	skillProperties, etag, _, err := s.esi.GetCharacterSkills(ctx, member.ID, member.AccessToken.String)
 	if err != nil {
 		entry.WithError(err).Error("failed to fetch skills for member")
-		return nil, fmt.Errorf("failed to fetch skills for member")
+		return nil, fmt.Errorf("failed to fetch skills for member: %w", err)
 	}
 
 	if petag != "" && etag.Etag == petag {
@@ -106,6 +108,11 @@ func (s *service) FetchMemberSkills(ctx context.Context, member *athena.Member)
 		}
 	}
 
+	err = s.cache.PublishMemberEvent(ctx, athena.NewMemberEvent(member.ID, athena.MemberSkillsChanged))
+	if err != nil {
+		entry.WithError(err).Error("failed to publish member skills changed event")
+	}
+
 	return etag, nil
 
 }
@@ -294,7 +301,7 @@ func (s *service) FetchMemberSkillQueue(ctx context.Context, member *athena.Memb
 	newPositions, etag, _, err := s.esi.GetCharacterSkillQueue(ctx, member.ID, member.AccessToken.String)
 	if err != nil {
 		entry.WithError(err).Error("failed to fetch member skill queue from ESI")
-		return nil, fmt.Errorf("failed to fetch member skill queue from ESI")
+		return nil, fmt.Errorf("failed to fetch member skill queue from ESI: %w", err)
 	}
 
 	if petag != "" && etag.Etag == petag {
@@ -320,6 +327,11 @@ func (s *service) FetchMemberSkillQueue(ctx context.Context, member *athena.Memb
 		}
 	}
 
+	err = s.cache.PublishMemberEvent(ctx, athena.NewMemberEvent(member.ID, athena.MemberSkillQueueChanged))
+	if err != nil {
+		entry.WithError(err).Error("failed to publish member skill queue changed event")
+	}
+
 	return etag, nil
 
 }
@@ -444,3 +456,108 @@ func (s *service) MemberSkillQueue(ctx context.Context, memberID uint) ([]*athen
 	return positions, nil
 
 }
+
+func (s *service) FetchMemberAttributes(ctx context.Context, member *athena.Member) (*athena.Etag, error) {
+
+	entry := s.logger.WithContext(ctx).WithFields(logrus.Fields{
+		"member_id": member.ID,
+		"service":   serviceIdentifier,
+		"method":    "FetchMemberAttributes",
+	})
+
+	etag, err := s.esi.Etag(ctx, esi.GetCharacterAttributes, esi.ModWithCharacterID(member.ID))
+	if err != nil {
+		entry.WithError(err).Error("failed to fetch etag object")
+		return nil, fmt.Errorf("failed to fetch etag object")
+	}
+
+	var petag string
+	if etag != nil {
+		if etag.CachedUntil.After(time.Now()) {
+			return etag, nil
+		}
+
+		petag = etag.Etag
+	}
+
+	attributes, etag, _, err := s.esi.GetCharacterAttributes(ctx, member.ID, member.AccessToken.String)
+	if err != nil {
+		entry.WithError(err).Error("failed to fetch attributes for member")
+		return nil, fmt.Errorf("failed to fetch attributes for member: %w", err)
+	}
+
+	if petag != "" && etag.Etag == petag {
+		return etag, nil
+	}
+
+	attributes.MemberID = member.ID
+
+	existing, err := s.skills.MemberAttributes(ctx, member.ID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		entry.WithError(err).Error("failed to fetch member attributes from db")
+		return nil, fmt.Errorf("failed to fetch member attributes from db")
+	}
+
+	switch existing == nil || errors.Is(err, sql.ErrNoRows) {
+	case true:
+		attributes, err = s.skills.CreateMemberAttributes(ctx, attributes)
+		if err != nil {
+			entry.WithError(err).Error("failed to create member attributes in db")
+			return nil, fmt.Errorf("failed to create member attributes in db")
+		}
+	case false:
+		err = s.skills.UpdateMemberAttributes(ctx, member.ID, attributes)
+		if err != nil {
+			entry.WithError(err).Error("failed to update member attributes in db")
+			return nil, fmt.Errorf("failed to update member attributes in db")
+		}
+	}
+
+	err = s.cache.SetMemberAttributes(ctx, member.ID, attributes)
+	if err != nil {
+		entry.WithError(err).Error("failed to cache member attributes")
+	}
+
+	err = s.cache.PublishMemberEvent(ctx, athena.NewMemberEvent(member.ID, athena.MemberAttributesChanged))
+	if err != nil {
+		entry.WithError(err).Error("failed to publish member attributes changed event")
+	}
+
+	return etag, nil
+
+}
+
+func (s *service) MemberAttributes(ctx context.Context, memberID uint) (*athena.MemberAttributes, error) {
+
+	entry := s.logger.WithContext(ctx).WithFields(logrus.Fields{
+		"member_id": memberID,
+		"service":   serviceIdentifier,
+		"method":    "MemberAttributes",
+	})
+
+	attributes, err := s.cache.MemberAttributes(ctx, memberID)
+	if err != nil {
+		entry.WithError(err).Error("failed to fetch member attributes from cache")
+		return nil, fmt.Errorf("failed to fetch member attributes from cache")
+	}
+
+	if attributes != nil {
+		return attributes, nil
+	}
+
+	attributes, err = s.skills.MemberAttributes(ctx, memberID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		entry.WithError(err).Error("failed to fetch member attributes from DB")
+		return nil, fmt.Errorf("failed to fetch member attributes from DB")
+	}
+
+	if err == nil {
+		err = s.cache.SetMemberAttributes(ctx, memberID, attributes)
+		if err != nil {
+			entry.WithError(err).Error("failed to cache member attributes")
+		}
+	}
+
+	return attributes, nil
+
+}
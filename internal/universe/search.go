@@ -0,0 +1,261 @@
+package universe
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/eveisesi/athena"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	DefaultSearchLimit uint = 10
+	MaxSearchLimit     uint = 50
+
+	minSearchTermLength = 2
+
+	// searchPrefixLength is the number of leading characters that a name must share with the search
+	// term to be considered at all. Candidates are selected through the index on the name column, so
+	// typos are only tolerated beyond this prefix
+	searchPrefixLength = 2
+	// searchCandidateLimit bounds the number of names that are ranked for a single search. Names that
+	// start with the whole term are selected separately, so a short prefix cannot crowd them out
+	searchCandidateLimit = 1000
+)
+
+// SearchTypes returns the published types whose name best matches term, ranked by searchRank
+func (s *service) SearchTypes(ctx context.Context, term string, limit uint) ([]*athena.Type, error) {
+
+	entry := s.logger.WithContext(ctx).WithFields(logrus.Fields{
+		"term":    term,
+		"service": serviceIdentifier,
+		"method":  "SearchTypes",
+	})
+
+	term, prefix, limit, err := searchParams(term, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]*athena.Type, 0)
+	seen := make(map[uint]bool)
+	for _, operators := range searchOperators(term, prefix, limit) {
+		types, err := s.universe.Types(ctx, append(operators, athena.NewEqualOperator("published", 1))...)
+		if err != nil {
+			entry.WithError(err).Error("failed to fetch search candidates from db")
+			return nil, fmt.Errorf("failed to fetch search candidates from db")
+		}
+
+		for _, t := range types {
+			if !seen[t.ID] {
+				seen[t.ID] = true
+				candidates = append(candidates, t)
+			}
+		}
+	}
+
+	names := make([]string, 0, len(candidates))
+	for _, candidate := range candidates {
+		names = append(names, candidate.Name)
+	}
+
+	indexes := searchRank(term, names, limit)
+	types := make([]*athena.Type, 0, len(indexes))
+	for _, i := range indexes {
+		types = append(types, candidates[i])
+	}
+
+	return types, nil
+
+}
+
+// SearchSolarSystems returns the solar systems whose name best matches term, ranked by searchRank
+func (s *service) SearchSolarSystems(ctx context.Context, term string, limit uint) ([]*athena.SolarSystem, error) {
+
+	entry := s.logger.WithContext(ctx).WithFields(logrus.Fields{
+		"term":    term,
+		"service": serviceIdentifier,
+		"method":  "SearchSolarSystems",
+	})
+
+	term, prefix, limit, err := searchParams(term, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]*athena.SolarSystem, 0)
+	seen := make(map[uint]bool)
+	for _, operators := range searchOperators(term, prefix, limit) {
+		systems, err := s.universe.SolarSystems(ctx, operators...)
+		if err != nil {
+			entry.WithError(err).Error("failed to fetch search candidates from db")
+			return nil, fmt.Errorf("failed to fetch search candidates from db")
+		}
+
+		for _, system := range systems {
+			if !seen[system.ID] {
+				seen[system.ID] = true
+				candidates = append(candidates, system)
+			}
+		}
+	}
+
+	names := make([]string, 0, len(candidates))
+	for _, candidate := range candidates {
+		names = append(names, candidate.Name)
+	}
+
+	indexes := searchRank(term, names, limit)
+	systems := make([]*athena.SolarSystem, 0, len(indexes))
+	for _, i := range indexes {
+		systems = append(systems, candidates[i])
+	}
+
+	return systems, nil
+
+}
+
+// searchParams normalizes the arguments of a search and returns the prefix that candidates are selected by
+func searchParams(term string, limit uint) (string, string, uint, error) {
+
+	term = strings.ToLower(strings.TrimSpace(term))
+	if utf8.RuneCountInString(term) < minSearchTermLength {
+		return "", "", 0, fmt.Errorf("search term must be at least %d characters", minSearchTermLength)
+	}
+
+	if limit == 0 {
+		limit = DefaultSearchLimit
+	}
+	if limit > MaxSearchLimit {
+		return "", "", 0, fmt.Errorf("limit must be between 1 and %d", MaxSearchLimit)
+	}
+
+	prefix := string([]rune(term)[:searchPrefixLength])
+
+	return term, prefix, limit, nil
+
+}
+
+// searchOperators returns the operators of the queries that select the candidates of a search. The
+// first selects the names that start with the whole term, the second the names that share a prefix
+// with the term and may be a few typos away from it. Both select the shortest names first. For the
+// first query that is the order searchRank ranks prefixed names in, so its limit never cuts off a
+// name that would have ranked above one it keeps. The edit distance of the second query's names is
+// not known until they are ranked, so when more than searchCandidateLimit names share the prefix a
+// longer name that is a closer match may be left out in favour of a shorter one that is further off
+func searchOperators(term, prefix string, limit uint) [][]*athena.Operator {
+
+	order := []*athena.Operator{
+		athena.NewOrderOperator("CHAR_LENGTH(name)", athena.SortAsc),
+		athena.NewOrderOperator("name", athena.SortAsc),
+	}
+
+	return [][]*athena.Operator{
+		append([]*athena.Operator{athena.NewPrefixOperator("name", term), athena.NewLimitOperator(int64(limit))}, order...),
+		append([]*athena.Operator{athena.NewPrefixOperator("name", prefix), athena.NewLimitOperator(searchCandidateLimit)}, order...),
+	}
+
+}
+
+// searchRank orders names by how closely they match term and returns the indexes of the best limit
+// names. Names that start with term rank first, shortest first, so that "rift" ranks Rifter above
+// Rifter Blueprint. The remaining names are ranked by the edit distance between term and the start
+// of the name, and names that are further than a third of the term away are dropped
+func searchRank(term string, names []string, limit uint) []int {
+
+	type match struct {
+		index    int
+		prefixed bool
+		distance int
+		name     string
+	}
+
+	termLength := utf8.RuneCountInString(term)
+	maxDistance := termLength / 3
+
+	matches := make([]match, 0, len(names))
+	for i, name := range names {
+		lower := strings.ToLower(name)
+		if strings.HasPrefix(lower, term) {
+			matches = append(matches, match{index: i, prefixed: true, distance: utf8.RuneCountInString(lower) - termLength, name: lower})
+			continue
+		}
+
+		start := []rune(lower)
+		if len(start) > termLength {
+			start = start[:termLength]
+		}
+
+		distance := levenshtein([]rune(term), start)
+		if distance > maxDistance {
+			continue
+		}
+
+		matches = append(matches, match{index: i, distance: distance, name: lower})
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].prefixed != matches[j].prefixed {
+			return matches[i].prefixed
+		}
+		if matches[i].distance != matches[j].distance {
+			return matches[i].distance < matches[j].distance
+		}
+
+		return matches[i].name < matches[j].name
+	})
+
+	if uint(len(matches)) > limit {
+		matches = matches[:limit]
+	}
+
+	indexes := make([]int, 0, len(matches))
+	for _, m := range matches {
+		indexes = append(indexes, m.index)
+	}
+
+	return indexes
+
+}
+
+// levenshtein returns the number of single character insertions, deletions and substitutions needed
+// to turn a into b
+func levenshtein(a, b []rune) int {
+
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			current[j] = minInt(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+
+}
+
+func minInt(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+
+	return m
+}
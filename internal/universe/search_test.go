@@ -0,0 +1,119 @@
+package universe
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSearchRank(t *testing.T) {
+
+	var tests = []struct {
+		name  string
+		term  string
+		names []string
+		limit uint
+		want  []int
+	}{
+		{
+			name:  "prefixed names rank shortest first",
+			term:  "rift",
+			names: []string{"Rifter Blueprint", "Rifter", "Rift"},
+			limit: 10,
+			want:  []int{2, 1, 0},
+		},
+		{
+			name:  "prefixed names rank above closer typos",
+			term:  "raven",
+			names: []string{"Ravem", "Raven Navy Issue"},
+			limit: 10,
+			want:  []int{1, 0},
+		},
+		{
+			name:  "typos are ranked by distance",
+			term:  "tristan",
+			names: []string{"Trastin", "Tristen"},
+			limit: 10,
+			want:  []int{1, 0},
+		},
+		{
+			name:  "typos beyond a third of the term are dropped",
+			term:  "raven",
+			names: []string{"Rabem", "Ravem"},
+			limit: 10,
+			want:  []int{1},
+		},
+		{
+			name:  "short terms tolerate no typos",
+			term:  "ab",
+			names: []string{"Ac", "Abc"},
+			limit: 10,
+			want:  []int{1},
+		},
+		{
+			name:  "ties are broken by name regardless of case",
+			term:  "jita",
+			names: []string{"jitb", "Jita IV", "JITA II"},
+			limit: 10,
+			want:  []int{2, 1, 0},
+		},
+		{
+			name:  "limit",
+			term:  "rift",
+			names: []string{"Rifter Blueprint", "Rifter", "Rift"},
+			limit: 2,
+			want:  []int{2, 1},
+		},
+		{
+			name:  "empty term matches every name as a prefix",
+			term:  "",
+			names: []string{"Rifter", "Ibis"},
+			limit: 10,
+			want:  []int{1, 0},
+		},
+		{
+			name:  "no names",
+			term:  "rift",
+			names: nil,
+			limit: 10,
+			want:  []int{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := searchRank(test.term, test.names, test.limit)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("searchRank(%q) = %v, want %v", test.term, got, test.want)
+			}
+		})
+	}
+
+}
+
+func TestLevenshtein(t *testing.T) {
+
+	var tests = []struct {
+		name string
+		a, b string
+		want int
+	}{
+		{name: "both empty", a: "", b: "", want: 0},
+		{name: "a empty", a: "", b: "abc", want: 3},
+		{name: "b empty", a: "abc", b: "", want: 3},
+		{name: "equal", a: "rifter", b: "rifter", want: 0},
+		{name: "substitution", a: "raven", b: "ravem", want: 1},
+		{name: "insertion", a: "rift", b: "rifts", want: 1},
+		{name: "deletion", a: "drift", b: "rift", want: 1},
+		{name: "mixed", a: "kitten", b: "sitting", want: 3},
+		{name: "runes", a: "jötunn", b: "jotunn", want: 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := levenshtein([]rune(test.a), []rune(test.b)); got != test.want {
+				t.Errorf("levenshtein(%q, %q) = %d, want %d", test.a, test.b, got, test.want)
+			}
+		})
+	}
+
+}
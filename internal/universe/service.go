@@ -39,6 +39,9 @@ type Service interface {
 	Structures(ctx context.Context, operators ...*athena.Operator) ([]*athena.Structure, error)
 	Type(ctx context.Context, id uint) (*athena.Type, error)
 	Types(ctx context.Context, operators ...*athena.Operator) ([]*athena.Type, error)
+
+	SearchTypes(ctx context.Context, term string, limit uint) ([]*athena.Type, error)
+	SearchSolarSystems(ctx context.Context, term string, limit uint) ([]*athena.SolarSystem, error)
 }
 
 type service struct {
@@ -22,13 +22,17 @@ import (
 type Service interface {
 	// Fetch Member Balance fetches the provided characters balance from ESI and stores it in the repository
 	FetchMemberBalance(ctx context.Context, member *athena.Member) (*athena.Etag, error)
-	MemberBalance(ctx context.Context, member *athena.Member) (*athena.MemberWalletBalance, error)
+	MemberBalance(ctx context.Context, memberID uint) (*athena.MemberWalletBalance, error)
 
 	// Fetch Member Wallet Transactions fetches the provided characters transactions from ESI and stores them in the repository
 	FetchMemberWalletTransactions(ctx context.Context, member *athena.Member) (*athena.Etag, error)
+	MemberWalletTransactions(ctx context.Context, memberID uint, page *athena.Page, operators ...*athena.Operator) ([]*athena.MemberWalletTransaction, error)
+	MemberWalletTransactionCount(ctx context.Context, memberID uint, operators ...*athena.Operator) (uint, error)
 
 	// Fetch Member Wallet Journals fetches the provided characters jounral entries from ESI and stores them in the repository
 	FetchMemberWalletJournals(ctx context.Context, member *athena.Member) (*athena.Etag, error)
+	MemberWalletJournals(ctx context.Context, memberID uint, page *athena.Page, operators ...*athena.Operator) ([]*athena.MemberWalletJournal, error)
+	MemberWalletJournalCount(ctx context.Context, memberID uint, operators ...*athena.Operator) (uint, error)
 }
 
 type service struct {
@@ -88,7 +92,7 @@ func (s *service) FetchMemberBalance(ctx context.Context, member *athena.Member)
 	rawBalance, etag, _, err := s.esi.GetCharacterWalletBalance(ctx, member.ID, member.AccessToken.String)
 	if err != nil {
 		entry.WithError(err).Error("failed to fetch member balance from ESI")
-		return nil, fmt.Errorf("failed to fetch member balance from ESI")
+		return nil, fmt.Errorf("failed to fetch member balance from ESI: %w", err)
 	}
 
 	balance, err := s.wallet.MemberWalletBalance(ctx, member.ID)
@@ -127,14 +131,16 @@ func (s *service) FetchMemberBalance(ctx context.Context, member *athena.Member)
 
 }
 
-func (s *service) MemberBalance(ctx context.Context, member *athena.Member) (*athena.MemberWalletBalance, error) {
+func (s *service) MemberBalance(ctx context.Context, memberID uint) (*athena.MemberWalletBalance, error) {
 
 	entry := s.logger.WithContext(ctx).WithFields(logrus.Fields{
-		"member_id": member.ID,
+		"member_id": memberID,
 		"service":   serviceIdentifier,
 		"method":    "MemberBalance",
 	})
 
+	member := &athena.Member{ID: memberID}
+
 	balance, err := s.cache.MemberWalletBalance(ctx, member)
 	if err != nil {
 		entry.WithError(err).Error("failed to fetch member wallet balance from cache")
@@ -142,14 +148,14 @@ func (s *service) MemberBalance(ctx context.Context, member *athena.Member) (*at
 	}
 
 	if balance == nil {
-		balance, err = s.wallet.MemberWalletBalance(ctx, member.ID)
+		balance, err = s.wallet.MemberWalletBalance(ctx, memberID)
 		if err != nil && !errors.Is(err, sql.ErrNoRows) {
 			entry.WithError(err).Error("failed to fetch member wallet balance from db")
 			return nil, fmt.Errorf("failed to fetch member wallet balance from db")
 		}
 
 		if balance == nil || errors.Is(err, sql.ErrNoRows) {
-			return nil, fmt.Errorf("no balance could be found for the specified character")
+			return nil, nil
 		}
 
 		err = s.cache.SetMemberWalletBalance(ctx, member, balance)
@@ -183,7 +189,7 @@ func (s *service) FetchMemberWalletTransactions(ctx context.Context, member *ath
 	_, _, err = s.esi.HeadCharacterWalletTransactions(ctx, member.ID, 0, member.AccessToken.String)
 	if err != nil {
 		entry.WithError(err).Error("failed to exec head request for member wallet transactions from ESI")
-		return nil, fmt.Errorf("failed to exec head request for member wallet transactions from ESI")
+		return nil, fmt.Errorf("failed to exec head request for member wallet transactions from ESI: %w", err)
 	}
 
 	from := uint64(0)
@@ -194,7 +200,7 @@ func (s *service) FetchMemberWalletTransactions(ctx context.Context, member *ath
 		ptransactions, _, _, err := s.esi.GetCharacterWalletTransactions(ctx, member.ID, from, member.AccessToken.String)
 		if err != nil {
 			entry.WithError(err).Error("failed to fetch member wallet transactions from ESI")
-			return nil, fmt.Errorf("failed to fetch member wallet transactions from ESI")
+			return nil, fmt.Errorf("failed to fetch member wallet transactions from ESI: %w", err)
 		}
 
 		if len(ptransactions) > 0 {
@@ -363,7 +369,7 @@ func (s *service) FetchMemberWalletJournals(ctx context.Context, member *athena.
 	etag, res, err := s.esi.HeadCharacterWalletJournals(ctx, member.ID, 1, member.AccessToken.String)
 	if err != nil {
 		entry.WithError(err).Error("failed to exec head request for member wallet journals from ESI")
-		return nil, fmt.Errorf("failed to exec head request for member wallet journals from ESI")
+		return nil, fmt.Errorf("failed to exec head request for member wallet journals from ESI: %w", err)
 	}
 
 	pages := esi.RetrieveXPagesFromHeader(res.Header)
@@ -375,7 +381,7 @@ func (s *service) FetchMemberWalletJournals(ctx context.Context, member *athena.
 		entries, _, _, err := s.esi.GetCharacterWalletJournals(ctx, member.ID, page, member.AccessToken.String)
 		if err != nil {
 			entry.WithError(err).Error("failed to fetch member wallet journals from ESI")
-			return nil, fmt.Errorf("failed to fetch member wallet journals from ESI")
+			return nil, fmt.Errorf("failed to fetch member wallet journals from ESI: %w", err)
 		}
 
 		if len(entries) > 0 {
@@ -515,3 +521,87 @@ func (s *service) resolveContextIDType(ctx context.Context, member *athena.Membe
 		}
 	}
 }
+
+func (s *service) MemberWalletTransactions(ctx context.Context, memberID uint, page *athena.Page, operators ...*athena.Operator) ([]*athena.MemberWalletTransaction, error) {
+
+	entry := s.logger.WithContext(ctx).WithFields(logrus.Fields{
+		"member_id": memberID,
+		"service":   serviceIdentifier,
+		"method":    "MemberWalletTransactions",
+	})
+
+	operators = append(append([]*athena.Operator{
+		athena.NewEqualOperator("member_id", memberID),
+	}, operators...), page.Operators("transaction_id", athena.SortDesc)...)
+
+	transactions, err := s.wallet.MemberWalletTransactions(ctx, operators...)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		entry.WithError(err).Error("failed to fetch member wallet transactions from db")
+		return nil, fmt.Errorf("failed to fetch member wallet transactions from db")
+	}
+
+	return transactions, nil
+
+}
+
+func (s *service) MemberWalletTransactionCount(ctx context.Context, memberID uint, operators ...*athena.Operator) (uint, error) {
+
+	operators = append([]*athena.Operator{
+		athena.NewEqualOperator("member_id", memberID),
+	}, operators...)
+
+	count, err := s.wallet.CountMemberWalletTransactions(ctx, operators...)
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithFields(logrus.Fields{
+			"member_id": memberID,
+			"service":   serviceIdentifier,
+			"method":    "MemberWalletTransactionCount",
+		}).Error("failed to count member wallet transactions in db")
+		return 0, fmt.Errorf("failed to count member wallet transactions in db")
+	}
+
+	return count, nil
+
+}
+
+func (s *service) MemberWalletJournals(ctx context.Context, memberID uint, page *athena.Page, operators ...*athena.Operator) ([]*athena.MemberWalletJournal, error) {
+
+	entry := s.logger.WithContext(ctx).WithFields(logrus.Fields{
+		"member_id": memberID,
+		"service":   serviceIdentifier,
+		"method":    "MemberWalletJournals",
+	})
+
+	operators = append(append([]*athena.Operator{
+		athena.NewEqualOperator("member_id", memberID),
+	}, operators...), page.Operators("journal_id", athena.SortDesc)...)
+
+	entries, err := s.wallet.MemberWalletJournals(ctx, operators...)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		entry.WithError(err).Error("failed to fetch member wallet journals from db")
+		return nil, fmt.Errorf("failed to fetch member wallet journals from db")
+	}
+
+	return entries, nil
+
+}
+
+func (s *service) MemberWalletJournalCount(ctx context.Context, memberID uint, operators ...*athena.Operator) (uint, error) {
+
+	operators = append([]*athena.Operator{
+		athena.NewEqualOperator("member_id", memberID),
+	}, operators...)
+
+	count, err := s.wallet.CountMemberWalletJournals(ctx, operators...)
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithFields(logrus.Fields{
+			"member_id": memberID,
+			"service":   serviceIdentifier,
+			"method":    "MemberWalletJournalCount",
+		}).Error("failed to count member wallet journals in db")
+		return 0, fmt.Errorf("failed to count member wallet journals in db")
+	}
+
+	return count, nil
+
+}
@@ -24,6 +24,7 @@ type MailRepository interface {
 
 type mailRepository interface {
 	MailHeader(ctx context.Context, mailID uint) (*MailHeader, error)
+	MailHeaders(ctx context.Context, operators ...*Operator) ([]*MailHeader, error)
 	CreateMailHeaders(ctx context.Context, headers []*MailHeader) ([]*MailHeader, error)
 }
 
@@ -34,6 +35,8 @@ type mailRecipientRepository interface {
 
 type memberMailRepository interface {
 	MemberMailHeaders(ctx context.Context, operators ...*Operator) ([]*MemberMailHeader, error)
+	SearchMemberMailHeaders(ctx context.Context, memberID uint, filter *MemberMailHeaderFilter, operators ...*Operator) ([]*MemberMailHeader, error)
+	CountMemberMailHeaders(ctx context.Context, memberID uint, filter *MemberMailHeaderFilter) (uint, error)
 	CreateMemberMailHeaders(ctx context.Context, memberID uint, headers []*MemberMailHeader) ([]*MemberMailHeader, error)
 	UpdateMemberMailHeaders(ctx context.Context, memberID uint, headers []*MemberMailHeader) ([]*MemberMailHeader, error)
 }
@@ -66,6 +69,15 @@ type MemberMailHeader struct {
 	UpdatedAt time.Time `db:"updated_at" json:"updated_at"`
 }
 
+// MemberMailHeaderFilter narrows down the mail headers of a member. Fields that are not valid are ignored
+type MemberMailHeaderFilter struct {
+	LabelID       null.Uint
+	SenderID      null.Uint
+	RecipientType RecipientType
+	From          null.Time
+	To            null.Time
+}
+
 type MailHeader struct {
 	MailID     uint             `db:"id" json:"mail_id"`
 	Sender     null.Uint        `db:"sender_id" json:"from,omitempty"`
@@ -168,3 +180,5 @@ type MailingList struct {
 	CreatedAt     time.Time `db:"created_at" json:"created_at" deep:"-"`
 	UpdatedAt     time.Time `db:"updated_at" json:"updated_at" deep:"-"`
 }
+
+func (MailingList) IsMailParty() {}
@@ -12,7 +12,13 @@ type MemberRepository interface {
 	Members(ctx context.Context, operators ...*Operator) ([]*Member, error)
 	CreateMember(ctx context.Context, member *Member) (*Member, error)
 	UpdateMember(ctx context.Context, id uint, member *Member) (*Member, error)
+	UpdateMemberToken(ctx context.Context, id uint, member *Member) (*Member, error)
+	UpdateMemberScopes(ctx context.Context, id uint, scopes MemberScopes) (*Member, error)
 	DeleteMember(ctx context.Context, id uint) (bool, error)
+	// PurgeMember and ResetMember return the hashes of the API keys that were revoked or narrowed
+	// down because they were created by or granted access to the member
+	PurgeMember(ctx context.Context, id uint, entry *AuditEntry) ([]string, error)
+	ResetMember(ctx context.Context, id uint, entry *AuditEntry) ([]string, error)
 }
 
 type Member struct {
@@ -22,6 +28,8 @@ type Member struct {
 	RefreshToken      null.String  `db:"refresh_token" json:"refresh_token"`
 	Expires           null.Time    `db:"expires," json:"expires"`
 	OwnerHash         null.String  `db:"owner_hash" json:"owner_hash"`
+	OwnershipEpoch    uint         `db:"ownership_epoch" json:"ownership_epoch"`
+	OwnerChangedAt    null.Time    `db:"owner_changed_at,omitempty" json:"owner_changed_at"`
 	Scopes            MemberScopes `db:"scopes,omitempty" json:"scopes,omitempty"`
 	IsNew             bool         `db:"-" json:"-"`
 	Disabled          bool         `db:"disabled" json:"disabled"`
@@ -32,6 +40,13 @@ type Member struct {
 	UpdatedAt         time.Time    `db:"updated_at" json:"updated_at"`
 }
 
+// TokenRefreshFailure is emitted when the refresh token of a member could not be exchanged for a new access token
+type TokenRefreshFailure struct {
+	MemberID  uint      `json:"member_id"`
+	Error     string    `json:"error"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
 type MemberLogin struct {
 	ID        uint      `db:"_id,omitempty" json:"_id"`
 	CreatedAt time.Time `db:"created_at" json:"created_at"`
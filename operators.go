@@ -20,6 +20,7 @@ const (
 	InOp                 operation = "in"
 	NotInOp              operation = "not in"
 	LikeOp               operation = "like"
+	PrefixOp             operation = "prefix"
 
 	LimitOp  operation = "limit"
 	OrderOp  operation = "order"
@@ -39,6 +40,7 @@ var AllOperations = []operation{
 	InOp,
 	NotInOp,
 	LikeOp,
+	PrefixOp,
 	LimitOp,
 	OrderOp,
 	SkipOp,
@@ -51,7 +53,7 @@ func (o operation) IsValid() bool {
 	switch o {
 	case EqualOp, NotEqualOp,
 		GreaterThanOp, LessThanOp, GreaterThanEqualToOp, LessThanEqualToOp,
-		InOp, NotInOp, LikeOp,
+		InOp, NotInOp, LikeOp, PrefixOp,
 		LimitOp, OrderOp, SkipOp, OrOp, AndOp, ExistsOp:
 		return true
 	}
@@ -74,6 +76,16 @@ func NewLikeOperator(column string, value interface{}) *Operator {
 	}
 }
 
+// NewPrefixOperator matches the values of column that start with value. Unlike NewLikeOperator, an
+// index on column can be used to satisfy it
+func NewPrefixOperator(column string, value interface{}) *Operator {
+	return &Operator{
+		Column:    column,
+		Operation: PrefixOp,
+		Value:     value,
+	}
+}
+
 func NewEqualOperator(column string, value interface{}) *Operator {
 	return &Operator{
 		Column:    column,
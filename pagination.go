@@ -0,0 +1,218 @@
+package athena
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultPageSize uint = 50
+	MaxPageSize     uint = 250
+
+	cursorPrefix = "cursor:"
+)
+
+// Page describes a single page of a keyset paginated list. Records are walked in order of a
+// stable key so that a page does not shift when the processor rewrites rows around it.
+type Page struct {
+	First uint
+	After *uint64
+	Sort  *PageSort
+
+	// afterValue is the value of the sort column for the record at After
+	afterValue interface{}
+}
+
+// PageSort orders a page by Column before the key of the list. Records that share a value in
+// Column fall back to the order of the key, so every record keeps a stable position. Column
+// must not be nullable.
+//
+// The value of the sort column is carried in the cursor with its type, so that the next page
+// compares it to the column as a number or a time rather than as a string. FLOAT columns must be
+// sorted on a float32 value, a float64 does not equal the single precision value that MySQL
+// stores and the records sharing it would be skipped or repeated
+type PageSort struct {
+	Column string
+	Sort   Sort
+}
+
+// NewPage builds a Page from the first and after arguments of a Relay connection. A nil first
+// defaults to DefaultPageSize and an empty after starts at the beginning of the list. Cursors
+// are only valid for the sort that they were issued under
+func NewPage(first *uint, after *string, sort *PageSort) (*Page, error) {
+
+	page := &Page{First: DefaultPageSize, Sort: sort}
+	if first != nil {
+		if *first == 0 || *first > MaxPageSize {
+			return nil, fmt.Errorf("first must be between 1 and %d", MaxPageSize)
+		}
+
+		page.First = *first
+	}
+
+	if after != nil && *after != "" {
+		key, value, err := Cursor(*after).Decode()
+		if err != nil {
+			return nil, err
+		}
+
+		if (value == nil) != (sort == nil) {
+			return nil, fmt.Errorf("cursor %s was not issued for the requested sort", *after)
+		}
+
+		page.After = &key
+		page.afterValue = value
+	}
+
+	return page, nil
+
+}
+
+// Operators returns the operators that select the page, ordered by column. One record more than
+// First is requested so that the caller can tell whether another page follows this one. A nil
+// Page selects every record. ESI issues journal, transaction and mail IDs in order, so lists that
+// are browsed newest first walk those IDs descending instead of keying on a date
+func (p *Page) Operators(column string, sort Sort) []*Operator {
+
+	if p == nil {
+		return []*Operator{NewOrderOperator(column, sort)}
+	}
+
+	if p.Sort != nil {
+		return p.sortedOperators(column)
+	}
+
+	operators := make([]*Operator, 0, 3)
+	if p.After != nil {
+		operators = append(operators, keysetOperator(column, sort, *p.After))
+	}
+
+	return append(operators,
+		NewOrderOperator(column, sort),
+		NewLimitOperator(int64(p.First)+1),
+	)
+
+}
+
+func (p *Page) sortedOperators(column string) []*Operator {
+
+	operators := make([]*Operator, 0, 4)
+	if p.After != nil {
+		operators = append(operators, NewOrOperator(
+			keysetOperator(p.Sort.Column, p.Sort.Sort, p.afterValue),
+			NewAndOperator(
+				NewEqualOperator(p.Sort.Column, p.afterValue),
+				keysetOperator(column, p.Sort.Sort, *p.After),
+			),
+		))
+	}
+
+	return append(operators,
+		NewOrderOperator(p.Sort.Column, p.Sort.Sort),
+		NewOrderOperator(column, p.Sort.Sort),
+		NewLimitOperator(int64(p.First)+1),
+	)
+
+}
+
+func keysetOperator(column string, sort Sort, value interface{}) *Operator {
+	if sort == SortDesc {
+		return NewLessThanOperator(column, value)
+	}
+
+	return NewGreaterThanOperator(column, value)
+}
+
+// Cursor is an opaque reference to the key of a record in a keyset paginated list. Cursors for
+// sorted pages also carry the type and value of the sort column for the record
+type Cursor string
+
+const (
+	cursorValueFloat32 = "float32"
+	cursorValueFloat64 = "float64"
+	cursorValueTime    = "time"
+	cursorValueString  = "string"
+)
+
+func NewCursor(key uint64) Cursor {
+	return encodeCursor(strconv.FormatUint(key, 10))
+}
+
+// NewSortedCursor returns a Cursor for a record of a page ordered by a PageSort, value is the
+// value of the record in the sort column. Times keep their offset so that the driver converts
+// them to the location of the connection, the same way it does for the times that it reads
+func NewSortedCursor(key uint64, value interface{}) Cursor {
+
+	var kind, formatted string
+	switch v := value.(type) {
+	case time.Time:
+		kind, formatted = cursorValueTime, v.Format(time.RFC3339Nano)
+	case float32:
+		kind, formatted = cursorValueFloat32, strconv.FormatFloat(float64(v), 'g', -1, 32)
+	case float64:
+		kind, formatted = cursorValueFloat64, strconv.FormatFloat(v, 'g', -1, 64)
+	case fmt.Stringer:
+		kind, formatted = cursorValueString, v.String()
+	default:
+		kind, formatted = cursorValueString, fmt.Sprintf("%v", v)
+	}
+
+	return encodeCursor(strings.Join([]string{strconv.FormatUint(key, 10), kind, formatted}, ":"))
+
+}
+
+func encodeCursor(s string) Cursor {
+	return Cursor(base64.StdEncoding.EncodeToString([]byte(cursorPrefix + s)))
+}
+
+// Decode returns the key that the cursor points at along with the value of the sort column if
+// the cursor was issued for a sorted page. The value has the type that it was issued with
+func (c Cursor) Decode() (uint64, interface{}, error) {
+
+	data, err := base64.StdEncoding.DecodeString(string(c))
+	if err != nil || !strings.HasPrefix(string(data), cursorPrefix) {
+		return 0, nil, fmt.Errorf("invalid cursor %s", c)
+	}
+
+	parts := strings.SplitN(strings.TrimPrefix(string(data), cursorPrefix), ":", 3)
+	key, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return 0, nil, fmt.Errorf("invalid cursor %s", c)
+	}
+
+	switch len(parts) {
+	case 1:
+		return key, nil, nil
+	case 2:
+		return 0, nil, fmt.Errorf("invalid cursor %s", c)
+	}
+
+	var value interface{}
+	switch parts[1] {
+	case cursorValueTime:
+		value, err = time.Parse(time.RFC3339Nano, parts[2])
+	case cursorValueFloat32:
+		var f float64
+		f, err = strconv.ParseFloat(parts[2], 32)
+		value = float32(f)
+	case cursorValueFloat64:
+		value, err = strconv.ParseFloat(parts[2], 64)
+	case cursorValueString:
+		value = parts[2]
+	default:
+		err = fmt.Errorf("unknown value type %s", parts[1])
+	}
+	if err != nil {
+		return 0, nil, fmt.Errorf("invalid cursor %s", c)
+	}
+
+	return key, value, nil
+
+}
+
+func (c Cursor) String() string {
+	return string(c)
+}
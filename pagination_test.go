@@ -0,0 +1,85 @@
+package athena
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSortedCursorRoundTrip(t *testing.T) {
+
+	tests := []struct {
+		name  string
+		value interface{}
+		want  interface{}
+	}{
+		{
+			name:  "float32 keeps single precision",
+			value: float32(5.3),
+			want:  float32(5.3),
+		},
+		{
+			name:  "float64",
+			value: 0.1 + 0.2,
+			want:  0.1 + 0.2,
+		},
+		{
+			name:  "time keeps offset",
+			value: time.Date(2021, 2, 1, 12, 30, 15, 0, time.FixedZone("", 2*60*60)),
+			want:  time.Date(2021, 2, 1, 10, 30, 15, 0, time.UTC),
+		},
+		{
+			name:  "string containing the separator",
+			value: "character:corporation",
+			want:  "character:corporation",
+		},
+		{
+			name:  "integer",
+			value: 42,
+			want:  "42",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			key, value, err := NewSortedCursor(90000001, test.value).Decode()
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if key != 90000001 {
+				t.Errorf("key = %d, want 90000001", key)
+			}
+
+			if want, ok := test.want.(time.Time); ok {
+				got, ok := value.(time.Time)
+				if !ok || !got.Equal(want) {
+					t.Errorf("value = %#v, want %s", value, want)
+				}
+				return
+			}
+
+			if value != test.want {
+				t.Errorf("value = %#v, want %#v", value, test.want)
+			}
+		})
+	}
+
+}
+
+func TestCursorDecodeRejectsInvalidCursors(t *testing.T) {
+
+	for _, cursor := range []Cursor{
+		"not base64!",
+		encodeCursor("abc"),
+		encodeCursor("1:float64"),
+		encodeCursor("1:float64:abc"),
+		encodeCursor("1:unknown:abc"),
+		Cursor("bm90IGEgY3Vyc29y"),
+	} {
+		_, _, err := cursor.Decode()
+		if err == nil {
+			t.Errorf("expected %s to be rejected", cursor)
+		}
+	}
+
+}
@@ -0,0 +1,156 @@
+package athena
+
+import (
+	"context"
+	"time"
+)
+
+type RedFlagRepository interface {
+	MemberRedFlags(ctx context.Context, memberID uint, operators ...*Operator) ([]*MemberRedFlag, error)
+	ReplaceMemberRedFlags(ctx context.Context, memberID uint, flags []*MemberRedFlag) ([]*MemberRedFlag, error)
+}
+
+// RedFlagRule describes a warning sign that recruiters look for in the data of a member. Every record
+// of Entity whose Field compares to Threshold under Operator, and that satisfies each of the Where
+// conditions, raises a red flag of Severity for the member
+type RedFlagRule struct {
+	ID          string              `json:"id" yaml:"id"`
+	Description string              `json:"description" yaml:"description"`
+	Entity      RedFlagEntity       `json:"entity" yaml:"entity"`
+	Field       string              `json:"field" yaml:"field"`
+	Operator    RedFlagOperator     `json:"operator" yaml:"operator"`
+	Threshold   interface{}         `json:"threshold" yaml:"threshold"`
+	Severity    RedFlagSeverity     `json:"severity" yaml:"severity"`
+	Where       []*RedFlagCondition `json:"where,omitempty" yaml:"where,omitempty"`
+}
+
+type RedFlagCondition struct {
+	Field     string          `json:"field" yaml:"field"`
+	Operator  RedFlagOperator `json:"operator" yaml:"operator"`
+	Threshold interface{}     `json:"threshold" yaml:"threshold"`
+}
+
+// MemberRedFlag is a record of a member that matched a red flag rule. EntityID identifies the record
+// within the entity of the rule and Value holds the value of the field of the rule at the time the
+// record was evaluated
+type MemberRedFlag struct {
+	MemberID    uint            `db:"member_id" json:"member_id"`
+	RuleID      string          `db:"rule_id" json:"rule_id"`
+	Entity      RedFlagEntity   `db:"entity" json:"entity"`
+	EntityID    string          `db:"entity_id" json:"entity_id"`
+	Severity    RedFlagSeverity `db:"severity" json:"severity"`
+	Description string          `db:"description" json:"description"`
+	Value       string          `db:"value" json:"value"`
+	CreatedAt   time.Time       `db:"created_at" json:"created_at"`
+	UpdatedAt   time.Time       `db:"updated_at" json:"updated_at"`
+}
+
+type RedFlagEntity string
+
+const (
+	RedFlagEntityWalletJournal      RedFlagEntity = "wallet_journal"
+	RedFlagEntityContact            RedFlagEntity = "contact"
+	RedFlagEntityContract           RedFlagEntity = "contract"
+	RedFlagEntityMail               RedFlagEntity = "mail"
+	RedFlagEntityCorporationHistory RedFlagEntity = "corporation_history"
+)
+
+var AllRedFlagEntities = []RedFlagEntity{
+	RedFlagEntityWalletJournal,
+	RedFlagEntityContact,
+	RedFlagEntityContract,
+	RedFlagEntityMail,
+	RedFlagEntityCorporationHistory,
+}
+
+func (e RedFlagEntity) Valid() bool {
+	for _, v := range AllRedFlagEntities {
+		if v == e {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (e RedFlagEntity) String() string {
+	return string(e)
+}
+
+type RedFlagOperator string
+
+const (
+	RedFlagOperatorEqual              RedFlagOperator = "eq"
+	RedFlagOperatorNotEqual           RedFlagOperator = "ne"
+	RedFlagOperatorGreaterThan        RedFlagOperator = "gt"
+	RedFlagOperatorGreaterThanEqualTo RedFlagOperator = "gte"
+	RedFlagOperatorLessThan           RedFlagOperator = "lt"
+	RedFlagOperatorLessThanEqualTo    RedFlagOperator = "lte"
+	RedFlagOperatorContains           RedFlagOperator = "contains"
+	RedFlagOperatorNotContains        RedFlagOperator = "not_contains"
+	RedFlagOperatorIn                 RedFlagOperator = "in"
+	RedFlagOperatorNotIn              RedFlagOperator = "not_in"
+)
+
+var AllRedFlagOperators = []RedFlagOperator{
+	RedFlagOperatorEqual,
+	RedFlagOperatorNotEqual,
+	RedFlagOperatorGreaterThan,
+	RedFlagOperatorGreaterThanEqualTo,
+	RedFlagOperatorLessThan,
+	RedFlagOperatorLessThanEqualTo,
+	RedFlagOperatorContains,
+	RedFlagOperatorNotContains,
+	RedFlagOperatorIn,
+	RedFlagOperatorNotIn,
+}
+
+func (o RedFlagOperator) Valid() bool {
+	for _, v := range AllRedFlagOperators {
+		if v == o {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (o RedFlagOperator) String() string {
+	return string(o)
+}
+
+type RedFlagSeverity string
+
+const (
+	RedFlagSeverityLow      RedFlagSeverity = "low"
+	RedFlagSeverityMedium   RedFlagSeverity = "medium"
+	RedFlagSeverityHigh     RedFlagSeverity = "high"
+	RedFlagSeverityCritical RedFlagSeverity = "critical"
+)
+
+// AllRedFlagSeverities is ordered from the least to the most severe
+var AllRedFlagSeverities = []RedFlagSeverity{
+	RedFlagSeverityLow,
+	RedFlagSeverityMedium,
+	RedFlagSeverityHigh,
+	RedFlagSeverityCritical,
+}
+
+func (s RedFlagSeverity) Valid() bool {
+	return s.Rank() >= 0
+}
+
+// Rank returns the position of the severity in AllRedFlagSeverities, or -1 when the severity is not valid
+func (s RedFlagSeverity) Rank() int {
+	for i, v := range AllRedFlagSeverities {
+		if v == s {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (s RedFlagSeverity) String() string {
+	return string(s)
+}
@@ -0,0 +1,94 @@
+package athena
+
+import (
+	"time"
+
+	"github.com/volatiletech/null"
+)
+
+type RefreshJobStatus string
+
+const (
+	RefreshJobQueued     RefreshJobStatus = "queued"
+	RefreshJobProcessing RefreshJobStatus = "processing"
+	RefreshJobCompleted  RefreshJobStatus = "completed"
+	RefreshJobFailed     RefreshJobStatus = "failed"
+)
+
+func (s RefreshJobStatus) String() string {
+	return string(s)
+}
+
+type RefreshScopeStatus string
+
+const (
+	RefreshScopePending    RefreshScopeStatus = "pending"
+	RefreshScopeProcessing RefreshScopeStatus = "processing"
+	RefreshScopeCompleted  RefreshScopeStatus = "completed"
+	RefreshScopeSkipped    RefreshScopeStatus = "skipped"
+	RefreshScopeFailed     RefreshScopeStatus = "failed"
+)
+
+func (s RefreshScopeStatus) String() string {
+	return string(s)
+}
+
+// RefreshJob is an on demand request to sync the scopes of a member. Jobs are processed ahead of
+// the regular processor queue and publish their progress every time the status of a scope changes.
+// Forced jobs reset the etags of every scope first, so that data is requested from ESI even when
+// the previous response has not expired yet
+type RefreshJob struct {
+	ID          string             `json:"id"`
+	MemberID    uint               `json:"member_id"`
+	Force       bool               `json:"force"`
+	Status      RefreshJobStatus   `json:"status"`
+	Scopes      []*RefreshJobScope `json:"scopes"`
+	Error       null.String        `json:"error,omitempty"`
+	CreatedAt   time.Time          `json:"created_at"`
+	CompletedAt null.Time          `json:"completed_at,omitempty"`
+}
+
+type RefreshJobScope struct {
+	Scope     Scope              `json:"scope"`
+	Status    RefreshScopeStatus `json:"status"`
+	Error     null.String        `json:"error,omitempty"`
+	UpdatedAt time.Time          `json:"updated_at"`
+}
+
+func NewRefreshJob(id string, memberID uint, scopes []Scope, force bool) *RefreshJob {
+
+	now := time.Now()
+
+	job := &RefreshJob{
+		ID:        id,
+		MemberID:  memberID,
+		Force:     force,
+		Status:    RefreshJobQueued,
+		Scopes:    make([]*RefreshJobScope, 0, len(scopes)),
+		CreatedAt: now,
+	}
+
+	for _, scope := range scopes {
+		job.Scopes = append(job.Scopes, &RefreshJobScope{
+			Scope:     scope,
+			Status:    RefreshScopePending,
+			UpdatedAt: now,
+		})
+	}
+
+	return job
+
+}
+
+// IsDone reports whether the job has finished processing, successfully or not
+func (j *RefreshJob) IsDone() bool {
+	return j.Status == RefreshJobCompleted || j.Status == RefreshJobFailed
+}
+
+// SetStatus updates the status of a scope of the job. A non empty reason is recorded as the
+// error of the scope
+func (j *RefreshJobScope) SetStatus(status RefreshScopeStatus, reason string) {
+	j.Status = status
+	j.Error = null.NewString(reason, reason != "")
+	j.UpdatedAt = time.Now()
+}
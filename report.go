@@ -0,0 +1,159 @@
+package athena
+
+import (
+	"time"
+
+	"github.com/volatiletech/null"
+)
+
+type ReportFormat string
+
+const (
+	ReportFormatJSON     ReportFormat = "json"
+	ReportFormatMarkdown ReportFormat = "markdown"
+	ReportFormatHTML     ReportFormat = "html"
+)
+
+var AllReportFormats = []ReportFormat{
+	ReportFormatJSON,
+	ReportFormatMarkdown,
+	ReportFormatHTML,
+}
+
+func (f ReportFormat) Valid() bool {
+	for _, v := range AllReportFormats {
+		if v == f {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (f ReportFormat) String() string {
+	return string(f)
+}
+
+// MemberReport is a vetting report of a member and every alt that is linked to the main of the
+// member. It is assembled from the data that the processor has already collected, so sections of
+// a character whose scopes have not been granted or synced yet are left empty
+type MemberReport struct {
+	MemberID    uint               `json:"member_id"`
+	MainID      uint               `json:"main_id"`
+	TotalSP     uint               `json:"total_sp"`
+	Characters  []*CharacterReport `json:"characters"`
+	GeneratedAt time.Time          `json:"generated_at"`
+}
+
+type CharacterReport struct {
+	CharacterID        uint                      `json:"character_id"`
+	Name               string                    `json:"name"`
+	IsMain             bool                      `json:"is_main"`
+	Birthday           time.Time                 `json:"birthday"`
+	SecurityStatus     null.Float64              `json:"security_status"`
+	Corporation        *ReportEntity             `json:"corporation"`
+	Alliance           *ReportEntity             `json:"alliance"`
+	CorporationHistory []*ReportCorporationStint `json:"corporation_history"`
+	Skills             *ReportSkills             `json:"skills"`
+	Wallet             *ReportWallet             `json:"wallet"`
+	NegativeContacts   []*ReportContact          `json:"negative_contacts"`
+	RecentContracts    []*ReportContract         `json:"recent_contracts"`
+	Clones             *ReportClones             `json:"clones"`
+	LastSeen           *ReportLastSeen           `json:"last_seen"`
+}
+
+// ReportEntity is a character, corporation, alliance or faction that is referenced by a report
+type ReportEntity struct {
+	ID     uint   `json:"id"`
+	Type   string `json:"type"`
+	Name   string `json:"name"`
+	Ticker string `json:"ticker,omitempty"`
+}
+
+type ReportCorporationStint struct {
+	Corporation *ReportEntity `json:"corporation"`
+	StartDate   time.Time     `json:"start_date"`
+	EndDate     null.Time     `json:"end_date"`
+	TenureDays  uint          `json:"tenure_days"`
+}
+
+type ReportSkills struct {
+	TotalSP       uint                `json:"total_sp"`
+	UnallocatedSP null.Int            `json:"unallocated_sp"`
+	Groups        []*ReportSkillGroup `json:"groups"`
+}
+
+type ReportSkillGroup struct {
+	GroupID     uint   `json:"group_id"`
+	Name        string `json:"name"`
+	Skills      uint   `json:"skills"`
+	Skillpoints uint   `json:"skillpoints"`
+}
+
+type ReportWallet struct {
+	Balance  null.Float64     `json:"balance"`
+	RefTypes []*ReportRefType `json:"ref_types"`
+}
+
+type ReportRefType struct {
+	RefType RefType `json:"ref_type"`
+	Entries uint    `json:"entries"`
+	Income  float64 `json:"income"`
+	Expense float64 `json:"expense"`
+	Net     float64 `json:"net"`
+}
+
+type ReportContact struct {
+	Contact   *ReportEntity `json:"contact"`
+	Standing  float64       `json:"standing"`
+	IsBlocked bool          `json:"is_blocked"`
+	IsWatched bool          `json:"is_watched"`
+}
+
+type ReportContract struct {
+	ContractID uint           `json:"contract_id"`
+	Type       ContractType   `json:"type"`
+	Status     ContractStatus `json:"status"`
+	Title      null.String    `json:"title"`
+	IssuerID   uint64         `json:"issuer_id"`
+	AssigneeID null.Uint      `json:"assignee_id"`
+	AcceptorID null.Uint      `json:"acceptor_id"`
+	Price      null.Float64   `json:"price"`
+	Reward     null.Float64   `json:"reward"`
+	DateIssued time.Time      `json:"date_issued"`
+}
+
+type ReportClones struct {
+	HomeLocation      *ReportLocation    `json:"home_location"`
+	ActiveImplants    []*ReportItem      `json:"active_implants"`
+	JumpClones        []*ReportJumpClone `json:"jump_clones"`
+	LastCloneJumpDate null.Time          `json:"last_clone_jump_date"`
+}
+
+type ReportJumpClone struct {
+	JumpCloneID uint            `json:"jump_clone_id"`
+	Location    *ReportLocation `json:"location"`
+	Implants    []*ReportItem   `json:"implants"`
+}
+
+// ReportLocation is a station, structure or solar system. Name is empty when the location could
+// not be resolved, which happens for structures that the member is not allowed to dock at
+type ReportLocation struct {
+	LocationID   uint64 `json:"location_id"`
+	LocationType string `json:"location_type"`
+	Name         string `json:"name"`
+	SolarSystem  string `json:"solar_system"`
+}
+
+type ReportItem struct {
+	TypeID uint   `json:"type_id"`
+	Name   string `json:"name"`
+}
+
+type ReportLastSeen struct {
+	Location   *ReportLocation `json:"location"`
+	Online     bool            `json:"online"`
+	LastLogin  null.Time       `json:"last_login"`
+	LastLogout null.Time       `json:"last_logout"`
+	UpdatedAt  time.Time       `json:"updated_at"`
+}
@@ -24,6 +24,7 @@ const (
 	ReadImplantsV1   Scope = "esi-clones.read_implants.v1"
 	ReadContactsV1   Scope = "esi-characters.read_contacts.v1"
 	ReadContractsV1  Scope = "esi-contracts.read_character_contracts.v1"
+	ReadFittingsV1   Scope = "esi-fittings.read_fittings.v1"
 	ReadLocationV1   Scope = "esi-location.read_location.v1"
 	ReadOnlineV1     Scope = "esi-location.read_online.v1"
 	ReadShipV1       Scope = "esi-location.read_ship_type.v1"
@@ -35,18 +36,29 @@ const (
 
 var AllScopes = []Scope{
 	ReadAssetsV1, ReadClonesV1, ReadImplantsV1,
-	ReadContactsV1, ReadContractsV1, ReadLocationV1,
-	ReadOnlineV1, ReadShipV1, ReadMailV1,
-	ReadSkillQueueV1, ReadSkillsV1, ReadWalletV1,
+	ReadContactsV1, ReadContractsV1, ReadFittingsV1,
+	ReadLocationV1, ReadOnlineV1, ReadShipV1,
+	ReadMailV1, ReadSkillQueueV1, ReadSkillsV1,
+	ReadWalletV1,
 }
 
 func (s Scope) String() string {
 	return string(s)
 }
 
+// MemberScope is a scope that has been granted by a member. Failures counts the consecutive
+// number of times ESI has refused a request made under the scope. Once that count reaches
+// the revocation threshold the scope is considered revoked and is no longer processed.
 type MemberScope struct {
-	Scope  Scope     `db:"scope" json:"scope"`
-	Expiry null.Time `db:"expiry,omitempty" json:"expiry,omitempty"`
+	Scope    Scope     `db:"scope" json:"scope"`
+	Expiry   null.Time `db:"expiry,omitempty" json:"expiry,omitempty"`
+	Failures uint      `db:"failures" json:"failures,omitempty"`
+	Revoked  null.Time `db:"revoked,omitempty" json:"revoked,omitempty"`
+}
+
+// IsRevoked reports whether ESI has refused enough requests made under the scope to consider it revoked
+func (s MemberScope) IsRevoked() bool {
+	return s.Revoked.Valid
 }
 
 type MemberScopes []MemberScope
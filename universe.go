@@ -195,6 +195,7 @@ type Faction struct {
 }
 
 func (Faction) IsContactInfo() {}
+func (Faction) IsWalletParty() {}
 
 type Group struct {
 	ID         uint      `db:"id" json:"id"`
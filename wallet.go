@@ -25,11 +25,13 @@ type memberWalletBalanceRepository interface {
 
 type memberWalletTransactionRepository interface {
 	MemberWalletTransactions(ctx context.Context, operators ...*Operator) ([]*MemberWalletTransaction, error)
+	CountMemberWalletTransactions(ctx context.Context, operators ...*Operator) (uint, error)
 	CreateMemberWalletTransactions(ctx context.Context, memberID uint, transactions []*MemberWalletTransaction) ([]*MemberWalletTransaction, error)
 }
 
 type memberWalletJournalRepository interface {
 	MemberWalletJournals(ctx context.Context, operators ...*Operator) ([]*MemberWalletJournal, error)
+	CountMemberWalletJournals(ctx context.Context, operators ...*Operator) (uint, error)
 	CreateMemberWalletJournals(ctx context.Context, memberID uint, entries []*MemberWalletJournal) ([]*MemberWalletJournal, error)
 }
 
@@ -355,6 +357,108 @@ func (i RefType) String() string {
 	return string(i)
 }
 
+// Category groups the ref type into one of the broad categories of income and expense that the
+// wallet journal analysis reports on
+func (i RefType) Category() WalletJournalCategory {
+	if category, ok := refTypeCategories[i]; ok {
+		return category
+	}
+
+	return WalletJournalCategoryOther
+}
+
+type WalletJournalCategory string
+
+const (
+	WalletJournalCategoryBounties  WalletJournalCategory = "bounties"
+	WalletJournalCategoryMarket    WalletJournalCategory = "market"
+	WalletJournalCategoryIndustry  WalletJournalCategory = "industry"
+	WalletJournalCategoryDonations WalletJournalCategory = "donations"
+	WalletJournalCategoryContracts WalletJournalCategory = "contracts"
+	WalletJournalCategoryOther     WalletJournalCategory = "other"
+)
+
+var AllWalletJournalCategories = []WalletJournalCategory{
+	WalletJournalCategoryBounties,
+	WalletJournalCategoryMarket,
+	WalletJournalCategoryIndustry,
+	WalletJournalCategoryDonations,
+	WalletJournalCategoryContracts,
+	WalletJournalCategoryOther,
+}
+
+func (i WalletJournalCategory) Valid() bool {
+	for _, v := range AllWalletJournalCategories {
+		if i == v {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (i WalletJournalCategory) String() string {
+	return string(i)
+}
+
+var refTypeCategories = map[RefType]WalletJournalCategory{
+	RefTypeBounty:                    WalletJournalCategoryBounties,
+	RefTypeBountyPrize:               WalletJournalCategoryBounties,
+	RefTypeBountyPrizeCorporationTax: WalletJournalCategoryBounties,
+	RefTypeBountyPrizes:              WalletJournalCategoryBounties,
+	RefTypeBountyReimbursement:       WalletJournalCategoryBounties,
+	RefTypeBountySurcharge:           WalletJournalCategoryBounties,
+	RefTypeEssEscrowTransfer:         WalletJournalCategoryBounties,
+
+	RefTypeBrokersFee:        WalletJournalCategoryMarket,
+	RefTypeMarketEscrow:      WalletJournalCategoryMarket,
+	RefTypeMarketFinePaid:    WalletJournalCategoryMarket,
+	RefTypeMarketTransaction: WalletJournalCategoryMarket,
+	RefTypeTransactionTax:    WalletJournalCategoryMarket,
+
+	RefTypeCopying:                         WalletJournalCategoryIndustry,
+	RefTypeDatacoreFee:                     WalletJournalCategoryIndustry,
+	RefTypeFactorySlotRentalFee:            WalletJournalCategoryIndustry,
+	RefTypeIndustryJobTax:                  WalletJournalCategoryIndustry,
+	RefTypeManufacturing:                   WalletJournalCategoryIndustry,
+	RefTypePlanetaryConstruction:           WalletJournalCategoryIndustry,
+	RefTypePlanetaryExportTax:              WalletJournalCategoryIndustry,
+	RefTypePlanetaryImportTax:              WalletJournalCategoryIndustry,
+	RefTypeReaction:                        WalletJournalCategoryIndustry,
+	RefTypeReprocessingTax:                 WalletJournalCategoryIndustry,
+	RefTypeResearchingMaterialProductivity: WalletJournalCategoryIndustry,
+	RefTypeResearchingTechnology:           WalletJournalCategoryIndustry,
+	RefTypeResearchingTimeProductivity:     WalletJournalCategoryIndustry,
+	RefTypeReverseEngineering:              WalletJournalCategoryIndustry,
+
+	RefTypeCorporationAccountWithdrawal: WalletJournalCategoryDonations,
+	RefTypePlayerDonation:               WalletJournalCategoryDonations,
+	RefTypePlayerTrading:                WalletJournalCategoryDonations,
+
+	RefTypeContractAuctionBid:              WalletJournalCategoryContracts,
+	RefTypeContractAuctionBidCorp:          WalletJournalCategoryContracts,
+	RefTypeContractAuctionBidRefund:        WalletJournalCategoryContracts,
+	RefTypeContractAuctionSold:             WalletJournalCategoryContracts,
+	RefTypeContractBrokersFee:              WalletJournalCategoryContracts,
+	RefTypeContractBrokersFeeCorp:          WalletJournalCategoryContracts,
+	RefTypeContractCollateral:              WalletJournalCategoryContracts,
+	RefTypeContractCollateralDepositedCorp: WalletJournalCategoryContracts,
+	RefTypeContractCollateralPayout:        WalletJournalCategoryContracts,
+	RefTypeContractCollateralRefund:        WalletJournalCategoryContracts,
+	RefTypeContractDeposit:                 WalletJournalCategoryContracts,
+	RefTypeContractDepositCorp:             WalletJournalCategoryContracts,
+	RefTypeContractDepositRefund:           WalletJournalCategoryContracts,
+	RefTypeContractDepositSalesTax:         WalletJournalCategoryContracts,
+	RefTypeContractPrice:                   WalletJournalCategoryContracts,
+	RefTypeContractPricePaymentCorp:        WalletJournalCategoryContracts,
+	RefTypeContractReversal:                WalletJournalCategoryContracts,
+	RefTypeContractReward:                  WalletJournalCategoryContracts,
+	RefTypeContractRewardDeposited:         WalletJournalCategoryContracts,
+	RefTypeContractRewardDepositedCorp:     WalletJournalCategoryContracts,
+	RefTypeContractRewardRefund:            WalletJournalCategoryContracts,
+	RefTypeContractSalesTax:                WalletJournalCategoryContracts,
+}
+
 type NullableContextIDType struct {
 	Valid         bool
 	ContextIDType ContextIDType
@@ -404,7 +508,7 @@ func (i NullableContextIDType) MarshalJSON() ([]byte, error) {
 	if !i.Valid {
 		return null.NullBytes, nil
 	}
-	return []byte(i.ContextIDType.String()), nil
+	return json.Marshal(i.ContextIDType.String())
 }
 
 func (i *NullableContextIDType) UnmarshalJSON(data []byte) error {